@@ -1,27 +1,47 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/usecase"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/infrastructure/config"
 	infraMessaging "github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/infrastructure/messaging"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/infrastructure/persistence"
+	httpHandler "github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/presentation/http"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/auth"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/messaging"
 )
 
 func main() {
+	// One-off commands share the service's configuration but skip messaging and HTTP
+	if len(os.Args) > 1 && os.Args[1] == "reconcile" {
+		os.Exit(runReconcileCommand(os.Args[2:]))
+	}
+
 	log.Println("Starting Inventory Service...")
 
 	// Initialize database
 	db := config.NewDatabase()
 	defer db.Close()
 
-	// Initialize repository
+	// Initialize repositories
 	inventoryRepo := persistence.NewPostgresInventoryRepository(db)
+	warehouseRepo := persistence.NewPostgresWarehouseRepository(db)
+	categoryRepo := persistence.NewPostgresCategoryRepository(db)
+	attributeRepo := persistence.NewPostgresAttributeRepository(db)
+	priceRepo := persistence.NewPostgresPriceRepository(db)
+
+	// Decide how orders are spread over the warehouses
+	allocationStrategy, err := entity.ParseAllocationStrategy(getEnv("ALLOCATION_STRATEGY", string(entity.AllocationSingleLocationFirst)))
+	if err != nil {
+		log.Fatal("Invalid ALLOCATION_STRATEGY:", err)
+	}
 
 	// Initialize RabbitMQ connection
 	rabbitConn, err := messaging.NewRabbitMQConnection(
@@ -46,23 +66,133 @@ func main() {
 		rabbitConn,
 		"ecommerce-events",  // exchange name
 		"inventory-service", // queue name
-		"order.created",     // routing key
+		"order.*",           // routing key
 	)
 	if err != nil {
 		log.Fatal("Failed to create consumer:", err)
 	}
 
 	// Initialize use cases
-	reserveStockUseCase := usecase.NewReserveStockUseCase(inventoryRepo, publisher)
+	checkStockLevelsUseCase := usecase.NewCheckStockLevelsUseCase(inventoryRepo, publisher)
+	publishProductEventsUseCase := usecase.NewPublishProductEventsUseCase(inventoryRepo, publisher)
+	reserveStockUseCase := usecase.NewReserveStockUseCase(inventoryRepo, publisher, allocationStrategy, checkStockLevelsUseCase, publishProductEventsUseCase)
+	receiveStockUseCase := usecase.NewReceiveStockUseCase(inventoryRepo, publisher, checkStockLevelsUseCase, publishProductEventsUseCase)
+	createProductUseCase := usecase.NewCreateProductUseCase(inventoryRepo, publishProductEventsUseCase)
+	createVariantUseCase := usecase.NewCreateVariantUseCase(inventoryRepo, publishProductEventsUseCase)
+	getProductUseCase := usecase.NewGetProductUseCase(inventoryRepo, attributeRepo)
+	listProductsUseCase := usecase.NewListProductsUseCase(inventoryRepo)
+	updateProductUseCase := usecase.NewUpdateProductUseCase(inventoryRepo, checkStockLevelsUseCase, publishProductEventsUseCase)
+	deleteProductUseCase := usecase.NewDeleteProductUseCase(inventoryRepo, publishProductEventsUseCase)
+	setProductActiveUseCase := usecase.NewSetProductActiveUseCase(inventoryRepo, publishProductEventsUseCase)
+	adjustStockUseCase := usecase.NewAdjustStockUseCase(inventoryRepo, receiveStockUseCase, checkStockLevelsUseCase, publishProductEventsUseCase)
+	listMovementsUseCase := usecase.NewListStockMovementsUseCase(inventoryRepo)
+	settleOrderStockUseCase := usecase.NewSettleOrderStockUseCase(inventoryRepo, checkStockLevelsUseCase, publishProductEventsUseCase)
+	reconcileStockUseCase := usecase.NewReconcileStockUseCase(inventoryRepo, checkStockLevelsUseCase, publishProductEventsUseCase)
+	getStockLevelsUseCase := usecase.NewGetStockLevelsUseCase(inventoryRepo)
+	lowStockReportUseCase := usecase.NewGetLowStockReportUseCase(inventoryRepo)
+	importProductsUseCase := usecase.NewImportProductsUseCase(inventoryRepo, publisher, checkStockLevelsUseCase, publishProductEventsUseCase)
+	exportProductsUseCase := usecase.NewExportProductsUseCase(inventoryRepo)
+	createWarehouseUseCase := usecase.NewCreateWarehouseUseCase(warehouseRepo)
+	listWarehousesUseCase := usecase.NewListWarehousesUseCase(warehouseRepo)
+	updateWarehouseUseCase := usecase.NewUpdateWarehouseUseCase(warehouseRepo)
+	createCategoryUseCase := usecase.NewCreateCategoryUseCase(categoryRepo)
+	listCategoriesUseCase := usecase.NewListCategoriesUseCase(categoryRepo)
+	updateCategoryUseCase := usecase.NewUpdateCategoryUseCase(categoryRepo)
+	createAttributeUseCase := usecase.NewCreateAttributeUseCase(attributeRepo)
+	listAttributesUseCase := usecase.NewListAttributesUseCase(attributeRepo)
+	setProductAttributesUseCase := usecase.NewSetProductAttributesUseCase(inventoryRepo, attributeRepo)
+	searchProductsUseCase := usecase.NewSearchProductsUseCase(inventoryRepo, categoryRepo, attributeRepo)
+	getProductPriceUseCase := usecase.NewGetProductPriceUseCase(inventoryRepo, priceRepo)
+	listPriceHistoryUseCase := usecase.NewListPriceHistoryUseCase(inventoryRepo, priceRepo)
+	schedulePriceUseCase := usecase.NewSchedulePriceUseCase(inventoryRepo, priceRepo)
+	listScheduledPricesUseCase := usecase.NewListScheduledPricesUseCase(inventoryRepo, priceRepo)
+	cancelScheduledPriceUseCase := usecase.NewCancelScheduledPriceUseCase(priceRepo)
+	applyScheduledPricesUseCase := usecase.NewApplyScheduledPricesUseCase(inventoryRepo, priceRepo, publishProductEventsUseCase)
+
+	// Periodically check stock levels against the ledger
+	go runPeriodicReconciliation(
+		reconcileStockUseCase,
+		getDurationEnv("RECONCILIATION_INTERVAL", time.Hour),
+		getEnv("RECONCILIATION_REPAIR", "false") == "true",
+	)
+
+	// Start and end scheduled price changes as they come due
+	go runPriceScheduler(applyScheduledPricesUseCase, getDurationEnv("PRICE_SCHEDULER_INTERVAL", time.Minute))
 
 	// Initialize event consumer
-	orderEventConsumer := infraMessaging.NewOrderEventConsumer(consumer, reserveStockUseCase)
+	orderEventConsumer := infraMessaging.NewOrderEventConsumer(consumer, reserveStockUseCase, settleOrderStockUseCase)
 
 	// Start consuming events
 	if err := orderEventConsumer.Start(); err != nil {
 		log.Fatal("Failed to start event consumer:", err)
 	}
 
+	// Initialize HTTP handler
+	productHandler := httpHandler.NewProductHandler(
+		createProductUseCase,
+		getProductUseCase,
+		listProductsUseCase,
+		updateProductUseCase,
+		deleteProductUseCase,
+		setProductActiveUseCase,
+		adjustStockUseCase,
+		listMovementsUseCase,
+		getStockLevelsUseCase,
+		lowStockReportUseCase,
+		createVariantUseCase,
+	)
+	warehouseHandler := httpHandler.NewWarehouseHandler(
+		createWarehouseUseCase,
+		listWarehousesUseCase,
+		updateWarehouseUseCase,
+	)
+	catalogHandler := httpHandler.NewCatalogHandler(
+		createCategoryUseCase,
+		listCategoriesUseCase,
+		updateCategoryUseCase,
+		createAttributeUseCase,
+		listAttributesUseCase,
+		setProductAttributesUseCase,
+		searchProductsUseCase,
+	)
+	priceHandler := httpHandler.NewPriceHandler(
+		getProductPriceUseCase,
+		listPriceHistoryUseCase,
+		schedulePriceUseCase,
+		listScheduledPricesUseCase,
+		cancelScheduledPriceUseCase,
+	)
+	bulkHandler := httpHandler.NewBulkHandler(importProductsUseCase, exportProductsUseCase)
+	adminHandler := httpHandler.NewAdminHandler(reconcileStockUseCase)
+
+	// Verify access tokens issued by the user service
+	tokenVerifier, err := auth.NewVerifier(auth.Config{
+		JWKSURL: os.Getenv("JWKS_URL"),
+		Secret:  os.Getenv("JWT_SECRET"),
+		Issuer:  "user-service",
+	})
+	if err != nil {
+		log.Fatalf("Failed to create token verifier: %v", err)
+	}
+
+	// Setup router and serve the catalog API alongside the event consumer
+	router := httpHandler.SetupRouter(
+		productHandler,
+		bulkHandler,
+		warehouseHandler,
+		catalogHandler,
+		priceHandler,
+		adminHandler,
+		auth.Middleware(tokenVerifier),
+	)
+	port := getEnv("PORT", "8083")
+	go func() {
+		log.Printf("Inventory Service HTTP API starting on port %s", port)
+		if err := router.Run(":" + port); err != nil {
+			log.Fatal("Failed to start server:", err)
+		}
+	}()
+
 	log.Println("✅ Inventory Service is running and listening for events...")
 
 	// Wait for interrupt signal
@@ -79,3 +209,47 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getDurationEnv gets a duration environment variable or returns default value
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// runPeriodicReconciliation reconciles stock levels with the ledger on every tick
+func runPeriodicReconciliation(uc *usecase.ReconcileStockUseCase, interval time.Duration, repair bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		report, err := uc.Execute(context.Background(), repair)
+		if err != nil {
+			log.Printf("Warning: stock reconciliation failed: %v", err)
+			continue
+		}
+		if len(report.Mismatches) > 0 {
+			log.Printf("Stock reconciliation found %d mismatch(es) in %d product(s), repaired %d",
+				len(report.Mismatches), report.CheckedProducts, report.Repaired)
+		}
+	}
+}
+
+// runPriceScheduler applies the scheduled price changes that came due on every tick
+func runPriceScheduler(uc *usecase.ApplyScheduledPricesUseCase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		applied, err := uc.Execute(context.Background(), time.Now().UTC())
+		if err != nil {
+			log.Printf("Warning: applying scheduled prices failed: %v", err)
+			continue
+		}
+		if applied > 0 {
+			log.Printf("Applied %d scheduled price change(s)", applied)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/usecase"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/infrastructure/config"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/infrastructure/persistence"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/messaging"
+)
+
+// runReconcileCommand runs a single stock reconciliation and prints the report as JSON.
+//
+//	inventory-service reconcile [-repair]
+//
+// It exits with 1 when mismatches remain, so it can be used as a check in scripts.
+// Repairs publish stock events, so -repair also needs RabbitMQ.
+func runReconcileCommand(args []string) int {
+	flags := flag.NewFlagSet("reconcile", flag.ExitOnError)
+	repair := flags.Bool("repair", false, "overwrite mismatched stock levels with the ledger totals")
+	flags.Parse(args)
+
+	db := config.NewDatabase()
+	defer db.Close()
+
+	inventoryRepo := persistence.NewPostgresInventoryRepository(db)
+
+	// A dry run changes nothing, so only repairs have events to publish
+	var checkStockLevelsUseCase *usecase.CheckStockLevelsUseCase
+	var publishProductEventsUseCase *usecase.PublishProductEventsUseCase
+	if *repair {
+		rabbitConn, err := messaging.NewRabbitMQConnection(
+			getEnv("RABBITMQ_HOST", "localhost"),
+			getEnv("RABBITMQ_PORT", "5672"),
+			getEnv("RABBITMQ_USER", "admin"),
+			getEnv("RABBITMQ_PASSWORD", "admin"),
+		)
+		if err != nil {
+			log.Printf("Failed to connect to RabbitMQ: %v", err)
+			return 2
+		}
+		defer rabbitConn.Close()
+
+		publisher, err := messaging.NewEventPublisher(rabbitConn, "ecommerce-events")
+		if err != nil {
+			log.Printf("Failed to create publisher: %v", err)
+			return 2
+		}
+		checkStockLevelsUseCase = usecase.NewCheckStockLevelsUseCase(inventoryRepo, publisher)
+		publishProductEventsUseCase = usecase.NewPublishProductEventsUseCase(inventoryRepo, publisher)
+	}
+
+	reconcileStockUseCase := usecase.NewReconcileStockUseCase(inventoryRepo, checkStockLevelsUseCase, publishProductEventsUseCase)
+	report, err := reconcileStockUseCase.Execute(context.Background(), *repair)
+	if err != nil {
+		log.Printf("Stock reconciliation failed: %v", err)
+		return 2
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write report: %v\n", err)
+		return 2
+	}
+
+	if report.Unresolved() > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,134 @@
+package dto
+
+import "time"
+
+// CreateCategoryRequest represents the request to add a category
+type CreateCategoryRequest struct {
+	Name string `json:"name" binding:"required,max=255"`
+	// Slug identifies the category in URLs and must be unique
+	Slug string `json:"slug" binding:"required,max=255"`
+	// ParentID nests the category under another one, empty for a top-level category
+	ParentID string `json:"parent_id" binding:"omitempty,uuid"`
+}
+
+// UpdateCategoryRequest represents a partial update of a category
+type UpdateCategoryRequest struct {
+	Name *string `json:"name" binding:"omitempty,min=1,max=255"`
+	Slug *string `json:"slug" binding:"omitempty,min=1,max=255"`
+	// ParentID moves the category, an empty string makes it a top-level category
+	ParentID *string `json:"parent_id"`
+}
+
+// CategoryResponse represents a category with its subcategories
+type CategoryResponse struct {
+	ID        string             `json:"id"`
+	ParentID  string             `json:"parent_id,omitempty"`
+	Name      string             `json:"name"`
+	Slug      string             `json:"slug"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+	Children  []CategoryResponse `json:"children,omitempty"`
+}
+
+// CategoryTreeResponse represents the top-level categories, each with its subcategories
+type CategoryTreeResponse struct {
+	Categories []CategoryResponse `json:"categories"`
+}
+
+// CreateAttributeRequest represents the request to define a product attribute
+type CreateAttributeRequest struct {
+	// Code identifies the attribute in product data and search filters
+	Code string `json:"code" binding:"required,max=64"`
+	Name string `json:"name" binding:"required,max=255"`
+	Type string `json:"type" binding:"required,oneof=text number boolean"`
+}
+
+// AttributeResponse represents an attribute definition
+type AttributeResponse struct {
+	ID        string    `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AttributeListResponse represents all attribute definitions
+type AttributeListResponse struct {
+	Attributes []AttributeResponse `json:"attributes"`
+}
+
+// SetProductAttributesRequest replaces all attribute values of a product.
+// Values are keyed by attribute code and must match the attribute's type.
+type SetProductAttributesRequest struct {
+	Attributes map[string]any `json:"attributes" binding:"required"`
+}
+
+// ProductAttributesResponse represents a product's attribute values by attribute code
+type ProductAttributesResponse struct {
+	ProductID  string         `json:"product_id"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+// SearchProductsQuery represents the filters, sorting and pagination of a product search
+type SearchProductsQuery struct {
+	// Q is matched against names and descriptions
+	Q string `form:"q" binding:"max=200"`
+	// CategoryID also matches products in its subcategories
+	CategoryID string   `form:"category_id" binding:"omitempty,uuid"`
+	MinPrice   *float64 `form:"min_price" binding:"omitempty,min=0"`
+	MaxPrice   *float64 `form:"max_price" binding:"omitempty,min=0"`
+	// InStock only matches products that can be ordered now
+	InStock bool   `form:"in_stock"`
+	Sort    string `form:"sort,default=relevance" binding:"oneof=relevance price_asc price_desc newest name"`
+	Limit   int    `form:"limit,default=20" binding:"min=1,max=100"`
+	Offset  int    `form:"offset,default=0" binding:"min=0"`
+	// Attributes filters by attribute value, given as attr[code]=value
+	Attributes map[string]string `form:"-"`
+}
+
+// ProductSearchResponse represents a page of search results with facet
+// counts over all matching products
+type ProductSearchResponse struct {
+	Products []ProductResponse    `json:"products"`
+	Total    int64                `json:"total"`
+	Limit    int                  `json:"limit"`
+	Offset   int                  `json:"offset"`
+	Facets   ProductFacetResponse `json:"facets"`
+}
+
+// ProductFacetResponse represents the facet counts of a product search
+type ProductFacetResponse struct {
+	Categories  []CategoryFacetResponse   `json:"categories"`
+	PriceRanges []PriceRangeFacetResponse `json:"price_ranges"`
+	Attributes  []AttributeFacetResponse  `json:"attributes"`
+}
+
+// CategoryFacetResponse counts the matching products listed directly under a category
+type CategoryFacetResponse struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Slug  string `json:"slug"`
+	Count int64  `json:"count"`
+}
+
+// PriceRangeFacetResponse counts the matching products priced from Min up to,
+// but excluding, Max
+type PriceRangeFacetResponse struct {
+	Min   float64  `json:"min"`
+	Max   *float64 `json:"max,omitempty"`
+	Count int64    `json:"count"`
+}
+
+// AttributeFacetResponse counts the matching products per value of an attribute
+type AttributeFacetResponse struct {
+	Code   string                `json:"code"`
+	Name   string                `json:"name"`
+	Type   string                `json:"type"`
+	Values []AttributeValueCount `json:"values"`
+}
+
+// AttributeValueCount counts the matching products having an attribute value
+type AttributeValueCount struct {
+	Value any   `json:"value"`
+	Count int64 `json:"count"`
+}
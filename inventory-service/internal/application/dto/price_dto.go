@@ -0,0 +1,76 @@
+package dto
+
+import "time"
+
+// GetPriceQuery selects the moment to get a product's price at
+type GetPriceQuery struct {
+	// At defaults to now
+	At time.Time `form:"at" time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// PriceResponse represents the price a product had at a moment
+type PriceResponse struct {
+	ProductID string    `json:"product_id"`
+	At        time.Time `json:"at"`
+	Price     float64   `json:"price"`
+	// EffectiveFrom and EffectiveTo bound the period the price was in effect,
+	// EffectiveTo is omitted for the current price
+	EffectiveFrom time.Time  `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to,omitempty"`
+	Source        string     `json:"source"`
+	ScheduleID    string     `json:"schedule_id,omitempty"`
+}
+
+// ListPriceHistoryQuery represents the pagination parameters of the price history
+type ListPriceHistoryQuery struct {
+	Limit  int `form:"limit,default=50" binding:"min=1,max=500"`
+	Offset int `form:"offset,default=0" binding:"min=0"`
+}
+
+// PriceHistoryEntryResponse represents a price a product had
+type PriceHistoryEntryResponse struct {
+	Price         float64    `json:"price"`
+	EffectiveFrom time.Time  `json:"effective_from"`
+	EffectiveTo   *time.Time `json:"effective_to,omitempty"`
+	Source        string     `json:"source"`
+	ScheduleID    string     `json:"schedule_id,omitempty"`
+}
+
+// PriceHistoryResponse represents a page of a product's prices, newest first
+type PriceHistoryResponse struct {
+	ProductID string                      `json:"product_id"`
+	Prices    []PriceHistoryEntryResponse `json:"prices"`
+	Total     int64                       `json:"total"`
+	Limit     int                         `json:"limit"`
+	Offset    int                         `json:"offset"`
+}
+
+// SchedulePriceRequest represents a future price change. With ends_at the
+// current price is restored when the change ends, e.g. at the end of a sale.
+type SchedulePriceRequest struct {
+	Price    float64    `json:"price" binding:"required,gt=0"`
+	StartsAt time.Time  `json:"starts_at" binding:"required"`
+	EndsAt   *time.Time `json:"ends_at"`
+	Reason   string     `json:"reason" binding:"max=500"`
+}
+
+// ScheduledPriceResponse represents a scheduled price change
+type ScheduledPriceResponse struct {
+	ID        string     `json:"id"`
+	ProductID string     `json:"product_id"`
+	Price     float64    `json:"price"`
+	StartsAt  time.Time  `json:"starts_at"`
+	EndsAt    *time.Time `json:"ends_at,omitempty"`
+	// PreviousPrice is the price the change replaced once it started
+	PreviousPrice *float64  `json:"previous_price,omitempty"`
+	Status        string    `json:"status"`
+	Reason        string    `json:"reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ScheduledPriceListResponse represents all scheduled price changes of a product
+type ScheduledPriceListResponse struct {
+	ProductID string                   `json:"product_id"`
+	Schedules []ScheduledPriceResponse `json:"schedules"`
+}
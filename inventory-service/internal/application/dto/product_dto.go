@@ -0,0 +1,173 @@
+package dto
+
+import "time"
+
+// CreateProductRequest represents the request to add a product to the catalog
+type CreateProductRequest struct {
+	Name          string  `json:"name" binding:"required,max=255"`
+	Description   string  `json:"description" binding:"max=5000"`
+	Price         float64 `json:"price" binding:"required,gt=0"`
+	StockQuantity int32   `json:"stock_quantity" binding:"min=0"`
+	// ReorderThreshold is the available stock at or below which the product is low on stock
+	ReorderThreshold int32 `json:"reorder_threshold" binding:"min=0"`
+	// IsActive defaults to true when omitted
+	IsActive *bool `json:"is_active"`
+	// SKU is the optional stock keeping unit code, unique across products
+	SKU        string `json:"sku" binding:"max=64"`
+	CategoryID string `json:"category_id" binding:"omitempty,uuid"`
+}
+
+// CreateVariantRequest represents the request to add a variant to a product.
+// The variant holds its own stock, the parent product no longer does.
+type CreateVariantRequest struct {
+	SKU string `json:"sku" binding:"max=64"`
+	// Options tell the variant apart from the product's other variants, e.g. {"size": "L"}
+	Options map[string]string `json:"options" binding:"required,min=1,dive,keys,required,max=64,endkeys,required,max=255"`
+	// Name defaults to the parent's name followed by the option values
+	Name        string `json:"name" binding:"max=255"`
+	Description string `json:"description" binding:"max=5000"`
+	// Price defaults to the parent's price
+	Price            *float64 `json:"price" binding:"omitempty,gt=0"`
+	StockQuantity    int32    `json:"stock_quantity" binding:"min=0"`
+	ReorderThreshold int32    `json:"reorder_threshold" binding:"min=0"`
+	// IsActive defaults to true when omitted
+	IsActive *bool `json:"is_active"`
+}
+
+// UpdateProductRequest represents a partial update of a product's details.
+// Stock is changed through the stock adjustment endpoint instead.
+type UpdateProductRequest struct {
+	Name        *string  `json:"name" binding:"omitempty,min=1,max=255"`
+	Description *string  `json:"description" binding:"omitempty,max=5000"`
+	Price       *float64 `json:"price" binding:"omitempty,gt=0"`
+	// ReorderThreshold of zero only reports the product once it is out of stock
+	ReorderThreshold *int32  `json:"reorder_threshold" binding:"omitempty,min=0"`
+	SKU              *string `json:"sku" binding:"omitempty,max=64"`
+	// Options replaces a variant's options, only variants have options
+	Options map[string]string `json:"options" binding:"omitempty,min=1,dive,keys,required,max=64,endkeys,required,max=255"`
+	// CategoryID moves the product to another category, an empty string leaves it uncategorised
+	CategoryID *string `json:"category_id"`
+	// Version, when given, rejects the update if the product changed since it was read
+	Version *int32 `json:"version" binding:"omitempty,min=1"`
+}
+
+// AdjustStockRequest represents a manual stock correction or goods receipt
+type AdjustStockRequest struct {
+	// Delta is added to the stock quantity, negative values remove stock
+	Delta int32 `json:"delta" binding:"required,ne=0"`
+	// Type is recorded in the stock ledger, defaults to receipt for positive
+	// and adjustment for negative deltas
+	Type   string `json:"type" binding:"omitempty,oneof=receipt adjustment"`
+	Reason string `json:"reason" binding:"required,max=500"`
+	// WarehouseID defaults to the default warehouse, the active one with the lowest priority
+	WarehouseID string `json:"warehouse_id" binding:"omitempty,uuid"`
+}
+
+// ListStockMovementsQuery selects the ledger entries of a product in [from, to)
+type ListStockMovementsQuery struct {
+	// From defaults to 30 days before To
+	From time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	// To defaults to now
+	To     time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Limit  int       `form:"limit,default=50" binding:"min=1,max=500"`
+	Offset int       `form:"offset,default=0" binding:"min=0"`
+}
+
+// ListProductsQuery represents the pagination parameters of the product list
+type ListProductsQuery struct {
+	Limit           int  `form:"limit,default=20" binding:"min=1,max=100"`
+	Offset          int  `form:"offset,default=0" binding:"min=0"`
+	IncludeInactive bool `form:"include_inactive"`
+}
+
+// ProductResponse represents the product data returned to the client
+type ProductResponse struct {
+	ID               string    `json:"id"`
+	Name             string    `json:"name"`
+	Description      string    `json:"description"`
+	Price            float64   `json:"price"`
+	StockQuantity    int32     `json:"stock_quantity"`
+	ReservedStock    int32     `json:"reserved_stock"`
+	AvailableStock   int32     `json:"available_stock"`
+	ReorderThreshold int32     `json:"reorder_threshold"`
+	StockStatus      string    `json:"stock_status"`
+	IsActive         bool      `json:"is_active"`
+	Version          int32     `json:"version"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	// ParentID, SKU and Options are set for variants, SKU may be set on any product
+	ParentID string            `json:"parent_id,omitempty"`
+	SKU      string            `json:"sku,omitempty"`
+	Options  map[string]string `json:"options,omitempty"`
+	// CategoryID is empty for an uncategorised product
+	CategoryID string `json:"category_id,omitempty"`
+	// Attributes maps attribute codes to typed values, only included for a single product
+	Attributes map[string]any `json:"attributes,omitempty"`
+	// Variants of the product, the stock of a product with variants is held by them
+	Variants []ProductResponse `json:"variants,omitempty"`
+}
+
+// ProductListResponse represents a page of products
+type ProductListResponse struct {
+	Products []ProductResponse `json:"products"`
+	Total    int64             `json:"total"`
+	Limit    int               `json:"limit"`
+	Offset   int               `json:"offset"`
+}
+
+// LowStockReportQuery represents the pagination parameters of the low stock report
+type LowStockReportQuery struct {
+	Limit  int `form:"limit,default=50" binding:"min=1,max=500"`
+	Offset int `form:"offset,default=0" binding:"min=0"`
+}
+
+// LowStockProductResponse represents a product at or below its reorder threshold
+type LowStockProductResponse struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	StockQuantity    int32  `json:"stock_quantity"`
+	ReservedStock    int32  `json:"reserved_stock"`
+	AvailableStock   int32  `json:"available_stock"`
+	ReorderThreshold int32  `json:"reorder_threshold"`
+	StockStatus      string `json:"stock_status"`
+}
+
+// LowStockReportResponse represents a page of the low stock report,
+// products furthest below their reorder threshold first
+type LowStockReportResponse struct {
+	Products []LowStockProductResponse `json:"products"`
+	Total    int64                     `json:"total"`
+	Limit    int                       `json:"limit"`
+	Offset   int                       `json:"offset"`
+}
+
+// StockAdjustmentResponse represents the product after a stock adjustment
+type StockAdjustmentResponse struct {
+	Product ProductResponse `json:"product"`
+	// AllocatedToBackorders is how much of the added stock was reserved for waiting orders
+	AllocatedToBackorders int32 `json:"allocated_to_backorders"`
+}
+
+// StockMovementResponse represents a single stock ledger entry
+type StockMovementResponse struct {
+	ID            string    `json:"id"`
+	WarehouseID   string    `json:"warehouse_id,omitempty"`
+	Type          string    `json:"type"`
+	StockDelta    int32     `json:"stock_delta"`
+	ReservedDelta int32     `json:"reserved_delta"`
+	Reason        string    `json:"reason"`
+	OrderID       string    `json:"order_id,omitempty"`
+	CorrelationID string    `json:"correlation_id,omitempty"`
+	Actor         string    `json:"actor"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// StockMovementListResponse represents a page of a product's stock ledger
+type StockMovementListResponse struct {
+	ProductID string                  `json:"product_id"`
+	From      time.Time               `json:"from"`
+	To        time.Time               `json:"to"`
+	Movements []StockMovementResponse `json:"movements"`
+	Limit     int                     `json:"limit"`
+	Offset    int                     `json:"offset"`
+}
@@ -0,0 +1,54 @@
+package dto
+
+// ProductRecord is one row of a product import or export file. The same
+// product appears once per warehouse it holds stock in, and variants follow
+// their parent product.
+type ProductRecord struct {
+	// ID is empty to create a new product
+	ID          string  `json:"id,omitempty"`
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Price       float64 `json:"price"`
+	// StockQuantity is the stock held in the warehouse, omitted to leave stock unchanged
+	StockQuantity    *int32 `json:"stock_quantity,omitempty"`
+	ReorderThreshold *int32 `json:"reorder_threshold,omitempty"`
+	IsActive         *bool  `json:"is_active,omitempty"`
+	// WarehouseCode defaults to the default warehouse
+	WarehouseCode string `json:"warehouse_code,omitempty"`
+	// ParentID creates the product as a variant of that product
+	ParentID string            `json:"parent_id,omitempty"`
+	SKU      string            `json:"sku,omitempty"`
+	Options  map[string]string `json:"options,omitempty"`
+}
+
+// ImportProductsQuery represents the options of a bulk product import
+type ImportProductsQuery struct {
+	// Format defaults to the one matching the request's Content-Type
+	Format string `form:"format" binding:"omitempty,oneof=csv jsonl"`
+	// DryRun validates and applies every row, then discards the changes
+	DryRun bool `form:"dry_run"`
+}
+
+// ExportProductsQuery represents the options of a product export
+type ExportProductsQuery struct {
+	Format string `form:"format,default=csv" binding:"oneof=csv jsonl"`
+}
+
+// ProductImportRowError represents a row that was not imported
+type ProductImportRowError struct {
+	Line      int    `json:"line"`
+	ProductID string `json:"product_id,omitempty"`
+	Error     string `json:"error"`
+}
+
+// ProductImportReport represents the outcome of a bulk product import
+type ProductImportReport struct {
+	DryRun    bool `json:"dry_run"`
+	TotalRows int  `json:"total_rows"`
+	Created   int  `json:"created"`
+	Updated   int  `json:"updated"`
+	Unchanged int  `json:"unchanged"`
+	Failed    int  `json:"failed"`
+	// Errors lists the failed rows, capped at the first 1000
+	Errors []ProductImportRowError `json:"errors"`
+}
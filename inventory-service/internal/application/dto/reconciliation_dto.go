@@ -0,0 +1,49 @@
+package dto
+
+import "time"
+
+// ReconcileStockQuery represents the options of a reconciliation run
+type ReconcileStockQuery struct {
+	// Repair overwrites mismatched stock levels with the ledger totals
+	Repair bool `form:"repair"`
+}
+
+// StockMismatchResponse represents a product whose stock levels disagree with its ledger
+type StockMismatchResponse struct {
+	ProductID      string `json:"product_id"`
+	ProductName    string `json:"product_name"`
+	StockQuantity  int32  `json:"stock_quantity"`
+	LedgerStock    int32  `json:"ledger_stock"`
+	StockDrift     int32  `json:"stock_drift"`
+	ReservedStock  int32  `json:"reserved_stock"`
+	LedgerReserved int32  `json:"ledger_reserved"`
+	ReservedDrift  int32  `json:"reserved_drift"`
+	// Warehouses lists the warehouses whose stock levels of the product drifted
+	Warehouses []WarehouseStockMismatchResponse `json:"warehouses,omitempty"`
+	Repaired   bool                             `json:"repaired"`
+	Error      string                           `json:"error,omitempty"`
+}
+
+// WarehouseStockMismatchResponse represents a warehouse whose stock levels of a product disagree with the ledger
+type WarehouseStockMismatchResponse struct {
+	WarehouseID    string `json:"warehouse_id"`
+	StockQuantity  int32  `json:"stock_quantity"`
+	LedgerStock    int32  `json:"ledger_stock"`
+	ReservedStock  int32  `json:"reserved_stock"`
+	LedgerReserved int32  `json:"ledger_reserved"`
+}
+
+// ReconciliationReport represents the outcome of a reconciliation run
+type ReconciliationReport struct {
+	Repair          bool                    `json:"repair"`
+	CheckedProducts int                     `json:"checked_products"`
+	Mismatches      []StockMismatchResponse `json:"mismatches"`
+	Repaired        int                     `json:"repaired"`
+	StartedAt       time.Time               `json:"started_at"`
+	FinishedAt      time.Time               `json:"finished_at"`
+}
+
+// Unresolved reports how many mismatches are left after the run
+func (r *ReconciliationReport) Unresolved() int {
+	return len(r.Mismatches) - r.Repaired
+}
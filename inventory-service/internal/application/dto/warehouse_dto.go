@@ -0,0 +1,60 @@
+package dto
+
+import "time"
+
+// CreateWarehouseRequest represents the request to add a warehouse
+type CreateWarehouseRequest struct {
+	Code string `json:"code" binding:"required,max=50"`
+	Name string `json:"name" binding:"required,max=255"`
+	// Latitude and Longitude are optional but must be given together
+	Latitude  *float64 `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	// Priority orders warehouses for allocation, lower values are preferred
+	Priority int32 `json:"priority" binding:"min=0"`
+	// IsActive defaults to true when omitted
+	IsActive *bool `json:"is_active"`
+}
+
+// UpdateWarehouseRequest represents a partial update of a warehouse. The code cannot change.
+type UpdateWarehouseRequest struct {
+	Name      *string  `json:"name" binding:"omitempty,min=1,max=255"`
+	Latitude  *float64 `json:"latitude" binding:"omitempty,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"omitempty,min=-180,max=180"`
+	Priority  *int32   `json:"priority" binding:"omitempty,min=0"`
+	IsActive  *bool    `json:"is_active"`
+}
+
+// WarehouseResponse represents the warehouse data returned to the client
+type WarehouseResponse struct {
+	ID        string    `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Latitude  *float64  `json:"latitude,omitempty"`
+	Longitude *float64  `json:"longitude,omitempty"`
+	Priority  int32     `json:"priority"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WarehouseListResponse represents all warehouses, preferred ones first
+type WarehouseListResponse struct {
+	Warehouses []WarehouseResponse `json:"warehouses"`
+}
+
+// WarehouseStockResponse represents a product's stock in one warehouse
+type WarehouseStockResponse struct {
+	WarehouseID     string `json:"warehouse_id"`
+	WarehouseCode   string `json:"warehouse_code"`
+	WarehouseName   string `json:"warehouse_name"`
+	WarehouseActive bool   `json:"warehouse_active"`
+	StockQuantity   int32  `json:"stock_quantity"`
+	ReservedStock   int32  `json:"reserved_stock"`
+	AvailableStock  int32  `json:"available_stock"`
+}
+
+// StockLevelsResponse represents a product's stock per warehouse
+type StockLevelsResponse struct {
+	ProductID  string                   `json:"product_id"`
+	Warehouses []WarehouseStockResponse `json:"warehouses"`
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+// AdjustStockUseCase applies manual stock corrections and goods receipts
+type AdjustStockUseCase struct {
+	inventoryRepo               repository.InventoryRepository
+	receiveStockUseCase         *ReceiveStockUseCase
+	checkStockLevelsUseCase     *CheckStockLevelsUseCase
+	publishProductEventsUseCase *PublishProductEventsUseCase
+}
+
+func NewAdjustStockUseCase(
+	inventoryRepo repository.InventoryRepository,
+	receiveStockUseCase *ReceiveStockUseCase,
+	checkStockLevelsUseCase *CheckStockLevelsUseCase,
+	publishProductEventsUseCase *PublishProductEventsUseCase) *AdjustStockUseCase {
+	return &AdjustStockUseCase{
+		inventoryRepo:               inventoryRepo,
+		receiveStockUseCase:         receiveStockUseCase,
+		checkStockLevelsUseCase:     checkStockLevelsUseCase,
+		publishProductEventsUseCase: publishProductEventsUseCase,
+	}
+}
+
+// Execute adds or removes stock and records the change in the stock ledger.
+// Added stock goes to pending backorders first, removed stock can never
+// exceed what is not reserved.
+func (uc *AdjustStockUseCase) Execute(ctx context.Context, productID, actor string, req dto.AdjustStockRequest) (*dto.StockAdjustmentResponse, error) {
+	change := entity.StockChange{
+		ProductID:   productID,
+		WarehouseID: req.WarehouseID,
+		Quantity:    req.Delta,
+		Type:        entity.StockMovementType(req.Type),
+		Actor:       actor,
+		Reason:      req.Reason,
+	}
+
+	var allocated int32
+	if req.Delta > 0 {
+		if change.Type == "" {
+			change.Type = entity.StockMovementReceipt
+		}
+		allocations, err := uc.receiveStockUseCase.Execute(ctx, change)
+		if err != nil {
+			return nil, err
+		}
+		for _, allocation := range allocations {
+			allocated += allocation.Quantity
+		}
+	} else {
+		// Only corrections can take stock out, receipts are always positive
+		if change.Type != "" && change.Type != entity.StockMovementAdjustment {
+			return nil, entity.ErrNegativeReceipt
+		}
+		change.Type = entity.StockMovementAdjustment
+		change.Quantity = -req.Delta
+		if err := uc.inventoryRepo.RemoveStock(ctx, change); err != nil {
+			return nil, fmt.Errorf("failed to remove stock: %w", err)
+		}
+		uc.publishProductEventsUseCase.StockChanged(ctx, "", productID)
+		uc.checkStockLevelsUseCase.Execute(ctx, "", productID)
+	}
+
+	product, err := uc.inventoryRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.StockAdjustmentResponse{
+		Product:               *toProductResponse(product),
+		AllocatedToBackorders: allocated,
+	}, nil
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+// scheduledPriceBatchSize bounds the changes applied per run, the rest are
+// picked up by the next run
+const scheduledPriceBatchSize = 100
+
+// ApplyScheduledPricesUseCase starts and ends the scheduled price changes
+// that are due, it is run periodically by the price scheduler
+type ApplyScheduledPricesUseCase struct {
+	inventoryRepo               repository.InventoryRepository
+	priceRepo                   repository.PriceRepository
+	publishProductEventsUseCase *PublishProductEventsUseCase
+}
+
+func NewApplyScheduledPricesUseCase(
+	inventoryRepo repository.InventoryRepository,
+	priceRepo repository.PriceRepository,
+	publishProductEventsUseCase *PublishProductEventsUseCase) *ApplyScheduledPricesUseCase {
+	return &ApplyScheduledPricesUseCase{
+		inventoryRepo:               inventoryRepo,
+		priceRepo:                   priceRepo,
+		publishProductEventsUseCase: publishProductEventsUseCase,
+	}
+}
+
+// Execute applies the changes due at now and returns how many it applied.
+// A change that fails is logged and retried on the next run.
+func (uc *ApplyScheduledPricesUseCase) Execute(ctx context.Context, now time.Time) (int, error) {
+	schedules, err := uc.priceRepo.ListDueSchedules(ctx, now, scheduledPriceBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list due scheduled prices: %w", err)
+	}
+
+	applied := 0
+	for _, schedule := range schedules {
+		transition, err := uc.inventoryRepo.ApplyScheduledPrice(ctx, schedule.ID, now)
+		if err != nil {
+			log.Printf("ERROR: Failed to apply scheduled price %s of product %s: %v", schedule.ID, schedule.ProductID, err)
+			continue
+		}
+		// Another replica got to it first
+		if transition == nil {
+			continue
+		}
+		applied++
+
+		if transition.Product != nil {
+			uc.publishProductEventsUseCase.Changed("", transition.Previous, transition.Product)
+		}
+	}
+	return applied, nil
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+// CancelScheduledPriceUseCase cancels a scheduled price change before it starts
+type CancelScheduledPriceUseCase struct {
+	priceRepo repository.PriceRepository
+}
+
+func NewCancelScheduledPriceUseCase(priceRepo repository.PriceRepository) *CancelScheduledPriceUseCase {
+	return &CancelScheduledPriceUseCase{
+		priceRepo: priceRepo,
+	}
+}
+
+func (uc *CancelScheduledPriceUseCase) Execute(ctx context.Context, productID, scheduleID string) (*dto.ScheduledPriceResponse, error) {
+	schedule, err := uc.priceRepo.CancelSchedule(ctx, productID, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toScheduledPriceResponse(*schedule)
+	return &response, nil
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/events"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/messaging"
+)
+
+// CheckStockLevelsUseCase publishes an event whenever a product's available
+// stock crosses its reorder threshold. It runs after a stock change has been
+// committed, so failures are logged and never undo the change.
+type CheckStockLevelsUseCase struct {
+	inventoryRepo  repository.InventoryRepository
+	eventPublisher eventPublisher
+}
+
+func NewCheckStockLevelsUseCase(
+	inventoryRepo repository.InventoryRepository,
+	eventPublisher *messaging.EventPublisher) *CheckStockLevelsUseCase {
+	return &CheckStockLevelsUseCase{
+		inventoryRepo:  inventoryRepo,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Execute checks the given products, correlationID ties the events to the
+// order that changed the stock and is generated when empty
+func (uc *CheckStockLevelsUseCase) Execute(ctx context.Context, correlationID string, productIDs ...string) {
+	if len(productIDs) == 0 {
+		return
+	}
+
+	changes, err := uc.inventoryRepo.UpdateStockStatuses(ctx, productIDs)
+	if err != nil {
+		fmt.Printf("ERROR: Failed to check stock levels: %v\n", err)
+		return
+	}
+
+	if correlationID == "" {
+		correlationID = uuid.New().String()
+	}
+	for _, change := range changes {
+		uc.publishStockLevelEvent(change, correlationID)
+	}
+}
+
+func (uc *CheckStockLevelsUseCase) publishStockLevelEvent(change entity.StockStatusChange, correlationID string) {
+	var eventType string
+	switch change.Status {
+	case entity.StockStatusLowStock:
+		// Stock coming back from zero but still below the threshold is not a restock
+		eventType = events.InventoryLowStockEventType
+	case entity.StockStatusOutOfStock:
+		eventType = events.InventoryOutOfStockEventType
+	default:
+		eventType = events.InventoryRestockedEventType
+	}
+
+	stockLevelEvent := events.InventoryStockLevelEvent{
+		BaseEvent:        events.NewBaseEvent(eventType, change.ProductID, correlationID),
+		ProductID:        change.ProductID,
+		ProductName:      change.ProductName,
+		AvailableStock:   int(change.AvailableStock),
+		ReorderThreshold: int(change.ReorderThreshold),
+		PreviousStatus:   string(change.PreviousStatus),
+		Status:           string(change.Status),
+	}
+
+	if err := uc.eventPublisher.Publish(eventType, stockLevelEvent); err != nil {
+		fmt.Printf("ERROR: Failed to publish %s event: %v\n", eventType, err)
+	}
+}
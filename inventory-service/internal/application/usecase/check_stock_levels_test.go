@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/events"
+)
+
+// stockStatusRepository keeps the last reported stock status of a product and,
+// like the postgres repository, only reports a change when the status moved
+type stockStatusRepository struct {
+	repository.InventoryRepository
+	product  *entity.Product
+	reported entity.StockStatus
+}
+
+func (r *stockStatusRepository) UpdateStockStatuses(ctx context.Context, productIDs []string) ([]entity.StockStatusChange, error) {
+	status := r.product.StockStatus()
+	if status == r.reported {
+		return nil, nil
+	}
+	change := entity.StockStatusChange{
+		ProductID:        r.product.ID,
+		ProductName:      r.product.Name,
+		PreviousStatus:   r.reported,
+		Status:           status,
+		AvailableStock:   r.product.AvailableStock(),
+		ReorderThreshold: r.product.ReorderThreshold,
+	}
+	r.reported = status
+	return []entity.StockStatusChange{change}, nil
+}
+
+type eventPublisherFunc func(routingKey string, event interface{}) error
+
+func (f eventPublisherFunc) Publish(routingKey string, event interface{}) error {
+	return f(routingKey, event)
+}
+
+func TestCheckStockLevelsPublishesOncePerCrossing(t *testing.T) {
+	repo := &stockStatusRepository{
+		product:  &entity.Product{ID: "p-1", Name: "Mug", StockQuantity: 20, ReorderThreshold: 5},
+		reported: entity.StockStatusInStock,
+	}
+	var published []events.InventoryStockLevelEvent
+	uc := NewCheckStockLevelsUseCase(repo, nil)
+	uc.eventPublisher = eventPublisherFunc(func(routingKey string, event interface{}) error {
+		stockLevelEvent := event.(events.InventoryStockLevelEvent)
+		if stockLevelEvent.EventType != routingKey {
+			t.Errorf("event type %s published as %s", stockLevelEvent.EventType, routingKey)
+		}
+		published = append(published, stockLevelEvent)
+		return nil
+	})
+
+	steps := []struct {
+		name      string
+		stock     int32
+		wantEvent string
+		wantFrom  entity.StockStatus
+	}{
+		{name: "still above the threshold", stock: 10},
+		{name: "down to the threshold", stock: 5, wantEvent: events.InventoryLowStockEventType, wantFrom: entity.StockStatusInStock},
+		{name: "further below the threshold", stock: 3},
+		{name: "checked again without a change", stock: 3},
+		{name: "sold out", stock: 0, wantEvent: events.InventoryOutOfStockEventType, wantFrom: entity.StockStatusLowStock},
+		{name: "still sold out", stock: 0},
+		{name: "back from zero below the threshold", stock: 2, wantEvent: events.InventoryLowStockEventType, wantFrom: entity.StockStatusOutOfStock},
+		{name: "restocked above the threshold", stock: 8, wantEvent: events.InventoryRestockedEventType, wantFrom: entity.StockStatusLowStock},
+		{name: "more stock", stock: 30},
+	}
+	for _, step := range steps {
+		published = nil
+		repo.product.StockQuantity = step.stock
+
+		uc.Execute(context.Background(), "corr-1", repo.product.ID)
+
+		if step.wantEvent == "" {
+			if len(published) != 0 {
+				t.Errorf("%s: published %+v, want no event", step.name, published)
+			}
+			continue
+		}
+		if len(published) != 1 {
+			t.Errorf("%s: published %d events, want one %s", step.name, len(published), step.wantEvent)
+			continue
+		}
+		event := published[0]
+		if event.EventType != step.wantEvent || event.PreviousStatus != string(step.wantFrom) ||
+			event.AvailableStock != int(step.stock) || event.ReorderThreshold != 5 || event.CorrelationID != "corr-1" {
+			t.Errorf("%s: published %+v, want %s from %s", step.name, event, step.wantEvent, step.wantFrom)
+		}
+	}
+}
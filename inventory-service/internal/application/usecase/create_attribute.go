@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+type CreateAttributeUseCase struct {
+	attributeRepo repository.AttributeRepository
+}
+
+func NewCreateAttributeUseCase(attributeRepo repository.AttributeRepository) *CreateAttributeUseCase {
+	return &CreateAttributeUseCase{
+		attributeRepo: attributeRepo,
+	}
+}
+
+func (uc *CreateAttributeUseCase) Execute(ctx context.Context, req dto.CreateAttributeRequest) (*dto.AttributeResponse, error) {
+	attribute := &entity.Attribute{
+		ID:        uuid.New().String(),
+		Code:      req.Code,
+		Name:      req.Name,
+		Type:      entity.AttributeType(req.Type),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if err := uc.attributeRepo.Create(ctx, attribute); err != nil {
+		return nil, err
+	}
+
+	return toAttributeResponse(attribute), nil
+}
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+type CreateCategoryUseCase struct {
+	categoryRepo repository.CategoryRepository
+}
+
+func NewCreateCategoryUseCase(categoryRepo repository.CategoryRepository) *CreateCategoryUseCase {
+	return &CreateCategoryUseCase{
+		categoryRepo: categoryRepo,
+	}
+}
+
+func (uc *CreateCategoryUseCase) Execute(ctx context.Context, req dto.CreateCategoryRequest) (*dto.CategoryResponse, error) {
+	now := time.Now().UTC()
+	category := &entity.Category{
+		ID:        uuid.New().String(),
+		ParentID:  req.ParentID,
+		Name:      req.Name,
+		Slug:      req.Slug,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := uc.categoryRepo.Create(ctx, category); err != nil {
+		return nil, err
+	}
+
+	return toCategoryResponse(category), nil
+}
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+type CreateProductUseCase struct {
+	inventoryRepo               repository.InventoryRepository
+	publishProductEventsUseCase *PublishProductEventsUseCase
+}
+
+func NewCreateProductUseCase(
+	inventoryRepo repository.InventoryRepository,
+	publishProductEventsUseCase *PublishProductEventsUseCase) *CreateProductUseCase {
+	return &CreateProductUseCase{
+		inventoryRepo:               inventoryRepo,
+		publishProductEventsUseCase: publishProductEventsUseCase,
+	}
+}
+
+func (uc *CreateProductUseCase) Execute(ctx context.Context, req dto.CreateProductRequest) (*dto.ProductResponse, error) {
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	now := time.Now().UTC()
+	product := &entity.Product{
+		ID:               uuid.New().String(),
+		Name:             req.Name,
+		Description:      req.Description,
+		Price:            req.Price,
+		StockQuantity:    req.StockQuantity,
+		IsActive:         isActive,
+		ReorderThreshold: req.ReorderThreshold,
+		SKU:              req.SKU,
+		CategoryID:       req.CategoryID,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	if err := uc.inventoryRepo.Create(ctx, product); err != nil {
+		return nil, fmt.Errorf("failed to create product: %w", err)
+	}
+	uc.publishProductEventsUseCase.Created("", product)
+
+	return toProductResponse(product), nil
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+type CreateVariantUseCase struct {
+	inventoryRepo               repository.InventoryRepository
+	publishProductEventsUseCase *PublishProductEventsUseCase
+}
+
+func NewCreateVariantUseCase(
+	inventoryRepo repository.InventoryRepository,
+	publishProductEventsUseCase *PublishProductEventsUseCase) *CreateVariantUseCase {
+	return &CreateVariantUseCase{
+		inventoryRepo:               inventoryRepo,
+		publishProductEventsUseCase: publishProductEventsUseCase,
+	}
+}
+
+// Execute adds a variant to the parent product. The name and price default to
+// the parent's, and the parent must not hold stock or be a variant itself.
+func (uc *CreateVariantUseCase) Execute(ctx context.Context, parentID string, req dto.CreateVariantRequest) (*dto.ProductResponse, error) {
+	parent, err := uc.inventoryRepo.GetByID(ctx, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent.IsVariant() {
+		return nil, entity.ErrVariantOfVariant
+	}
+
+	name := req.Name
+	if name == "" {
+		name = entity.VariantName(parent.Name, req.Options)
+	}
+	price := parent.Price
+	if req.Price != nil {
+		price = *req.Price
+	}
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	now := time.Now().UTC()
+	variant := &entity.Product{
+		ID:               uuid.New().String(),
+		Name:             name,
+		Description:      req.Description,
+		Price:            price,
+		StockQuantity:    req.StockQuantity,
+		IsActive:         isActive,
+		ReorderThreshold: req.ReorderThreshold,
+		ParentID:         parent.ID,
+		SKU:              req.SKU,
+		Options:          req.Options,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}
+
+	if err := uc.inventoryRepo.Create(ctx, variant); err != nil {
+		return nil, fmt.Errorf("failed to create variant: %w", err)
+	}
+	uc.publishProductEventsUseCase.Created("", variant)
+
+	return toProductResponse(variant), nil
+}
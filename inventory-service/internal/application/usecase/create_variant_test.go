@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/events"
+)
+
+// variantRepository serves fixed products and keeps the ones created
+type variantRepository struct {
+	repository.InventoryRepository
+	products  map[string]*entity.Product
+	createErr error
+	created   []*entity.Product
+}
+
+func (r *variantRepository) GetByID(ctx context.Context, id string) (*entity.Product, error) {
+	product, ok := r.products[id]
+	if !ok {
+		return nil, entity.ErrProductNotFound
+	}
+	return product, nil
+}
+
+func (r *variantRepository) Create(ctx context.Context, product *entity.Product) error {
+	if r.createErr != nil {
+		return r.createErr
+	}
+	r.created = append(r.created, product)
+	return nil
+}
+
+func TestCreateVariant(t *testing.T) {
+	parent := &entity.Product{ID: "shirt", Name: "T-Shirt", Price: 20, IsActive: true}
+	variant := &entity.Product{ID: "shirt-l", Name: "T-Shirt - L", Price: 20, ParentID: "shirt", Options: map[string]string{"size": "L"}}
+	price := 25.0
+	inactive := false
+
+	tests := []struct {
+		name      string
+		parentID  string
+		req       dto.CreateVariantRequest
+		createErr error
+		want      *entity.Product
+		wantErr   error
+	}{
+		{
+			name:     "name and price of the parent",
+			parentID: "shirt",
+			req:      dto.CreateVariantRequest{SKU: "TS-RED-L", Options: map[string]string{"size": "L", "colour": "red"}, StockQuantity: 4},
+			want: &entity.Product{
+				Name: "T-Shirt - red / L", Price: 20, StockQuantity: 4, IsActive: true,
+				ParentID: "shirt", SKU: "TS-RED-L", Options: map[string]string{"size": "L", "colour": "red"},
+			},
+		},
+		{
+			name:     "own name, price and state",
+			parentID: "shirt",
+			req:      dto.CreateVariantRequest{Name: "Big shirt", Price: &price, IsActive: &inactive, Options: map[string]string{"size": "XL"}},
+			want:     &entity.Product{Name: "Big shirt", Price: 25, ParentID: "shirt", Options: map[string]string{"size": "XL"}},
+		},
+		{name: "unknown parent", parentID: "hat", req: dto.CreateVariantRequest{Options: map[string]string{"size": "L"}}, wantErr: entity.ErrProductNotFound},
+		{name: "parent is a variant", parentID: "shirt-l", req: dto.CreateVariantRequest{Options: map[string]string{"colour": "red"}}, wantErr: entity.ErrVariantOfVariant},
+		{name: "parent holds stock", parentID: "shirt", req: dto.CreateVariantRequest{Options: map[string]string{"size": "L"}}, createErr: entity.ErrParentHoldsStock, wantErr: entity.ErrParentHoldsStock},
+		{name: "options taken", parentID: "shirt", req: dto.CreateVariantRequest{Options: map[string]string{"size": "L"}}, createErr: entity.ErrDuplicateVariantOptions, wantErr: entity.ErrDuplicateVariantOptions},
+		{name: "SKU taken", parentID: "shirt", req: dto.CreateVariantRequest{SKU: "TS-L", Options: map[string]string{"size": "L"}}, createErr: entity.ErrSKUTaken, wantErr: entity.ErrSKUTaken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &variantRepository{
+				products:  map[string]*entity.Product{parent.ID: parent, variant.ID: variant},
+				createErr: tt.createErr,
+			}
+			publisher := &recordingPublisher{}
+			uc := NewCreateVariantUseCase(repo, &PublishProductEventsUseCase{inventoryRepo: repo, eventPublisher: publisher})
+
+			response, err := uc.Execute(context.Background(), tt.parentID, tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if len(repo.created) != 0 || len(publisher.eventTypes) != 0 {
+					t.Errorf("failed Execute() created %d products and published %v", len(repo.created), publisher.eventTypes)
+				}
+				return
+			}
+
+			if len(repo.created) != 1 {
+				t.Fatalf("created %d products, want 1", len(repo.created))
+			}
+			got := repo.created[0]
+			if got.Name != tt.want.Name || got.Price != tt.want.Price || got.StockQuantity != tt.want.StockQuantity ||
+				got.IsActive != tt.want.IsActive || got.ParentID != tt.want.ParentID || got.SKU != tt.want.SKU ||
+				len(got.Options) != len(tt.want.Options) {
+				t.Errorf("created %+v, want %+v", got, tt.want)
+			}
+			for name, value := range tt.want.Options {
+				if got.Options[name] != value {
+					t.Errorf("option %s = %q, want %q", name, got.Options[name], value)
+				}
+			}
+			if response.ID != got.ID || response.ParentID != "shirt" {
+				t.Errorf("response = %+v, want the created variant", response)
+			}
+			if len(publisher.eventTypes) == 0 || publisher.eventTypes[0] != events.ProductCreatedEventType {
+				t.Errorf("published %v, want product.created first", publisher.eventTypes)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+type CreateWarehouseUseCase struct {
+	warehouseRepo repository.WarehouseRepository
+}
+
+func NewCreateWarehouseUseCase(warehouseRepo repository.WarehouseRepository) *CreateWarehouseUseCase {
+	return &CreateWarehouseUseCase{
+		warehouseRepo: warehouseRepo,
+	}
+}
+
+func (uc *CreateWarehouseUseCase) Execute(ctx context.Context, req dto.CreateWarehouseRequest) (*dto.WarehouseResponse, error) {
+	location, err := entity.NewLocation(req.Latitude, req.Longitude)
+	if err != nil {
+		return nil, err
+	}
+
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+
+	now := time.Now().UTC()
+	warehouse := &entity.Warehouse{
+		ID:        uuid.New().String(),
+		Code:      req.Code,
+		Name:      req.Name,
+		Location:  location,
+		Priority:  req.Priority,
+		IsActive:  isActive,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := uc.warehouseRepo.Create(ctx, warehouse); err != nil {
+		return nil, err
+	}
+
+	return toWarehouseResponse(warehouse), nil
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+type DeleteProductUseCase struct {
+	inventoryRepo               repository.InventoryRepository
+	publishProductEventsUseCase *PublishProductEventsUseCase
+}
+
+func NewDeleteProductUseCase(
+	inventoryRepo repository.InventoryRepository,
+	publishProductEventsUseCase *PublishProductEventsUseCase) *DeleteProductUseCase {
+	return &DeleteProductUseCase{
+		inventoryRepo:               inventoryRepo,
+		publishProductEventsUseCase: publishProductEventsUseCase,
+	}
+}
+
+// Execute removes the product from the catalog. Products are soft-deleted so
+// existing orders and reservations keep referring to them.
+func (uc *DeleteProductUseCase) Execute(ctx context.Context, productID string) error {
+	previous, err := uc.inventoryRepo.GetByID(ctx, productID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.inventoryRepo.Delete(ctx, productID); err != nil {
+		return fmt.Errorf("failed to delete product: %w", err)
+	}
+
+	if previous.IsActive {
+		product, err := uc.inventoryRepo.GetByID(ctx, productID)
+		if err != nil {
+			fmt.Printf("ERROR: Failed to get deleted product %s: %v\n", productID, err)
+			return nil
+		}
+		uc.publishProductEventsUseCase.Changed("", previous, product)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+// exportPageSize is the number of products read and written at a time
+const exportPageSize = 500
+
+// ExportProductsUseCase writes the catalogue in the format the import reads,
+// so an export can be edited and imported again
+type ExportProductsUseCase struct {
+	inventoryRepo repository.InventoryRepository
+}
+
+func NewExportProductsUseCase(inventoryRepo repository.InventoryRepository) *ExportProductsUseCase {
+	return &ExportProductsUseCase{
+		inventoryRepo: inventoryRepo,
+	}
+}
+
+// Execute streams every product to w a page at a time, one record per
+// warehouse the product holds stock in, each parent followed by its variants
+func (uc *ExportProductsUseCase) Execute(ctx context.Context, format string, w io.Writer) error {
+	writer, err := newProductRecordWriter(format, w)
+	if err != nil {
+		return err
+	}
+
+	afterID := ""
+	for {
+		products, err := uc.inventoryRepo.ListAfter(ctx, afterID, exportPageSize)
+		if err != nil {
+			return fmt.Errorf("failed to list products: %w", err)
+		}
+		if len(products) == 0 {
+			break
+		}
+
+		productIDs := make([]string, len(products))
+		for i, product := range products {
+			productIDs[i] = product.ID
+		}
+		variants, err := uc.inventoryRepo.GetVariants(ctx, productIDs, true)
+		if err != nil {
+			return fmt.Errorf("failed to get variants: %w", err)
+		}
+		for _, product := range products {
+			for _, variant := range variants[product.ID] {
+				productIDs = append(productIDs, variant.ID)
+			}
+		}
+		levels, err := uc.inventoryRepo.GetStockLevelsByProducts(ctx, productIDs)
+		if err != nil {
+			return fmt.Errorf("failed to get stock levels: %w", err)
+		}
+
+		// Variants follow their parent so that importing the file creates the parent first
+		for _, product := range products {
+			if err := writeProductRecords(writer, product, levels[product.ID]); err != nil {
+				return err
+			}
+			for _, variant := range variants[product.ID] {
+				if err := writeProductRecords(writer, variant, levels[variant.ID]); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+		afterID = products[len(products)-1].ID
+	}
+
+	return writer.Flush()
+}
+
+// writeProductRecords writes one record per warehouse the product holds stock in
+func writeProductRecords(writer productRecordWriter, product *entity.Product, levels []entity.WarehouseStock) error {
+	isActive := product.IsActive
+	reorderThreshold := product.ReorderThreshold
+	record := dto.ProductRecord{
+		ID:               product.ID,
+		Name:             product.Name,
+		Description:      product.Description,
+		Price:            product.Price,
+		ReorderThreshold: &reorderThreshold,
+		IsActive:         &isActive,
+		ParentID:         product.ParentID,
+		SKU:              product.SKU,
+		Options:          product.Options,
+	}
+
+	// A product without stock anywhere still gets a record, with no stock
+	if len(levels) == 0 {
+		var none int32
+		record.StockQuantity = &none
+		return writer.Write(record)
+	}
+	for _, level := range levels {
+		stock := level.StockQuantity
+		record.StockQuantity = &stock
+		record.WarehouseCode = level.WarehouseCode
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
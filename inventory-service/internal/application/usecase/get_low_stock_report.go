@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+// GetLowStockReportUseCase lists the active products that need reordering
+type GetLowStockReportUseCase struct {
+	inventoryRepo repository.InventoryRepository
+}
+
+func NewGetLowStockReportUseCase(inventoryRepo repository.InventoryRepository) *GetLowStockReportUseCase {
+	return &GetLowStockReportUseCase{
+		inventoryRepo: inventoryRepo,
+	}
+}
+
+func (uc *GetLowStockReportUseCase) Execute(ctx context.Context, query dto.LowStockReportQuery) (*dto.LowStockReportResponse, error) {
+	products, err := uc.inventoryRepo.ListLowStock(ctx, query.Limit, query.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list low stock products: %w", err)
+	}
+
+	total, err := uc.inventoryRepo.CountLowStock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count low stock products: %w", err)
+	}
+
+	response := &dto.LowStockReportResponse{
+		Products: make([]dto.LowStockProductResponse, len(products)),
+		Total:    total,
+		Limit:    query.Limit,
+		Offset:   query.Offset,
+	}
+	for i, product := range products {
+		response.Products[i] = dto.LowStockProductResponse{
+			ID:               product.ID,
+			Name:             product.Name,
+			StockQuantity:    product.StockQuantity,
+			ReservedStock:    product.ReservedStock,
+			AvailableStock:   product.AvailableStock(),
+			ReorderThreshold: product.ReorderThreshold,
+			StockStatus:      string(product.StockStatus()),
+		}
+	}
+	return response, nil
+}
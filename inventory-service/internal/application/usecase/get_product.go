@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+type GetProductUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	attributeRepo repository.AttributeRepository
+}
+
+func NewGetProductUseCase(
+	inventoryRepo repository.InventoryRepository,
+	attributeRepo repository.AttributeRepository) *GetProductUseCase {
+	return &GetProductUseCase{
+		inventoryRepo: inventoryRepo,
+		attributeRepo: attributeRepo,
+	}
+}
+
+func (uc *GetProductUseCase) Execute(ctx context.Context, productID string) (*dto.ProductResponse, error) {
+	product, err := uc.inventoryRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toProductResponse(product)
+	attributes, err := uc.attributeRepo.GetProductAttributes(ctx, product.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(attributes) > 0 {
+		response.Attributes = toAttributeValues(attributes)
+	}
+	if !product.IsVariant() {
+		variants, err := uc.inventoryRepo.GetVariants(ctx, []string{product.ID}, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get variants: %w", err)
+		}
+		response.Variants = toVariantResponses(variants[product.ID])
+	}
+	return response, nil
+}
+
+func toProductResponse(product *entity.Product) *dto.ProductResponse {
+	return &dto.ProductResponse{
+		ID:               product.ID,
+		Name:             product.Name,
+		Description:      product.Description,
+		Price:            product.Price,
+		StockQuantity:    product.StockQuantity,
+		ReservedStock:    product.ReservedStock,
+		AvailableStock:   product.AvailableStock(),
+		ReorderThreshold: product.ReorderThreshold,
+		StockStatus:      string(product.StockStatus()),
+		IsActive:         product.IsActive,
+		Version:          product.Version,
+		CreatedAt:        product.CreatedAt,
+		UpdatedAt:        product.UpdatedAt,
+		ParentID:         product.ParentID,
+		SKU:              product.SKU,
+		Options:          product.Options,
+		CategoryID:       product.CategoryID,
+	}
+}
+
+func toVariantResponses(variants []*entity.Product) []dto.ProductResponse {
+	if len(variants) == 0 {
+		return nil
+	}
+	responses := make([]dto.ProductResponse, len(variants))
+	for i, variant := range variants {
+		responses[i] = *toProductResponse(variant)
+	}
+	return responses
+}
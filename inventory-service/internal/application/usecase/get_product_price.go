@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+// GetProductPriceUseCase returns the price a product had at a given moment,
+// e.g. to check the price of an order placed in the past
+type GetProductPriceUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	priceRepo     repository.PriceRepository
+}
+
+func NewGetProductPriceUseCase(
+	inventoryRepo repository.InventoryRepository,
+	priceRepo repository.PriceRepository) *GetProductPriceUseCase {
+	return &GetProductPriceUseCase{
+		inventoryRepo: inventoryRepo,
+		priceRepo:     priceRepo,
+	}
+}
+
+func (uc *GetProductPriceUseCase) Execute(ctx context.Context, productID string, query dto.GetPriceQuery) (*dto.PriceResponse, error) {
+	// Return 404 for unknown products rather than a missing price
+	if _, err := uc.inventoryRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	at := query.At.UTC()
+	if query.At.IsZero() {
+		at = time.Now().UTC()
+	}
+
+	entry, err := uc.priceRepo.GetPriceAt(ctx, productID, at)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PriceResponse{
+		ProductID:     productID,
+		At:            at,
+		Price:         entry.Price,
+		EffectiveFrom: entry.EffectiveFrom,
+		EffectiveTo:   entry.EffectiveTo,
+		Source:        string(entry.Source),
+		ScheduleID:    entry.ScheduleID,
+	}, nil
+}
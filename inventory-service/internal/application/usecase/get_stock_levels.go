@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+// GetStockLevelsUseCase breaks a product's stock down by warehouse
+type GetStockLevelsUseCase struct {
+	inventoryRepo repository.InventoryRepository
+}
+
+func NewGetStockLevelsUseCase(inventoryRepo repository.InventoryRepository) *GetStockLevelsUseCase {
+	return &GetStockLevelsUseCase{
+		inventoryRepo: inventoryRepo,
+	}
+}
+
+func (uc *GetStockLevelsUseCase) Execute(ctx context.Context, productID string) (*dto.StockLevelsResponse, error) {
+	// Unknown products are a 404 rather than an empty breakdown
+	if _, err := uc.inventoryRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	levels, err := uc.inventoryRepo.GetStockLevels(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock levels: %w", err)
+	}
+
+	response := &dto.StockLevelsResponse{
+		ProductID:  productID,
+		Warehouses: make([]dto.WarehouseStockResponse, len(levels)),
+	}
+	for i, level := range levels {
+		response.Warehouses[i] = dto.WarehouseStockResponse{
+			WarehouseID:     level.WarehouseID,
+			WarehouseCode:   level.WarehouseCode,
+			WarehouseName:   level.WarehouseName,
+			WarehouseActive: level.WarehouseActive,
+			StockQuantity:   level.StockQuantity,
+			ReservedStock:   level.ReservedStock,
+			AvailableStock:  level.AvailableStock(),
+		}
+	}
+	return response, nil
+}
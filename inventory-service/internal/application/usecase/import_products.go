@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/messaging"
+)
+
+const (
+	// importBatchSize is the number of rows upserted per transaction
+	importBatchSize = 500
+	// maxReportedImportErrors bounds the report of a file with many bad rows
+	maxReportedImportErrors = 1000
+)
+
+// ImportProductsUseCase creates and updates products and their stock levels
+// from a CSV or JSON Lines file
+type ImportProductsUseCase struct {
+	inventoryRepo               repository.InventoryRepository
+	eventPublisher              *messaging.EventPublisher
+	checkStockLevelsUseCase     *CheckStockLevelsUseCase
+	publishProductEventsUseCase *PublishProductEventsUseCase
+}
+
+func NewImportProductsUseCase(
+	inventoryRepo repository.InventoryRepository,
+	eventPublisher *messaging.EventPublisher,
+	checkStockLevelsUseCase *CheckStockLevelsUseCase,
+	publishProductEventsUseCase *PublishProductEventsUseCase) *ImportProductsUseCase {
+	return &ImportProductsUseCase{
+		inventoryRepo:               inventoryRepo,
+		eventPublisher:              eventPublisher,
+		checkStockLevelsUseCase:     checkStockLevelsUseCase,
+		publishProductEventsUseCase: publishProductEventsUseCase,
+	}
+}
+
+// Execute reads the file row by row and upserts valid rows in batches, each
+// batch in its own transaction. Rows that fail validation or cannot be applied
+// are reported without stopping the import. In a dry run every batch is
+// rolled back, so the report shows what the import would do.
+func (uc *ImportProductsUseCase) Execute(ctx context.Context, format string, file io.Reader, actor string, dryRun bool) (*dto.ProductImportReport, error) {
+	reader, err := newProductRecordReader(format, file)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &dto.ProductImportReport{
+		DryRun: dryRun,
+		Errors: []dto.ProductImportRowError{},
+	}
+	batch := make([]entity.ProductImportRow, 0, importBatchSize)
+	for {
+		record, line, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil && !errors.Is(err, entity.ErrInvalidImportRow) {
+			return nil, fmt.Errorf("failed to read import file: %w", err)
+		}
+		report.TotalRows++
+
+		row := toProductImportRow(record, line)
+		if err == nil {
+			err = row.Validate()
+		}
+		if err != nil {
+			uc.recordFailure(report, line, record.ID, err)
+			continue
+		}
+
+		batch = append(batch, row)
+		if len(batch) == importBatchSize {
+			if err := uc.importBatch(ctx, report, batch, actor, dryRun); err != nil {
+				return nil, err
+			}
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		if err := uc.importBatch(ctx, report, batch, actor, dryRun); err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+func (uc *ImportProductsUseCase) importBatch(ctx context.Context, report *dto.ProductImportReport, batch []entity.ProductImportRow, actor string, dryRun bool) error {
+	results, err := uc.inventoryRepo.ImportProducts(ctx, batch, actor, dryRun)
+	if err != nil {
+		return fmt.Errorf("failed to import products: %w", err)
+	}
+
+	productIDs := make([]string, 0, len(results))
+	stockChangedIDs := make([]string, 0, len(results))
+	for _, result := range results {
+		switch result.Action {
+		case entity.ProductImportCreated:
+			report.Created++
+		case entity.ProductImportUpdated:
+			report.Updated++
+		case entity.ProductImportUnchanged:
+			report.Unchanged++
+		case entity.ProductImportFailed:
+			uc.recordFailure(report, result.Line, result.ProductID, result.Err)
+			continue
+		}
+		productIDs = append(productIDs, result.ProductID)
+
+		if dryRun {
+			continue
+		}
+		switch {
+		case result.Product != nil && result.Previous == nil:
+			uc.publishProductEventsUseCase.Created("", result.Product)
+		case result.Product != nil:
+			uc.publishProductEventsUseCase.Changed("", result.Previous, result.Product)
+		}
+		if result.StockChanged {
+			stockChangedIDs = append(stockChangedIDs, result.ProductID)
+		}
+		for _, allocation := range result.Allocations {
+			publishBackorderFulfilledEvent(uc.eventPublisher, allocation)
+		}
+	}
+
+	if !dryRun {
+		uc.publishProductEventsUseCase.StockChanged(ctx, "", stockChangedIDs...)
+		uc.checkStockLevelsUseCase.Execute(ctx, "", productIDs...)
+	}
+	return nil
+}
+
+func (uc *ImportProductsUseCase) recordFailure(report *dto.ProductImportReport, line int, productID string, err error) {
+	report.Failed++
+	if len(report.Errors) < maxReportedImportErrors {
+		report.Errors = append(report.Errors, dto.ProductImportRowError{
+			Line:      line,
+			ProductID: productID,
+			Error:     err.Error(),
+		})
+	}
+}
+
+func toProductImportRow(record dto.ProductRecord, line int) entity.ProductImportRow {
+	return entity.ProductImportRow{
+		Line:             line,
+		ID:               record.ID,
+		Name:             record.Name,
+		Description:      record.Description,
+		Price:            record.Price,
+		StockQuantity:    record.StockQuantity,
+		ReorderThreshold: record.ReorderThreshold,
+		IsActive:         record.IsActive,
+		WarehouseCode:    record.WarehouseCode,
+		ParentID:         record.ParentID,
+		SKU:              record.SKU,
+		Options:          record.Options,
+	}
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+type ListAttributesUseCase struct {
+	attributeRepo repository.AttributeRepository
+}
+
+func NewListAttributesUseCase(attributeRepo repository.AttributeRepository) *ListAttributesUseCase {
+	return &ListAttributesUseCase{
+		attributeRepo: attributeRepo,
+	}
+}
+
+func (uc *ListAttributesUseCase) Execute(ctx context.Context) (*dto.AttributeListResponse, error) {
+	attributes, err := uc.attributeRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.AttributeListResponse{
+		Attributes: make([]dto.AttributeResponse, len(attributes)),
+	}
+	for i, attribute := range attributes {
+		response.Attributes[i] = *toAttributeResponse(attribute)
+	}
+	return response, nil
+}
+
+func toAttributeResponse(attribute *entity.Attribute) *dto.AttributeResponse {
+	return &dto.AttributeResponse{
+		ID:        attribute.ID,
+		Code:      attribute.Code,
+		Name:      attribute.Name,
+		Type:      string(attribute.Type),
+		CreatedAt: attribute.CreatedAt,
+	}
+}
+
+// toAttributeValues maps a product's attribute values to typed values by code
+func toAttributeValues(attributes []entity.ProductAttribute) map[string]any {
+	values := make(map[string]any, len(attributes))
+	for _, attribute := range attributes {
+		values[attribute.Attribute.Code] = attribute.Attribute.TypedValue(attribute.Value)
+	}
+	return values
+}
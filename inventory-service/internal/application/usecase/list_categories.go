@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+type ListCategoriesUseCase struct {
+	categoryRepo repository.CategoryRepository
+}
+
+func NewListCategoriesUseCase(categoryRepo repository.CategoryRepository) *ListCategoriesUseCase {
+	return &ListCategoriesUseCase{
+		categoryRepo: categoryRepo,
+	}
+}
+
+// Execute returns the category tree, siblings ordered by name
+func (uc *ListCategoriesUseCase) Execute(ctx context.Context) (*dto.CategoryTreeResponse, error) {
+	categories, err := uc.categoryRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[string][]*entity.Category)
+	for _, category := range categories {
+		children[category.ParentID] = append(children[category.ParentID], category)
+	}
+
+	var build func(parentID string) []dto.CategoryResponse
+	build = func(parentID string) []dto.CategoryResponse {
+		nodes := make([]dto.CategoryResponse, len(children[parentID]))
+		for i, category := range children[parentID] {
+			nodes[i] = *toCategoryResponse(category)
+			nodes[i].Children = build(category.ID)
+		}
+		return nodes
+	}
+
+	return &dto.CategoryTreeResponse{Categories: build("")}, nil
+}
+
+func toCategoryResponse(category *entity.Category) *dto.CategoryResponse {
+	return &dto.CategoryResponse{
+		ID:        category.ID,
+		ParentID:  category.ParentID,
+		Name:      category.Name,
+		Slug:      category.Slug,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+type ListPriceHistoryUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	priceRepo     repository.PriceRepository
+}
+
+func NewListPriceHistoryUseCase(
+	inventoryRepo repository.InventoryRepository,
+	priceRepo repository.PriceRepository) *ListPriceHistoryUseCase {
+	return &ListPriceHistoryUseCase{
+		inventoryRepo: inventoryRepo,
+		priceRepo:     priceRepo,
+	}
+}
+
+func (uc *ListPriceHistoryUseCase) Execute(ctx context.Context, productID string, query dto.ListPriceHistoryQuery) (*dto.PriceHistoryResponse, error) {
+	if _, err := uc.inventoryRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	entries, err := uc.priceRepo.ListHistory(ctx, productID, query.Limit, query.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price history: %w", err)
+	}
+
+	total, err := uc.priceRepo.CountHistory(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count price history: %w", err)
+	}
+
+	response := &dto.PriceHistoryResponse{
+		ProductID: productID,
+		Prices:    make([]dto.PriceHistoryEntryResponse, len(entries)),
+		Total:     total,
+		Limit:     query.Limit,
+		Offset:    query.Offset,
+	}
+	for i, entry := range entries {
+		response.Prices[i] = dto.PriceHistoryEntryResponse{
+			Price:         entry.Price,
+			EffectiveFrom: entry.EffectiveFrom,
+			EffectiveTo:   entry.EffectiveTo,
+			Source:        string(entry.Source),
+			ScheduleID:    entry.ScheduleID,
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+type ListProductsUseCase struct {
+	inventoryRepo repository.InventoryRepository
+}
+
+func NewListProductsUseCase(inventoryRepo repository.InventoryRepository) *ListProductsUseCase {
+	return &ListProductsUseCase{
+		inventoryRepo: inventoryRepo,
+	}
+}
+
+// Execute returns a page of the products that are not variants, each with its variants
+func (uc *ListProductsUseCase) Execute(ctx context.Context, query dto.ListProductsQuery) (*dto.ProductListResponse, error) {
+	products, err := uc.inventoryRepo.List(ctx, query.Limit, query.Offset, query.IncludeInactive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+
+	total, err := uc.inventoryRepo.Count(ctx, query.IncludeInactive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count products: %w", err)
+	}
+
+	productIDs := make([]string, len(products))
+	for i, product := range products {
+		productIDs[i] = product.ID
+	}
+	variants, err := uc.inventoryRepo.GetVariants(ctx, productIDs, query.IncludeInactive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variants: %w", err)
+	}
+
+	response := &dto.ProductListResponse{
+		Products: make([]dto.ProductResponse, len(products)),
+		Total:    total,
+		Limit:    query.Limit,
+		Offset:   query.Offset,
+	}
+	for i, product := range products {
+		response.Products[i] = *toProductResponse(product)
+		response.Products[i].Variants = toVariantResponses(variants[product.ID])
+	}
+	return response, nil
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+type ListScheduledPricesUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	priceRepo     repository.PriceRepository
+}
+
+func NewListScheduledPricesUseCase(
+	inventoryRepo repository.InventoryRepository,
+	priceRepo repository.PriceRepository) *ListScheduledPricesUseCase {
+	return &ListScheduledPricesUseCase{
+		inventoryRepo: inventoryRepo,
+		priceRepo:     priceRepo,
+	}
+}
+
+func (uc *ListScheduledPricesUseCase) Execute(ctx context.Context, productID string) (*dto.ScheduledPriceListResponse, error) {
+	if _, err := uc.inventoryRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	schedules, err := uc.priceRepo.ListSchedules(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list scheduled prices: %w", err)
+	}
+
+	response := &dto.ScheduledPriceListResponse{
+		ProductID: productID,
+		Schedules: make([]dto.ScheduledPriceResponse, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		response.Schedules[i] = toScheduledPriceResponse(schedule)
+	}
+	return response, nil
+}
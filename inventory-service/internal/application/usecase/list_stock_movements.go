@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+// defaultMovementWindow is how far back the ledger is queried when no start is given
+const defaultMovementWindow = 30 * 24 * time.Hour
+
+type ListStockMovementsUseCase struct {
+	inventoryRepo repository.InventoryRepository
+}
+
+func NewListStockMovementsUseCase(inventoryRepo repository.InventoryRepository) *ListStockMovementsUseCase {
+	return &ListStockMovementsUseCase{
+		inventoryRepo: inventoryRepo,
+	}
+}
+
+func (uc *ListStockMovementsUseCase) Execute(ctx context.Context, productID string, query dto.ListStockMovementsQuery) (*dto.StockMovementListResponse, error) {
+	// Return 404 for unknown products rather than an empty ledger
+	if _, err := uc.inventoryRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	to := query.To.UTC()
+	if query.To.IsZero() {
+		to = time.Now().UTC()
+	}
+	from := query.From.UTC()
+	if query.From.IsZero() {
+		from = to.Add(-defaultMovementWindow)
+	}
+	if !from.Before(to) {
+		return nil, entity.ErrInvalidTimeRange
+	}
+
+	movements, err := uc.inventoryRepo.ListMovements(ctx, entity.StockMovementFilter{
+		ProductID: productID,
+		From:      from,
+		To:        to,
+		Limit:     query.Limit,
+		Offset:    query.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock movements: %w", err)
+	}
+
+	response := &dto.StockMovementListResponse{
+		ProductID: productID,
+		From:      from,
+		To:        to,
+		Movements: make([]dto.StockMovementResponse, len(movements)),
+		Limit:     query.Limit,
+		Offset:    query.Offset,
+	}
+	for i, movement := range movements {
+		response.Movements[i] = dto.StockMovementResponse{
+			ID:            movement.ID,
+			WarehouseID:   movement.WarehouseID,
+			Type:          string(movement.Type),
+			StockDelta:    movement.StockDelta,
+			ReservedDelta: movement.ReservedDelta,
+			Reason:        movement.Reason,
+			OrderID:       movement.OrderID,
+			CorrelationID: movement.CorrelationID,
+			Actor:         movement.Actor,
+			CreatedAt:     movement.CreatedAt,
+		}
+	}
+	return response, nil
+}
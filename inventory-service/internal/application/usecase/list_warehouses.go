@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+type ListWarehousesUseCase struct {
+	warehouseRepo repository.WarehouseRepository
+}
+
+func NewListWarehousesUseCase(warehouseRepo repository.WarehouseRepository) *ListWarehousesUseCase {
+	return &ListWarehousesUseCase{
+		warehouseRepo: warehouseRepo,
+	}
+}
+
+func (uc *ListWarehousesUseCase) Execute(ctx context.Context) (*dto.WarehouseListResponse, error) {
+	warehouses, err := uc.warehouseRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.WarehouseListResponse{
+		Warehouses: make([]dto.WarehouseResponse, len(warehouses)),
+	}
+	for i, warehouse := range warehouses {
+		response.Warehouses[i] = *toWarehouseResponse(warehouse)
+	}
+	return response, nil
+}
+
+func toWarehouseResponse(warehouse *entity.Warehouse) *dto.WarehouseResponse {
+	response := &dto.WarehouseResponse{
+		ID:        warehouse.ID,
+		Code:      warehouse.Code,
+		Name:      warehouse.Name,
+		Priority:  warehouse.Priority,
+		IsActive:  warehouse.IsActive,
+		CreatedAt: warehouse.CreatedAt,
+		UpdatedAt: warehouse.UpdatedAt,
+	}
+	if warehouse.Location != nil {
+		response.Latitude = &warehouse.Location.Latitude
+		response.Longitude = &warehouse.Location.Longitude
+	}
+	return response
+}
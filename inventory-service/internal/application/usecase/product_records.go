@@ -0,0 +1,303 @@
+package usecase
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+)
+
+// Bulk file formats
+const (
+	FileFormatCSV   = "csv"
+	FileFormatJSONL = "jsonl"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported file format, use csv or jsonl")
+	ErrInvalidCSVHeader  = errors.New("invalid csv header")
+)
+
+// maxJSONLLineSize bounds the memory a single JSON Lines record can take
+const maxJSONLLineSize = 1 << 20
+
+var productCSVColumns = []string{
+	"id", "name", "description", "price", "stock_quantity", "reorder_threshold", "is_active", "warehouse_code",
+	"parent_id", "sku", "options",
+}
+
+// productRecordReader decodes product records one at a time. A record that
+// cannot be decoded returns an error wrapping entity.ErrInvalidImportRow and
+// reading can continue, any other error ends the file.
+type productRecordReader interface {
+	Read() (record dto.ProductRecord, line int, err error)
+}
+
+type productRecordWriter interface {
+	Write(record dto.ProductRecord) error
+	Flush() error
+}
+
+func newProductRecordReader(format string, r io.Reader) (productRecordReader, error) {
+	switch format {
+	case FileFormatCSV:
+		return newCSVProductReader(r)
+	case FileFormatJSONL:
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(make([]byte, 64*1024), maxJSONLLineSize)
+		return &jsonlProductReader{scanner: scanner}, nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+func newProductRecordWriter(format string, w io.Writer) (productRecordWriter, error) {
+	switch format {
+	case FileFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(productCSVColumns); err != nil {
+			return nil, err
+		}
+		return &csvProductWriter{writer: writer}, nil
+	case FileFormatJSONL:
+		buffered := bufio.NewWriter(w)
+		return &jsonlProductWriter{buffered: buffered, encoder: json.NewEncoder(buffered)}, nil
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+// csvProductReader reads records by the column names of the header row, so
+// columns may come in any order and optional ones may be left out
+type csvProductReader struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVProductReader(r io.Reader) (*csvProductReader, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w: the file is empty", ErrInvalidCSVHeader)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCSVHeader, err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if i == 0 {
+			// Spreadsheet exports often start with a byte order mark
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		known := false
+		for _, column := range productCSVColumns {
+			known = known || column == name
+		}
+		if !known {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidCSVHeader, name)
+		}
+		if _, duplicate := columns[name]; duplicate {
+			return nil, fmt.Errorf("%w: duplicate column %q", ErrInvalidCSVHeader, name)
+		}
+		columns[name] = i
+	}
+	for _, required := range []string{"name", "price"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", ErrInvalidCSVHeader, required)
+		}
+	}
+
+	return &csvProductReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvProductReader) Read() (dto.ProductRecord, int, error) {
+	fields, err := r.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return dto.ProductRecord{}, parseErr.StartLine, fmt.Errorf("%w: %v", entity.ErrInvalidImportRow, parseErr.Err)
+		}
+		return dto.ProductRecord{}, 0, err
+	}
+	line, _ := r.reader.FieldPos(0)
+
+	field := func(name string) string {
+		i, ok := r.columns[name]
+		if !ok || i >= len(fields) {
+			return ""
+		}
+		return strings.TrimSpace(fields[i])
+	}
+
+	record := dto.ProductRecord{
+		ID:            field("id"),
+		Name:          field("name"),
+		Description:   field("description"),
+		WarehouseCode: field("warehouse_code"),
+		ParentID:      field("parent_id"),
+		SKU:           field("sku"),
+	}
+	if value := field("price"); value != "" {
+		if record.Price, err = strconv.ParseFloat(value, 64); err != nil {
+			return record, line, fmt.Errorf("%w: price %q is not a number", entity.ErrInvalidImportRow, value)
+		}
+	}
+	if record.StockQuantity, err = parseOptionalInt32(field("stock_quantity")); err != nil {
+		return record, line, fmt.Errorf("%w: stock_quantity %v", entity.ErrInvalidImportRow, err)
+	}
+	if record.ReorderThreshold, err = parseOptionalInt32(field("reorder_threshold")); err != nil {
+		return record, line, fmt.Errorf("%w: reorder_threshold %v", entity.ErrInvalidImportRow, err)
+	}
+	if value := field("is_active"); value != "" {
+		isActive, err := strconv.ParseBool(value)
+		if err != nil {
+			return record, line, fmt.Errorf("%w: is_active %q is not a boolean", entity.ErrInvalidImportRow, value)
+		}
+		record.IsActive = &isActive
+	}
+	if record.Options, err = parseOptions(field("options")); err != nil {
+		return record, line, fmt.Errorf("%w: options %v", entity.ErrInvalidImportRow, err)
+	}
+	return record, line, nil
+}
+
+// parseOptions reads variant options written as "size=L;colour=red", it
+// returns nil for an empty field
+func parseOptions(value string) (map[string]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+	options := make(map[string]string)
+	for _, pair := range strings.Split(value, ";") {
+		name, optionValue, ok := strings.Cut(pair, "=")
+		name, optionValue = strings.TrimSpace(name), strings.TrimSpace(optionValue)
+		if !ok || name == "" || optionValue == "" {
+			return nil, fmt.Errorf("%q is not a name=value pair", pair)
+		}
+		if _, duplicate := options[name]; duplicate {
+			return nil, fmt.Errorf("repeat %q", name)
+		}
+		options[name] = optionValue
+	}
+	return options, nil
+}
+
+// formatOptions writes variant options in the form parseOptions reads, sorted
+// by name so exports are stable
+func formatOptions(options map[string]string) string {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		pairs[i] = name + "=" + options[name]
+	}
+	return strings.Join(pairs, ";")
+}
+
+// parseOptionalInt32 returns nil for an empty field
+func parseOptionalInt32(value string) (*int32, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a whole number", value)
+	}
+	result := int32(parsed)
+	return &result, nil
+}
+
+// jsonlProductReader reads one JSON object per line, skipping blank lines
+type jsonlProductReader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (r *jsonlProductReader) Read() (dto.ProductRecord, int, error) {
+	for r.scanner.Scan() {
+		r.line++
+		data := bytes.TrimSpace(r.scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		var record dto.ProductRecord
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&record); err != nil {
+			return record, r.line, fmt.Errorf("%w: %v", entity.ErrInvalidImportRow, err)
+		}
+		return record, r.line, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return dto.ProductRecord{}, r.line + 1, fmt.Errorf("failed to read line %d: %w", r.line+1, err)
+	}
+	return dto.ProductRecord{}, r.line, io.EOF
+}
+
+type csvProductWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvProductWriter) Write(record dto.ProductRecord) error {
+	formatOptional := func(value *int32) string {
+		if value == nil {
+			return ""
+		}
+		return strconv.FormatInt(int64(*value), 10)
+	}
+	isActive := ""
+	if record.IsActive != nil {
+		isActive = strconv.FormatBool(*record.IsActive)
+	}
+
+	return w.writer.Write([]string{
+		record.ID,
+		record.Name,
+		record.Description,
+		strconv.FormatFloat(record.Price, 'f', 2, 64),
+		formatOptional(record.StockQuantity),
+		formatOptional(record.ReorderThreshold),
+		isActive,
+		record.WarehouseCode,
+		record.ParentID,
+		record.SKU,
+		formatOptions(record.Options),
+	})
+}
+
+func (w *csvProductWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type jsonlProductWriter struct {
+	buffered *bufio.Writer
+	encoder  *json.Encoder
+}
+
+func (w *jsonlProductWriter) Write(record dto.ProductRecord) error {
+	return w.encoder.Encode(record)
+}
+
+func (w *jsonlProductWriter) Flush() error {
+	return w.buffered.Flush()
+}
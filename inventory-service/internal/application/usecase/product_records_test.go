@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"maps"
+	"strings"
+	"testing"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+)
+
+func TestCSVProductReader(t *testing.T) {
+	file := "price,name,stock_quantity\n" +
+		"9.99,Mug,12\n" +
+		"abc,Broken,1\n" +
+		"5,Plate,\n"
+
+	reader, err := newProductRecordReader(FileFormatCSV, strings.NewReader(file))
+	if err != nil {
+		t.Fatalf("unexpected header error: %v", err)
+	}
+
+	record, line, err := reader.Read()
+	if err != nil || line != 2 || record.Name != "Mug" || record.Price != 9.99 || *record.StockQuantity != 12 {
+		t.Fatalf("got %+v on line %d, %v", record, line, err)
+	}
+	_, line, err = reader.Read()
+	if !errors.Is(err, entity.ErrInvalidImportRow) || line != 3 {
+		t.Fatalf("expected an invalid row on line 3, got line %d, %v", line, err)
+	}
+	record, _, err = reader.Read()
+	if err != nil || record.StockQuantity != nil {
+		t.Fatalf("expected an empty stock quantity to leave stock unchanged, got %+v, %v", record, err)
+	}
+	if _, _, err = reader.Read(); !errors.Is(err, io.EOF) {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
+
+func TestCSVProductReaderRejectsHeader(t *testing.T) {
+	for _, header := range []string{"", "name,price,colour\n", "name,name,price\n", "name,stock_quantity\n"} {
+		if _, err := newProductRecordReader(FileFormatCSV, strings.NewReader(header)); !errors.Is(err, ErrInvalidCSVHeader) {
+			t.Errorf("header %q: expected ErrInvalidCSVHeader, got %v", header, err)
+		}
+	}
+}
+
+func TestProductRecordsRoundTrip(t *testing.T) {
+	stock, threshold, active := int32(7), int32(2), false
+	want := dto.ProductRecord{
+		ID:               "4c7f1c55-5f8e-4d0a-9d4e-6a4f5bd0d6a1",
+		Name:             "Teapot, large",
+		Description:      "Holds \"1.5\" litres",
+		Price:            24.5,
+		StockQuantity:    &stock,
+		ReorderThreshold: &threshold,
+		IsActive:         &active,
+		WarehouseCode:    "berlin",
+		ParentID:         "9b2e4a7c-0d3f-4e5a-8b6c-1f2d3e4a5b6c",
+		SKU:              "TEA-L-RED",
+		Options:          map[string]string{"size": "L", "colour": "red"},
+	}
+
+	for _, format := range []string{FileFormatCSV, FileFormatJSONL} {
+		var buf bytes.Buffer
+		writer, err := newProductRecordWriter(format, &buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Write(want); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Flush(); err != nil {
+			t.Fatal(err)
+		}
+
+		reader, err := newProductRecordReader(format, &buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, _, err := reader.Read()
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if got.ID != want.ID || got.Name != want.Name || got.Description != want.Description ||
+			got.Price != want.Price || *got.StockQuantity != stock || *got.ReorderThreshold != threshold ||
+			*got.IsActive != active || got.WarehouseCode != want.WarehouseCode ||
+			got.ParentID != want.ParentID || got.SKU != want.SKU || !maps.Equal(got.Options, want.Options) {
+			t.Errorf("%s: got %+v, want %+v", format, got, want)
+		}
+	}
+}
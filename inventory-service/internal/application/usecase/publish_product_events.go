@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/events"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/messaging"
+)
+
+// eventPublisher publishes events, implemented by *messaging.EventPublisher
+type eventPublisher interface {
+	Publish(routingKey string, event interface{}) error
+}
+
+// PublishProductEventsUseCase publishes the product events other services
+// build their product projections from. It runs after the change has been
+// committed, so failures are logged and never undo the change.
+type PublishProductEventsUseCase struct {
+	inventoryRepo  repository.InventoryRepository
+	eventPublisher eventPublisher
+}
+
+func NewPublishProductEventsUseCase(
+	inventoryRepo repository.InventoryRepository,
+	eventPublisher *messaging.EventPublisher) *PublishProductEventsUseCase {
+	return &PublishProductEventsUseCase{
+		inventoryRepo:  inventoryRepo,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Created publishes product.created, followed by product.stock_changed when
+// the product starts with stock
+func (uc *PublishProductEventsUseCase) Created(correlationID string, product *entity.Product) {
+	if correlationID == "" {
+		correlationID = uuid.New().String()
+	}
+
+	uc.publish(events.ProductCreatedEventType, toProductEvent(events.ProductCreatedEventType, product, correlationID))
+	if product.StockQuantity > 0 {
+		uc.publishStockChanged(product, correlationID)
+	}
+}
+
+// Changed publishes product.updated with the product's new state, followed by
+// product.price_changed and product.deactivated when the change includes them
+func (uc *PublishProductEventsUseCase) Changed(correlationID string, previous, product *entity.Product) {
+	if correlationID == "" {
+		correlationID = uuid.New().String()
+	}
+
+	uc.publish(events.ProductUpdatedEventType, toProductEvent(events.ProductUpdatedEventType, product, correlationID))
+
+	if product.Price != previous.Price {
+		priceChangedEvent := events.ProductPriceChangedEvent{
+			BaseEvent:     events.NewBaseEvent(events.ProductPriceChangedEventType, product.ID, correlationID),
+			ProductID:     product.ID,
+			SKU:           product.SKU,
+			PreviousPrice: previous.Price,
+			Price:         product.Price,
+			Version:       int(product.Version),
+		}
+		uc.publish(events.ProductPriceChangedEventType, priceChangedEvent)
+	}
+
+	if previous.IsActive && !product.IsActive {
+		uc.publish(events.ProductDeactivatedEventType, toProductEvent(events.ProductDeactivatedEventType, product, correlationID))
+	}
+}
+
+// StockChanged publishes product.stock_changed with the current stock of each
+// product, correlationID ties the events to the order that changed the stock
+// and is generated when empty
+func (uc *PublishProductEventsUseCase) StockChanged(ctx context.Context, correlationID string, productIDs ...string) {
+	if correlationID == "" {
+		correlationID = uuid.New().String()
+	}
+
+	published := make(map[string]bool, len(productIDs))
+	for _, productID := range productIDs {
+		if published[productID] {
+			continue
+		}
+		published[productID] = true
+
+		product, err := uc.inventoryRepo.GetByID(ctx, productID)
+		if err != nil {
+			fmt.Printf("ERROR: Failed to get product %s for stock event: %v\n", productID, err)
+			continue
+		}
+		uc.publishStockChanged(product, correlationID)
+	}
+}
+
+func (uc *PublishProductEventsUseCase) publishStockChanged(product *entity.Product, correlationID string) {
+	stockChangedEvent := events.ProductStockChangedEvent{
+		BaseEvent:      events.NewBaseEvent(events.ProductStockChangedEventType, product.ID, correlationID),
+		ProductID:      product.ID,
+		SKU:            product.SKU,
+		StockQuantity:  int(product.StockQuantity),
+		ReservedStock:  int(product.ReservedStock),
+		AvailableStock: int(product.AvailableStock()),
+		Version:        int(product.Version),
+	}
+	uc.publish(events.ProductStockChangedEventType, stockChangedEvent)
+}
+
+func (uc *PublishProductEventsUseCase) publish(eventType string, event interface{}) {
+	if err := uc.eventPublisher.Publish(eventType, event); err != nil {
+		fmt.Printf("ERROR: Failed to publish %s event: %v\n", eventType, err)
+	}
+}
+
+func toProductEvent(eventType string, product *entity.Product, correlationID string) events.ProductEvent {
+	return events.ProductEvent{
+		BaseEvent:   events.NewBaseEvent(eventType, product.ID, correlationID),
+		ProductID:   product.ID,
+		ParentID:    product.ParentID,
+		SKU:         product.SKU,
+		Name:        product.Name,
+		Description: product.Description,
+		Price:       product.Price,
+		IsActive:    product.IsActive,
+		CategoryID:  product.CategoryID,
+		Options:     product.Options,
+		Version:     int(product.Version),
+	}
+}
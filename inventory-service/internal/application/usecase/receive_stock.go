@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/events"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/messaging"
+)
+
+// ReceiveStockUseCase books arriving stock and hands it to waiting backorders
+type ReceiveStockUseCase struct {
+	inventoryRepo               repository.InventoryRepository
+	eventPublisher              *messaging.EventPublisher
+	checkStockLevelsUseCase     *CheckStockLevelsUseCase
+	publishProductEventsUseCase *PublishProductEventsUseCase
+}
+
+func NewReceiveStockUseCase(
+	inventoryRepo repository.InventoryRepository,
+	eventPublisher *messaging.EventPublisher,
+	checkStockLevelsUseCase *CheckStockLevelsUseCase,
+	publishProductEventsUseCase *PublishProductEventsUseCase) *ReceiveStockUseCase {
+	return &ReceiveStockUseCase{
+		inventoryRepo:               inventoryRepo,
+		eventPublisher:              eventPublisher,
+		checkStockLevelsUseCase:     checkStockLevelsUseCase,
+		publishProductEventsUseCase: publishProductEventsUseCase,
+	}
+}
+
+func (uc *ReceiveStockUseCase) Execute(ctx context.Context, change entity.StockChange) ([]entity.BackorderAllocation, error) {
+	allocations, err := uc.inventoryRepo.ReceiveStock(ctx, change)
+	if err != nil {
+		return nil, fmt.Errorf("failed to receive stock: %w", err)
+	}
+
+	for _, allocation := range allocations {
+		publishBackorderFulfilledEvent(uc.eventPublisher, allocation)
+	}
+	uc.publishProductEventsUseCase.StockChanged(ctx, "", change.ProductID)
+	uc.checkStockLevelsUseCase.Execute(ctx, "", change.ProductID)
+
+	return allocations, nil
+}
+
+func publishBackorderFulfilledEvent(eventPublisher *messaging.EventPublisher, allocation entity.BackorderAllocation) {
+	fulfilledEvent := events.InventoryBackorderFulfilledEvent{
+		BaseEvent: events.NewBaseEvent(
+			events.InventoryBackorderFulfilledEventType,
+			allocation.OrderID,
+			allocation.CorrelationID,
+		),
+		OrderID:           allocation.OrderID,
+		ProductID:         allocation.ProductID,
+		WarehouseID:       allocation.WarehouseID,
+		Quantity:          int(allocation.Quantity),
+		RemainingQuantity: int(allocation.RemainingQuantity),
+	}
+
+	if err := eventPublisher.Publish("inventory.backorder_fulfilled", fulfilledEvent); err != nil {
+		fmt.Printf("ERROR: Failed to publish inventory.backorder_fulfilled event: %v\n", err)
+	}
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+// ReconcileStockUseCase recomputes stock_quantity and reserved_stock from the
+// stock ledger, reports products that drifted and optionally repairs them.
+// Repaired products get the same stock events as any other stock change.
+type ReconcileStockUseCase struct {
+	inventoryRepo               repository.InventoryRepository
+	checkStockLevelsUseCase     *CheckStockLevelsUseCase
+	publishProductEventsUseCase *PublishProductEventsUseCase
+}
+
+func NewReconcileStockUseCase(
+	inventoryRepo repository.InventoryRepository,
+	checkStockLevelsUseCase *CheckStockLevelsUseCase,
+	publishProductEventsUseCase *PublishProductEventsUseCase) *ReconcileStockUseCase {
+	return &ReconcileStockUseCase{
+		inventoryRepo:               inventoryRepo,
+		checkStockLevelsUseCase:     checkStockLevelsUseCase,
+		publishProductEventsUseCase: publishProductEventsUseCase,
+	}
+}
+
+func (uc *ReconcileStockUseCase) Execute(ctx context.Context, repair bool) (*dto.ReconciliationReport, error) {
+	report := &dto.ReconciliationReport{
+		Repair:     repair,
+		Mismatches: []dto.StockMismatchResponse{},
+		StartedAt:  time.Now().UTC(),
+	}
+
+	reconciliations, err := uc.inventoryRepo.GetStockReconciliations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconcile stock: %w", err)
+	}
+	report.CheckedProducts = len(reconciliations)
+
+	var repairedIDs []string
+	for _, reconciliation := range reconciliations {
+		if !reconciliation.HasDrift() {
+			continue
+		}
+
+		if repair {
+			// Re-checked under lock, the drift may have been a change in flight
+			found, err := uc.inventoryRepo.RepairStockLevels(ctx, reconciliation.ProductID)
+			if err != nil {
+				mismatch := toStockMismatchResponse(reconciliation)
+				mismatch.Error = err.Error()
+				report.Mismatches = append(report.Mismatches, mismatch)
+				log.Printf("ERROR: Failed to repair stock of product %s: %v", reconciliation.ProductID, err)
+				continue
+			}
+			if !found.HasDrift() {
+				continue
+			}
+			reconciliation = *found
+		}
+
+		mismatch := toStockMismatchResponse(reconciliation)
+		mismatch.Repaired = repair
+		if repair {
+			report.Repaired++
+			repairedIDs = append(repairedIDs, reconciliation.ProductID)
+		}
+		report.Mismatches = append(report.Mismatches, mismatch)
+
+		log.Printf("Stock mismatch for product %s: stock %d (ledger %d), reserved %d (ledger %d), %d warehouse(s) drifted, repaired: %t",
+			reconciliation.ProductID,
+			reconciliation.StockQuantity, reconciliation.LedgerStock,
+			reconciliation.ReservedStock, reconciliation.LedgerReserved,
+			len(reconciliation.WarehouseMismatches),
+			repair)
+	}
+
+	if len(repairedIDs) > 0 {
+		uc.publishProductEventsUseCase.StockChanged(ctx, "", repairedIDs...)
+		uc.checkStockLevelsUseCase.Execute(ctx, "", repairedIDs...)
+	}
+
+	report.FinishedAt = time.Now().UTC()
+	return report, nil
+}
+
+func toStockMismatchResponse(reconciliation entity.StockReconciliation) dto.StockMismatchResponse {
+	warehouses := make([]dto.WarehouseStockMismatchResponse, len(reconciliation.WarehouseMismatches))
+	for i, warehouse := range reconciliation.WarehouseMismatches {
+		warehouses[i] = dto.WarehouseStockMismatchResponse{
+			WarehouseID:    warehouse.WarehouseID,
+			StockQuantity:  warehouse.StockQuantity,
+			LedgerStock:    warehouse.LedgerStock,
+			ReservedStock:  warehouse.ReservedStock,
+			LedgerReserved: warehouse.LedgerReserved,
+		}
+	}
+
+	return dto.StockMismatchResponse{
+		ProductID:      reconciliation.ProductID,
+		ProductName:    reconciliation.ProductName,
+		StockQuantity:  reconciliation.StockQuantity,
+		LedgerStock:    reconciliation.LedgerStock,
+		StockDrift:     reconciliation.StockDrift(),
+		ReservedStock:  reconciliation.ReservedStock,
+		LedgerReserved: reconciliation.LedgerReserved,
+		ReservedDrift:  reconciliation.ReservedDrift(),
+		Warehouses:     warehouses,
+	}
+}
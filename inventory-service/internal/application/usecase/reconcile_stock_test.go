@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+// reconcileRepository reports fixed reconciliations and records every write
+type reconcileRepository struct {
+	repository.InventoryRepository
+	reconciliations []entity.StockReconciliation
+	// found is what RepairStockLevels finds under lock, the listed drift when missing
+	found     map[string]entity.StockReconciliation
+	repairErr map[string]error
+
+	repaired       []string
+	statusesFor    []string
+	stockEventsFor []string
+}
+
+func (r *reconcileRepository) GetStockReconciliations(ctx context.Context) ([]entity.StockReconciliation, error) {
+	return r.reconciliations, nil
+}
+
+func (r *reconcileRepository) RepairStockLevels(ctx context.Context, productID string) (*entity.StockReconciliation, error) {
+	if err := r.repairErr[productID]; err != nil {
+		return nil, err
+	}
+	r.repaired = append(r.repaired, productID)
+	if found, ok := r.found[productID]; ok {
+		return &found, nil
+	}
+	for _, reconciliation := range r.reconciliations {
+		if reconciliation.ProductID == productID {
+			return &reconciliation, nil
+		}
+	}
+	return nil, entity.ErrProductNotFound
+}
+
+func (r *reconcileRepository) GetByID(ctx context.Context, id string) (*entity.Product, error) {
+	r.stockEventsFor = append(r.stockEventsFor, id)
+	return &entity.Product{ID: id}, nil
+}
+
+func (r *reconcileRepository) UpdateStockStatuses(ctx context.Context, productIDs []string) ([]entity.StockStatusChange, error) {
+	r.statusesFor = append(r.statusesFor, productIDs...)
+	return nil, nil
+}
+
+type recordingPublisher struct {
+	eventTypes []string
+}
+
+func (p *recordingPublisher) Publish(routingKey string, event interface{}) error {
+	p.eventTypes = append(p.eventTypes, routingKey)
+	return nil
+}
+
+func newTestReconcileStockUseCase(repo *reconcileRepository, publisher *recordingPublisher) *ReconcileStockUseCase {
+	return NewReconcileStockUseCase(
+		repo,
+		&CheckStockLevelsUseCase{inventoryRepo: repo, eventPublisher: publisher},
+		&PublishProductEventsUseCase{inventoryRepo: repo, eventPublisher: publisher},
+	)
+}
+
+var testReconciliations = []entity.StockReconciliation{
+	{ProductID: "in-sync", StockQuantity: 10, LedgerStock: 10, ReservedStock: 2, LedgerReserved: 2},
+	{ProductID: "stock-drift", StockQuantity: 12, LedgerStock: 10, ReservedStock: 1, LedgerReserved: 3},
+	{
+		ProductID: "warehouse-drift", StockQuantity: 5, LedgerStock: 5,
+		WarehouseMismatches: []entity.WarehouseStockReconciliation{{WarehouseID: "w-1", StockQuantity: 4, LedgerStock: 5}},
+	},
+}
+
+func TestReconcileStockReportsDrift(t *testing.T) {
+	repo := &reconcileRepository{reconciliations: testReconciliations}
+	publisher := &recordingPublisher{}
+
+	report, err := newTestReconcileStockUseCase(repo, publisher).Execute(context.Background(), false)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if report.CheckedProducts != 3 || len(report.Mismatches) != 2 || report.Repaired != 0 || report.Unresolved() != 2 {
+		t.Fatalf("report = %+v, want 2 unresolved mismatches of 3 products", report)
+	}
+	stock, warehouse := report.Mismatches[0], report.Mismatches[1]
+	if stock.ProductID != "stock-drift" || stock.StockDrift != 2 || stock.ReservedDrift != -2 || stock.Repaired {
+		t.Errorf("stock mismatch = %+v, want drifts of 2 and -2", stock)
+	}
+	if warehouse.ProductID != "warehouse-drift" || warehouse.StockDrift != 0 || len(warehouse.Warehouses) != 1 || warehouse.Warehouses[0].WarehouseID != "w-1" {
+		t.Errorf("warehouse mismatch = %+v, want warehouse w-1", warehouse)
+	}
+
+	// A dry run writes nothing and has nothing to announce
+	if len(repo.repaired) != 0 || len(repo.statusesFor) != 0 || len(publisher.eventTypes) != 0 {
+		t.Errorf("dry run repaired %v, checked stock levels of %v and published %v", repo.repaired, repo.statusesFor, publisher.eventTypes)
+	}
+}
+
+func TestReconcileStockRepairs(t *testing.T) {
+	repo := &reconcileRepository{
+		reconciliations: append(slices.Clone(testReconciliations), entity.StockReconciliation{ProductID: "failing", StockQuantity: 1}),
+		// The warehouse drift was a change in flight, it is gone under lock
+		found:     map[string]entity.StockReconciliation{"warehouse-drift": {ProductID: "warehouse-drift", StockQuantity: 5, LedgerStock: 5}},
+		repairErr: map[string]error{"failing": errors.New("connection reset")},
+	}
+	publisher := &recordingPublisher{}
+
+	report, err := newTestReconcileStockUseCase(repo, publisher).Execute(context.Background(), true)
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if report.Repaired != 1 || len(report.Mismatches) != 2 || report.Unresolved() != 1 {
+		t.Fatalf("report = %+v, want 1 repaired and 1 unresolved mismatch", report)
+	}
+	if repaired := report.Mismatches[0]; repaired.ProductID != "stock-drift" || !repaired.Repaired {
+		t.Errorf("first mismatch = %+v, want stock-drift repaired", repaired)
+	}
+	if failed := report.Mismatches[1]; failed.ProductID != "failing" || failed.Repaired || failed.Error == "" {
+		t.Errorf("second mismatch = %+v, want the failed repair with its error", failed)
+	}
+
+	// Only the repaired product changed, so only it gets stock events
+	if !slices.Equal(repo.stockEventsFor, []string{"stock-drift"}) || !slices.Equal(repo.statusesFor, []string{"stock-drift"}) {
+		t.Errorf("stock events for %v and stock levels checked for %v, want stock-drift", repo.stockEventsFor, repo.statusesFor)
+	}
+	if len(publisher.eventTypes) != 1 {
+		t.Errorf("published %v, want one stock changed event", publisher.eventTypes)
+	}
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
@@ -11,86 +12,144 @@ import (
 )
 
 type ReserveStockUseCase struct {
-	inventoryRepo  repository.InventoryRepository
-	eventPublisher *messaging.EventPublisher
+	inventoryRepo               repository.InventoryRepository
+	eventPublisher              *messaging.EventPublisher
+	allocationStrategy          entity.AllocationStrategy
+	checkStockLevelsUseCase     *CheckStockLevelsUseCase
+	publishProductEventsUseCase *PublishProductEventsUseCase
 }
 
 func NewReserveStockUseCase(
 	inventoryRepo repository.InventoryRepository,
-	eventPublisher *messaging.EventPublisher) *ReserveStockUseCase {
+	eventPublisher *messaging.EventPublisher,
+	allocationStrategy entity.AllocationStrategy,
+	checkStockLevelsUseCase *CheckStockLevelsUseCase,
+	publishProductEventsUseCase *PublishProductEventsUseCase) *ReserveStockUseCase {
 	return &ReserveStockUseCase{
-		inventoryRepo:  inventoryRepo,
-		eventPublisher: eventPublisher,
+		inventoryRepo:               inventoryRepo,
+		eventPublisher:              eventPublisher,
+		allocationStrategy:          allocationStrategy,
+		checkStockLevelsUseCase:     checkStockLevelsUseCase,
+		publishProductEventsUseCase: publishProductEventsUseCase,
 	}
 }
 
 func (uc *ReserveStockUseCase) Execute(ctx context.Context, event events.OrderCreatedEvent) error {
-	productIDs := make([]string, 0, len(event.Items))
-	for _, item := range event.Items {
-		productIDs = append(productIDs, item.ProductID)
+	items := make([]entity.StockReservation, len(event.Items))
+	for i, item := range event.Items {
+		items[i] = entity.StockReservation{
+			ProductID: item.ProductID,
+			Quantity:  int32(item.Quantity),
+		}
 	}
 
-	products, err := uc.inventoryRepo.GetByIDs(ctx, productIDs)
-	if err != nil {
-		uc.publishFailureEvent(event.CorrelationID, event.OrderID, "failed to fetch products")
-		return fmt.Errorf("failed to get products: %w", err)
+	policy := entity.AllocationPolicy{Strategy: uc.allocationStrategy}
+	if event.ShippingLocation != nil {
+		policy.Destination = &entity.Location{
+			Latitude:  event.ShippingLocation.Latitude,
+			Longitude: event.ShippingLocation.Longitude,
+		}
 	}
 
-	productMap := make(map[string]*entity.Product)
-	for _, product := range products {
-		productMap[product.ID] = product
+	switch event.FulfillmentPolicy {
+	case events.FulfillmentPolicyAllowPartial, events.FulfillmentPolicyBackorder:
+		return uc.reserveAvailable(ctx, event, items, policy)
+	default:
+		return uc.reserveAll(ctx, event, items, policy)
 	}
+}
 
-	for _, item := range event.Items {
-		product, exists := productMap[item.ProductID]
-
-		// Check if product exists
-		if !exists {
-			msg := fmt.Sprintf("product %s not found", item.ProductID)
-			uc.publishFailureEvent(event.CorrelationID, event.OrderID, msg)
-			return fmt.Errorf(msg)
-		}
-
-		// Check if we can reserve (product active, enough stock, etc.)
-		if err := product.CanReserve(int32(item.Quantity)); err != nil {
-			msg := fmt.Sprintf("cannot reserve product %s: %v", item.ProductID, err)
-			uc.publishFailureEvent(event.CorrelationID, event.OrderID, msg)
-			return fmt.Errorf(msg)
+// reserveAll reserves every item or none of them
+func (uc *ReserveStockUseCase) reserveAll(ctx context.Context, event events.OrderCreatedEvent, items []entity.StockReservation, policy entity.AllocationPolicy) error {
+	// Reserve in SQL rather than read-modify-write so concurrent orders cannot oversell
+	outcomes, err := uc.inventoryRepo.ReserveStock(ctx, event.OrderID, event.CorrelationID, items, policy)
+	if err != nil {
+		var reservationErr *entity.StockReservationError
+		if errors.As(err, &reservationErr) {
+			// The order is rejected, retrying the event would not change that
+			uc.publishFailureEvent(event.CorrelationID, event.OrderID, reservationErr.ProductID, reservationErr.Error())
+			return nil
 		}
+		return fmt.Errorf("failed to reserve stock: %w", err)
 	}
 
-	for _, item := range event.Items {
-		product := productMap[item.ProductID]
-		if err := product.ReserveStock(int32(item.Quantity)); err != nil {
-			uc.publishFailureEvent(event.CorrelationID, event.OrderID, err.Error())
-			return err
+	uc.publishSuccessEvent(event.CorrelationID, event.OrderID, outcomes)
+	uc.checkStockLevels(ctx, event.CorrelationID, outcomes)
+
+	return nil
+}
+
+// reserveAvailable reserves what is in stock and, for the backorder policy,
+// backorders the rest. The order only fails if no line can be fulfilled at all.
+func (uc *ReserveStockUseCase) reserveAvailable(ctx context.Context, event events.OrderCreatedEvent, items []entity.StockReservation, policy entity.AllocationPolicy) error {
+	backorder := event.FulfillmentPolicy == events.FulfillmentPolicyBackorder
+
+	outcomes, err := uc.inventoryRepo.ReserveAvailableStock(ctx, event.OrderID, event.CorrelationID, items, policy, backorder)
+	if err != nil {
+		var reservationErr *entity.StockReservationError
+		if errors.As(err, &reservationErr) {
+			uc.publishFailureEvent(event.CorrelationID, event.OrderID, reservationErr.ProductID, reservationErr.Error())
+			return nil
 		}
+		return fmt.Errorf("failed to reserve stock: %w", err)
 	}
 
-	if err := uc.inventoryRepo.UpdateMultiple(ctx, products); err != nil {
-		msg := "failed to save reserved stock to database"
-		uc.publishFailureEvent(event.CorrelationID, event.OrderID, msg)
-		return fmt.Errorf("%s: %w", msg, err)
-	}
+	uc.checkStockLevels(ctx, event.CorrelationID, outcomes)
 
-	uc.publishSuccessEvent(event.CorrelationID, event.OrderID, event.Items)
+	for _, outcome := range outcomes {
+		if outcome.IsFulfillable() {
+			uc.publishSuccessEvent(event.CorrelationID, event.OrderID, outcomes)
+			return nil
+		}
+	}
 
+	uc.publishFailureEvent(event.CorrelationID, event.OrderID, "", "none of the ordered products are available")
 	return nil
+}
 
+// checkStockLevels publishes the new stock of the reserved products and alerts
+// on those the reservation took below their reorder threshold
+func (uc *ReserveStockUseCase) checkStockLevels(ctx context.Context, correlationID string, outcomes []entity.ReservationOutcome) {
+	productIDs := make([]string, 0, len(outcomes))
+	for _, outcome := range outcomes {
+		if outcome.Reserved > 0 {
+			productIDs = append(productIDs, outcome.ProductID)
+		}
+	}
+	uc.publishProductEventsUseCase.StockChanged(ctx, correlationID, productIDs...)
+	uc.checkStockLevelsUseCase.Execute(ctx, correlationID, productIDs...)
 }
 
-func (uc *ReserveStockUseCase) publishSuccessEvent(correlationID, orderID string, items []events.OrderItem) {
-	reservations := make([]events.InventoryReservation, len(items))
-	for i, item := range items {
+func (uc *ReserveStockUseCase) publishSuccessEvent(correlationID, orderID string, outcomes []entity.ReservationOutcome) {
+	reservations := make([]events.InventoryReservation, len(outcomes))
+	for i, outcome := range outcomes {
+		allocations := make([]events.InventoryAllocation, len(outcome.Allocations))
+		for j, allocation := range outcome.Allocations {
+			allocations[j] = events.InventoryAllocation{
+				WarehouseID:   allocation.WarehouseID,
+				WarehouseCode: allocation.WarehouseCode,
+				Quantity:      int(allocation.Quantity),
+			}
+		}
 		reservations[i] = events.InventoryReservation{
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
+			ProductID:           outcome.ProductID,
+			SKU:                 outcome.SKU,
+			Quantity:            int(outcome.Reserved),
+			RequestedQuantity:   int(outcome.Requested),
+			BackorderedQuantity: int(outcome.Backordered),
+			Status:              reservationStatus(outcome),
+			Allocations:         allocations,
+		}
+		if len(allocations) == 1 {
+			reservations[i].WarehouseID = allocations[0].WarehouseID
 		}
 	}
 	reservedEvent := events.InventoryReservedEvent{
-		BaseEvent: events.BaseEvent{
-			CorrelationID: correlationID,
-		},
+		BaseEvent: events.NewBaseEvent(
+			events.InventoryReservedEventType,
+			orderID,
+			correlationID,
+		),
 		OrderID:      orderID,
 		Reservations: reservations,
 	}
@@ -101,16 +160,32 @@ func (uc *ReserveStockUseCase) publishSuccessEvent(correlationID, orderID string
 	}
 }
 
-func (uc *ReserveStockUseCase) publishFailureEvent(correlationID, orderID, reason string) {
+func (uc *ReserveStockUseCase) publishFailureEvent(correlationID, orderID, productID, reason string) {
 	failedEvent := events.InventoryReservationFailedEvent{
-		BaseEvent: events.BaseEvent{
-			CorrelationID: correlationID,
-		},
-		OrderID: orderID,
-		Reason:  reason,
+		BaseEvent: events.NewBaseEvent(
+			events.InventoryReservationFailedEventType,
+			orderID,
+			correlationID,
+		),
+		OrderID:   orderID,
+		ProductID: productID,
+		Reason:    reason,
 	}
 
 	if err := uc.eventPublisher.Publish("inventory.reservation_failed", failedEvent); err != nil {
 		fmt.Printf("ERROR: Failed to publish inventory.reservation_failed event: %v\n", err)
 	}
 }
+
+func reservationStatus(outcome entity.ReservationOutcome) string {
+	switch {
+	case outcome.Reserved == outcome.Requested:
+		return events.ReservationStatusReserved
+	case outcome.Backordered > 0:
+		return events.ReservationStatusBackordered
+	case outcome.Reserved > 0:
+		return events.ReservationStatusPartiallyReserved
+	default:
+		return events.ReservationStatusUnavailable
+	}
+}
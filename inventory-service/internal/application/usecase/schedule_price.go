@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+// SchedulePriceUseCase schedules a future price change of a product
+type SchedulePriceUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	priceRepo     repository.PriceRepository
+}
+
+func NewSchedulePriceUseCase(
+	inventoryRepo repository.InventoryRepository,
+	priceRepo repository.PriceRepository) *SchedulePriceUseCase {
+	return &SchedulePriceUseCase{
+		inventoryRepo: inventoryRepo,
+		priceRepo:     priceRepo,
+	}
+}
+
+// Execute schedules the change. A start in the past is applied by the next
+// run of the price scheduler.
+func (uc *SchedulePriceUseCase) Execute(ctx context.Context, productID string, req dto.SchedulePriceRequest) (*dto.ScheduledPriceResponse, error) {
+	if _, err := uc.inventoryRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	schedule := &entity.ScheduledPrice{
+		ID:        uuid.New().String(),
+		ProductID: productID,
+		Price:     req.Price,
+		StartsAt:  req.StartsAt.UTC(),
+		Reason:    req.Reason,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if req.EndsAt != nil {
+		endsAt := req.EndsAt.UTC()
+		schedule.EndsAt = &endsAt
+	}
+	if err := schedule.Validate(now); err != nil {
+		return nil, err
+	}
+
+	if err := uc.priceRepo.CreateSchedule(ctx, schedule); err != nil {
+		return nil, fmt.Errorf("failed to schedule price: %w", err)
+	}
+
+	response := toScheduledPriceResponse(*schedule)
+	return &response, nil
+}
+
+func toScheduledPriceResponse(schedule entity.ScheduledPrice) dto.ScheduledPriceResponse {
+	return dto.ScheduledPriceResponse{
+		ID:            schedule.ID,
+		ProductID:     schedule.ProductID,
+		Price:         schedule.Price,
+		StartsAt:      schedule.StartsAt,
+		EndsAt:        schedule.EndsAt,
+		PreviousPrice: schedule.PreviousPrice,
+		Status:        string(schedule.Status),
+		Reason:        schedule.Reason,
+		CreatedAt:     schedule.CreatedAt,
+		UpdatedAt:     schedule.UpdatedAt,
+	}
+}
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+// SearchProductsUseCase searches the active catalogue and counts the matches
+// by category, price range and attribute value
+type SearchProductsUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	categoryRepo  repository.CategoryRepository
+	attributeRepo repository.AttributeRepository
+}
+
+func NewSearchProductsUseCase(
+	inventoryRepo repository.InventoryRepository,
+	categoryRepo repository.CategoryRepository,
+	attributeRepo repository.AttributeRepository) *SearchProductsUseCase {
+	return &SearchProductsUseCase{
+		inventoryRepo: inventoryRepo,
+		categoryRepo:  categoryRepo,
+		attributeRepo: attributeRepo,
+	}
+}
+
+func (uc *SearchProductsUseCase) Execute(ctx context.Context, query dto.SearchProductsQuery) (*dto.ProductSearchResponse, error) {
+	if query.MinPrice != nil && query.MaxPrice != nil && *query.MinPrice > *query.MaxPrice {
+		return nil, entity.ErrInvalidPriceRange
+	}
+
+	definitions, err := uc.attributeRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	attributes := make(map[string]*entity.Attribute, len(definitions))
+	for _, attribute := range definitions {
+		attributes[attribute.Code] = attribute
+	}
+
+	// Filter values arrive as text, compare them in their attribute's canonical form
+	filters := make(map[string]string, len(query.Attributes))
+	for code, value := range query.Attributes {
+		attribute, ok := attributes[code]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown attribute %q", entity.ErrInvalidAttributeValue, code)
+		}
+		if filters[code], err = attribute.CanonicalValue(value); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := uc.inventoryRepo.Search(ctx, entity.ProductSearch{
+		Text:       query.Q,
+		CategoryID: query.CategoryID,
+		MinPrice:   query.MinPrice,
+		MaxPrice:   query.MaxPrice,
+		InStock:    query.InStock,
+		Attributes: filters,
+		Sort:       entity.ProductSort(query.Sort),
+		Limit:      query.Limit,
+		Offset:     query.Offset,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	productIDs := make([]string, len(result.Products))
+	for i, product := range result.Products {
+		productIDs[i] = product.ID
+	}
+	variants, err := uc.inventoryRepo.GetVariants(ctx, productIDs, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variants: %w", err)
+	}
+
+	response := &dto.ProductSearchResponse{
+		Products: make([]dto.ProductResponse, len(result.Products)),
+		Total:    result.Total,
+		Limit:    query.Limit,
+		Offset:   query.Offset,
+	}
+	for i, product := range result.Products {
+		response.Products[i] = *toProductResponse(product)
+		response.Products[i].Variants = toVariantResponses(variants[product.ID])
+	}
+
+	response.Facets, err = uc.toFacetResponse(ctx, result.Facets, attributes)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// toFacetResponse names the facets, category and attribute facets only carry codes and IDs
+func (uc *SearchProductsUseCase) toFacetResponse(ctx context.Context, facets entity.ProductFacets, attributes map[string]*entity.Attribute) (dto.ProductFacetResponse, error) {
+	response := dto.ProductFacetResponse{
+		Categories:  make([]dto.CategoryFacetResponse, 0, len(facets.Categories)),
+		PriceRanges: make([]dto.PriceRangeFacetResponse, len(facets.PriceRanges)),
+		Attributes:  []dto.AttributeFacetResponse{},
+	}
+
+	if len(facets.Categories) > 0 {
+		categories, err := uc.categoryRepo.List(ctx)
+		if err != nil {
+			return response, err
+		}
+		byID := make(map[string]*entity.Category, len(categories))
+		for _, category := range categories {
+			byID[category.ID] = category
+		}
+		for _, facet := range facets.Categories {
+			category, ok := byID[facet.CategoryID]
+			if !ok {
+				continue
+			}
+			response.Categories = append(response.Categories, dto.CategoryFacetResponse{
+				ID:    category.ID,
+				Name:  category.Name,
+				Slug:  category.Slug,
+				Count: facet.Count,
+			})
+		}
+	}
+
+	for i, facet := range facets.PriceRanges {
+		response.PriceRanges[i] = dto.PriceRangeFacetResponse{Min: facet.Min, Max: facet.Max, Count: facet.Count}
+	}
+
+	// Facets come ordered by attribute code, group the values of each attribute
+	for _, facet := range facets.Attributes {
+		attribute, ok := attributes[facet.Attribute.Code]
+		if !ok {
+			continue
+		}
+		last := len(response.Attributes) - 1
+		if last < 0 || response.Attributes[last].Code != attribute.Code {
+			response.Attributes = append(response.Attributes, dto.AttributeFacetResponse{
+				Code:   attribute.Code,
+				Name:   attribute.Name,
+				Type:   string(attribute.Type),
+				Values: []dto.AttributeValueCount{},
+			})
+			last++
+		}
+		response.Attributes[last].Values = append(response.Attributes[last].Values, dto.AttributeValueCount{
+			Value: attribute.TypedValue(facet.Value),
+			Count: facet.Count,
+		})
+	}
+	return response, nil
+}
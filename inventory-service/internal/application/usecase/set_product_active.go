@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/retry"
+)
+
+// SetProductActiveUseCase activates or deactivates a product
+type SetProductActiveUseCase struct {
+	inventoryRepo               repository.InventoryRepository
+	publishProductEventsUseCase *PublishProductEventsUseCase
+}
+
+func NewSetProductActiveUseCase(
+	inventoryRepo repository.InventoryRepository,
+	publishProductEventsUseCase *PublishProductEventsUseCase) *SetProductActiveUseCase {
+	return &SetProductActiveUseCase{
+		inventoryRepo:               inventoryRepo,
+		publishProductEventsUseCase: publishProductEventsUseCase,
+	}
+}
+
+func (uc *SetProductActiveUseCase) Execute(ctx context.Context, productID string, active bool) (*dto.ProductResponse, error) {
+	var product, previous *entity.Product
+
+	err := retry.OnConflict(ctx, entity.ErrConcurrentModification, func() error {
+		var err error
+		product, err = uc.inventoryRepo.GetByID(ctx, productID)
+		if err != nil {
+			return err
+		}
+		previous = nil
+		if product.IsActive == active {
+			return nil
+		}
+		unchanged := *product
+		previous = &unchanged
+
+		if active {
+			product.Activate()
+		} else {
+			product.Deactivate()
+		}
+
+		if err := uc.inventoryRepo.Update(ctx, product); err != nil {
+			return fmt.Errorf("failed to update product: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if previous != nil {
+		uc.publishProductEventsUseCase.Changed("", previous, product)
+	}
+	return toProductResponse(product), nil
+}
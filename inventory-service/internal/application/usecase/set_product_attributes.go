@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+type SetProductAttributesUseCase struct {
+	inventoryRepo repository.InventoryRepository
+	attributeRepo repository.AttributeRepository
+}
+
+func NewSetProductAttributesUseCase(
+	inventoryRepo repository.InventoryRepository,
+	attributeRepo repository.AttributeRepository) *SetProductAttributesUseCase {
+	return &SetProductAttributesUseCase{
+		inventoryRepo: inventoryRepo,
+		attributeRepo: attributeRepo,
+	}
+}
+
+// Execute replaces the product's attribute values. Every value is checked
+// against its attribute's type before any is stored.
+func (uc *SetProductAttributesUseCase) Execute(ctx context.Context, productID string, req dto.SetProductAttributesRequest) (*dto.ProductAttributesResponse, error) {
+	if _, err := uc.inventoryRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	definitions, err := uc.attributeRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	byCode := make(map[string]*entity.Attribute, len(definitions))
+	for _, attribute := range definitions {
+		byCode[attribute.Code] = attribute
+	}
+
+	attributes := make([]entity.ProductAttribute, 0, len(req.Attributes))
+	for code, value := range req.Attributes {
+		attribute, ok := byCode[code]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", entity.ErrAttributeNotFound, code)
+		}
+		canonical, err := attribute.ParseValue(value)
+		if err != nil {
+			return nil, err
+		}
+		attributes = append(attributes, entity.ProductAttribute{Attribute: *attribute, Value: canonical})
+	}
+
+	if err := uc.attributeRepo.SetProductAttributes(ctx, productID, attributes); err != nil {
+		return nil, err
+	}
+
+	return &dto.ProductAttributesResponse{
+		ProductID:  productID,
+		Attributes: toAttributeValues(attributes),
+	}, nil
+}
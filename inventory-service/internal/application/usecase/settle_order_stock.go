@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+// SettleOrderStockUseCase settles the stock an order still holds once the
+// order reaches a final status: released if it failed or was cancelled,
+// sold if it completed
+type SettleOrderStockUseCase struct {
+	inventoryRepo               repository.InventoryRepository
+	checkStockLevelsUseCase     *CheckStockLevelsUseCase
+	publishProductEventsUseCase *PublishProductEventsUseCase
+}
+
+func NewSettleOrderStockUseCase(
+	inventoryRepo repository.InventoryRepository,
+	checkStockLevelsUseCase *CheckStockLevelsUseCase,
+	publishProductEventsUseCase *PublishProductEventsUseCase) *SettleOrderStockUseCase {
+	return &SettleOrderStockUseCase{
+		inventoryRepo:               inventoryRepo,
+		checkStockLevelsUseCase:     checkStockLevelsUseCase,
+		publishProductEventsUseCase: publishProductEventsUseCase,
+	}
+}
+
+// Release returns the order's reserved stock to available stock
+func (uc *SettleOrderStockUseCase) Release(ctx context.Context, orderID, correlationID, reason string) error {
+	movements, err := uc.inventoryRepo.ReleaseOrderReservations(ctx, orderID, correlationID, reason)
+	if err != nil {
+		return fmt.Errorf("failed to release stock: %w", err)
+	}
+
+	log.Printf("Released reserved stock of %d product(s) for order %s", len(movements), orderID)
+	productIDs := movementProductIDs(movements)
+	uc.publishProductEventsUseCase.StockChanged(ctx, correlationID, productIDs...)
+	uc.checkStockLevelsUseCase.Execute(ctx, correlationID, productIDs...)
+	return nil
+}
+
+// ConfirmSale takes the order's reserved stock out of the warehouse
+func (uc *SettleOrderStockUseCase) ConfirmSale(ctx context.Context, orderID, correlationID string) error {
+	movements, err := uc.inventoryRepo.ConfirmOrderSale(ctx, orderID, correlationID)
+	if err != nil {
+		return fmt.Errorf("failed to confirm sale: %w", err)
+	}
+
+	log.Printf("Confirmed sale of %d product(s) for order %s", len(movements), orderID)
+	productIDs := movementProductIDs(movements)
+	uc.publishProductEventsUseCase.StockChanged(ctx, correlationID, productIDs...)
+	uc.checkStockLevelsUseCase.Execute(ctx, correlationID, productIDs...)
+	return nil
+}
+
+func movementProductIDs(movements []entity.StockMovement) []string {
+	productIDs := make([]string, len(movements))
+	for i, movement := range movements {
+		productIDs[i] = movement.ProductID
+	}
+	return productIDs
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+// UpdateCategoryUseCase renames or moves a category. Products and
+// subcategories move along with it.
+type UpdateCategoryUseCase struct {
+	categoryRepo repository.CategoryRepository
+}
+
+func NewUpdateCategoryUseCase(categoryRepo repository.CategoryRepository) *UpdateCategoryUseCase {
+	return &UpdateCategoryUseCase{
+		categoryRepo: categoryRepo,
+	}
+}
+
+func (uc *UpdateCategoryUseCase) Execute(ctx context.Context, categoryID string, req dto.UpdateCategoryRequest) (*dto.CategoryResponse, error) {
+	category, err := uc.categoryRepo.GetByID(ctx, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		category.Name = *req.Name
+	}
+	if req.Slug != nil {
+		category.Slug = *req.Slug
+	}
+	if req.ParentID != nil {
+		category.ParentID = *req.ParentID
+	}
+	category.UpdatedAt = time.Now().UTC()
+
+	if err := uc.categoryRepo.Update(ctx, category); err != nil {
+		return nil, err
+	}
+
+	return toCategoryResponse(category), nil
+}
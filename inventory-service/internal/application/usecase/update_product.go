@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/retry"
+)
+
+type UpdateProductUseCase struct {
+	inventoryRepo               repository.InventoryRepository
+	checkStockLevelsUseCase     *CheckStockLevelsUseCase
+	publishProductEventsUseCase *PublishProductEventsUseCase
+}
+
+func NewUpdateProductUseCase(
+	inventoryRepo repository.InventoryRepository,
+	checkStockLevelsUseCase *CheckStockLevelsUseCase,
+	publishProductEventsUseCase *PublishProductEventsUseCase) *UpdateProductUseCase {
+	return &UpdateProductUseCase{
+		inventoryRepo:               inventoryRepo,
+		checkStockLevelsUseCase:     checkStockLevelsUseCase,
+		publishProductEventsUseCase: publishProductEventsUseCase,
+	}
+}
+
+// Execute applies the given fields to the product. Concurrent stock changes
+// are retried transparently, a stale client version is not.
+func (uc *UpdateProductUseCase) Execute(ctx context.Context, productID string, req dto.UpdateProductRequest) (*dto.ProductResponse, error) {
+	var product, previous *entity.Product
+
+	err := retry.OnConflict(ctx, entity.ErrConcurrentModification, func() error {
+		var err error
+		product, err = uc.inventoryRepo.GetByID(ctx, productID)
+		if err != nil {
+			return err
+		}
+		if req.Version != nil && *req.Version != product.Version {
+			return entity.ErrStaleVersion
+		}
+		unchanged := *product
+		previous = &unchanged
+
+		if req.Name != nil {
+			product.Name = *req.Name
+		}
+		if req.Description != nil {
+			product.Description = *req.Description
+		}
+		if req.Price != nil {
+			product.Price = *req.Price
+		}
+		if req.ReorderThreshold != nil {
+			product.ReorderThreshold = *req.ReorderThreshold
+		}
+		if req.SKU != nil {
+			product.SKU = *req.SKU
+		}
+		if req.CategoryID != nil {
+			if *req.CategoryID != "" && uuid.Validate(*req.CategoryID) != nil {
+				return fmt.Errorf("%w: %s", entity.ErrCategoryNotFound, *req.CategoryID)
+			}
+			product.CategoryID = *req.CategoryID
+		}
+		if req.Options != nil {
+			if !product.IsVariant() {
+				return entity.ErrOptionsRequireParent
+			}
+			product.Options = req.Options
+		}
+		product.UpdatedAt = time.Now().UTC()
+
+		if err := uc.inventoryRepo.Update(ctx, product); err != nil {
+			return fmt.Errorf("failed to update product: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.publishProductEventsUseCase.Changed("", previous, product)
+
+	// A new threshold can move the product in or out of low stock without a stock change
+	if req.ReorderThreshold != nil {
+		uc.checkStockLevelsUseCase.Execute(ctx, "", productID)
+	}
+
+	return toProductResponse(product), nil
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+// UpdateWarehouseUseCase changes a warehouse's details. Deactivated warehouses
+// keep their stock and reservations but are no longer allocated from.
+type UpdateWarehouseUseCase struct {
+	warehouseRepo repository.WarehouseRepository
+}
+
+func NewUpdateWarehouseUseCase(warehouseRepo repository.WarehouseRepository) *UpdateWarehouseUseCase {
+	return &UpdateWarehouseUseCase{
+		warehouseRepo: warehouseRepo,
+	}
+}
+
+func (uc *UpdateWarehouseUseCase) Execute(ctx context.Context, warehouseID string, req dto.UpdateWarehouseRequest) (*dto.WarehouseResponse, error) {
+	warehouse, err := uc.warehouseRepo.GetByID(ctx, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Latitude != nil || req.Longitude != nil {
+		location, err := entity.NewLocation(req.Latitude, req.Longitude)
+		if err != nil {
+			return nil, err
+		}
+		warehouse.Location = location
+	}
+	if req.Name != nil {
+		warehouse.Name = *req.Name
+	}
+	if req.Priority != nil {
+		warehouse.Priority = *req.Priority
+	}
+	if req.IsActive != nil {
+		warehouse.IsActive = *req.IsActive
+	}
+	warehouse.UpdatedAt = time.Now().UTC()
+
+	if err := uc.warehouseRepo.Update(ctx, warehouse); err != nil {
+		return nil, err
+	}
+
+	return toWarehouseResponse(warehouse), nil
+}
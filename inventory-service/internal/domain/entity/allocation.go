@@ -0,0 +1,183 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+var ErrUnknownAllocationStrategy = errors.New("unknown allocation strategy")
+
+// AllocationStrategy decides which warehouses an order's stock is reserved in
+type AllocationStrategy string
+
+const (
+	// AllocationSingleLocationFirst ships the whole order from one warehouse if
+	// any holds all of it, otherwise each line from one warehouse, and only
+	// splits lines across warehouses as a last resort
+	AllocationSingleLocationFirst AllocationStrategy = "single_location_first"
+	// AllocationNearest ships each line from the warehouse closest to the
+	// destination that holds all of it, splitting over the nearest ones otherwise
+	AllocationNearest AllocationStrategy = "nearest"
+	// AllocationSplit fills each line from the warehouses in priority order,
+	// splitting it whenever the preferred warehouse runs short
+	AllocationSplit AllocationStrategy = "split"
+)
+
+// ParseAllocationStrategy validates a strategy name, empty means single_location_first
+func ParseAllocationStrategy(strategy string) (AllocationStrategy, error) {
+	switch AllocationStrategy(strategy) {
+	case "":
+		return AllocationSingleLocationFirst, nil
+	case AllocationSingleLocationFirst, AllocationNearest, AllocationSplit:
+		return AllocationStrategy(strategy), nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownAllocationStrategy, strategy)
+	}
+}
+
+// AllocationPolicy is how an order's stock is spread over the warehouses
+type AllocationPolicy struct {
+	Strategy AllocationStrategy
+	// Destination is where the order ships to, nil when unknown
+	Destination *Location
+}
+
+// WarehouseAllocation is the part of an order line reserved in one warehouse
+type WarehouseAllocation struct {
+	WarehouseID   string `json:"warehouse_id"`
+	WarehouseCode string `json:"warehouse_code"`
+	Quantity      int32  `json:"quantity"`
+}
+
+type stockKey struct {
+	warehouseID string
+	productID   string
+}
+
+// AllocateStock decides how much of each item is reserved in which warehouse.
+// Only stock in the given warehouses is used, so callers leave out inactive
+// warehouses and products. The result holds the allocations of every item in
+// item order; they add up to less than the requested quantity when stock is short.
+func AllocateStock(policy AllocationPolicy, items []StockReservation, warehouses []Warehouse, stock []WarehouseStock) [][]WarehouseAllocation {
+	ranked := rankWarehouses(policy, warehouses)
+	available := make(map[stockKey]int32, len(stock))
+	for _, level := range stock {
+		available[stockKey{level.WarehouseID, level.ProductID}] += max(level.AvailableStock(), 0)
+	}
+
+	allocations := make([][]WarehouseAllocation, len(items))
+	if policy.Strategy == AllocationSingleLocationFirst {
+		if warehouse, ok := takeWholeOrder(items, ranked, available); ok {
+			for i, item := range items {
+				allocations[i] = []WarehouseAllocation{{
+					WarehouseID:   warehouse.ID,
+					WarehouseCode: warehouse.Code,
+					Quantity:      item.Quantity,
+				}}
+			}
+			return allocations
+		}
+	}
+
+	for i, item := range items {
+		allocations[i] = takeLine(policy.Strategy != AllocationSplit, item, ranked, available)
+	}
+	return allocations
+}
+
+// takeWholeOrder finds the first warehouse holding every item of the order
+func takeWholeOrder(items []StockReservation, ranked []Warehouse, available map[stockKey]int32) (Warehouse, bool) {
+	needed := make(map[string]int32, len(items))
+	for _, item := range items {
+		needed[item.ProductID] += item.Quantity
+	}
+
+	for _, warehouse := range ranked {
+		holdsAll := true
+		for productID, quantity := range needed {
+			if available[stockKey{warehouse.ID, productID}] < quantity {
+				holdsAll = false
+				break
+			}
+		}
+		if holdsAll {
+			for productID, quantity := range needed {
+				available[stockKey{warehouse.ID, productID}] -= quantity
+			}
+			return warehouse, true
+		}
+	}
+	return Warehouse{}, false
+}
+
+// takeLine allocates a single line, from one warehouse if keepWhole is set and
+// one can hold it, otherwise from the ranked warehouses in turn
+func takeLine(keepWhole bool, item StockReservation, ranked []Warehouse, available map[stockKey]int32) []WarehouseAllocation {
+	if keepWhole {
+		for _, warehouse := range ranked {
+			key := stockKey{warehouse.ID, item.ProductID}
+			if available[key] >= item.Quantity {
+				available[key] -= item.Quantity
+				return []WarehouseAllocation{{
+					WarehouseID:   warehouse.ID,
+					WarehouseCode: warehouse.Code,
+					Quantity:      item.Quantity,
+				}}
+			}
+		}
+	}
+
+	allocations := []WarehouseAllocation{}
+	remaining := item.Quantity
+	for _, warehouse := range ranked {
+		if remaining == 0 {
+			break
+		}
+		key := stockKey{warehouse.ID, item.ProductID}
+		taken := min(available[key], remaining)
+		if taken <= 0 {
+			continue
+		}
+		available[key] -= taken
+		remaining -= taken
+		allocations = append(allocations, WarehouseAllocation{
+			WarehouseID:   warehouse.ID,
+			WarehouseCode: warehouse.Code,
+			Quantity:      taken,
+		})
+	}
+	return allocations
+}
+
+// rankWarehouses orders warehouses by preference. The nearest strategy ranks
+// by distance to the destination first; the others by priority, using distance
+// to break ties. Warehouses without a location count as farthest.
+func rankWarehouses(policy AllocationPolicy, warehouses []Warehouse) []Warehouse {
+	distances := make(map[string]float64, len(warehouses))
+	for _, warehouse := range warehouses {
+		distances[warehouse.ID] = math.Inf(1)
+		if policy.Destination != nil && warehouse.Location != nil {
+			distances[warehouse.ID] = policy.Destination.DistanceKm(*warehouse.Location)
+		}
+	}
+
+	ranked := make([]Warehouse, len(warehouses))
+	copy(ranked, warehouses)
+	sort.SliceStable(ranked, func(a, b int) bool {
+		wa, wb := ranked[a], ranked[b]
+		da, db := distances[wa.ID], distances[wb.ID]
+		if policy.Strategy == AllocationNearest && da != db {
+			return da < db
+		}
+		if wa.Priority != wb.Priority {
+			return wa.Priority < wb.Priority
+		}
+		if da != db {
+			return da < db
+		}
+		return wa.Code < wb.Code
+	})
+	return ranked
+}
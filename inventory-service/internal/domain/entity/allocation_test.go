@@ -0,0 +1,85 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAllocateStock(t *testing.T) {
+	// Berlin is preferred by priority, Paris is closer to Brussels
+	warehouses := []Warehouse{
+		{ID: "w-berlin", Code: "berlin", Priority: 0, Location: &Location{Latitude: 52.52, Longitude: 13.40}},
+		{ID: "w-paris", Code: "paris", Priority: 1, Location: &Location{Latitude: 48.86, Longitude: 2.35}},
+	}
+	brussels := &Location{Latitude: 50.85, Longitude: 4.35}
+	stock := []WarehouseStock{
+		{WarehouseID: "w-berlin", ProductID: "a", StockQuantity: 5},
+		{WarehouseID: "w-berlin", ProductID: "b", StockQuantity: 1},
+		{WarehouseID: "w-paris", ProductID: "a", StockQuantity: 5, ReservedStock: 1},
+		{WarehouseID: "w-paris", ProductID: "b", StockQuantity: 5},
+	}
+
+	tests := []struct {
+		name   string
+		policy AllocationPolicy
+		items  []StockReservation
+		want   [][]WarehouseAllocation
+	}{
+		{
+			name:   "single location ships the whole order from one warehouse",
+			policy: AllocationPolicy{Strategy: AllocationSingleLocationFirst},
+			items:  []StockReservation{{ProductID: "a", Quantity: 2}, {ProductID: "b", Quantity: 2}},
+			want: [][]WarehouseAllocation{
+				{{WarehouseID: "w-paris", WarehouseCode: "paris", Quantity: 2}},
+				{{WarehouseID: "w-paris", WarehouseCode: "paris", Quantity: 2}},
+			},
+		},
+		{
+			name:   "single location keeps lines whole when no warehouse holds the order",
+			policy: AllocationPolicy{Strategy: AllocationSingleLocationFirst},
+			items:  []StockReservation{{ProductID: "a", Quantity: 5}, {ProductID: "b", Quantity: 2}},
+			want: [][]WarehouseAllocation{
+				{{WarehouseID: "w-berlin", WarehouseCode: "berlin", Quantity: 5}},
+				{{WarehouseID: "w-paris", WarehouseCode: "paris", Quantity: 2}},
+			},
+		},
+		{
+			name:   "nearest prefers the closest warehouse",
+			policy: AllocationPolicy{Strategy: AllocationNearest, Destination: brussels},
+			items:  []StockReservation{{ProductID: "a", Quantity: 3}},
+			want: [][]WarehouseAllocation{
+				{{WarehouseID: "w-paris", WarehouseCode: "paris", Quantity: 3}},
+			},
+		},
+		{
+			name:   "split fills from the preferred warehouse first",
+			policy: AllocationPolicy{Strategy: AllocationSplit},
+			items:  []StockReservation{{ProductID: "b", Quantity: 3}},
+			want: [][]WarehouseAllocation{{
+				{WarehouseID: "w-berlin", WarehouseCode: "berlin", Quantity: 1},
+				{WarehouseID: "w-paris", WarehouseCode: "paris", Quantity: 2},
+			}},
+		},
+		{
+			name:   "short stock allocates what there is",
+			policy: AllocationPolicy{Strategy: AllocationSingleLocationFirst},
+			items:  []StockReservation{{ProductID: "a", Quantity: 6}, {ProductID: "a", Quantity: 5}},
+			want: [][]WarehouseAllocation{
+				{
+					{WarehouseID: "w-berlin", WarehouseCode: "berlin", Quantity: 5},
+					{WarehouseID: "w-paris", WarehouseCode: "paris", Quantity: 1},
+				},
+				{{WarehouseID: "w-paris", WarehouseCode: "paris", Quantity: 3}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AllocateStock(tt.policy, tt.items, warehouses, stock)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("AllocateStock() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrAttributeNotFound     = errors.New("attribute not found")
+	ErrAttributeCodeTaken    = errors.New("attribute code is already in use")
+	ErrInvalidAttributeValue = errors.New("invalid attribute value")
+)
+
+// AttributeType is the type of the values an attribute takes
+type AttributeType string
+
+const (
+	AttributeTypeText    AttributeType = "text"
+	AttributeTypeNumber  AttributeType = "number"
+	AttributeTypeBoolean AttributeType = "boolean"
+)
+
+// Attribute is a typed property products can have, e.g. "material" or "weight_kg"
+type Attribute struct {
+	ID        string        `json:"id"`
+	Code      string        `json:"code"`
+	Name      string        `json:"name"`
+	Type      AttributeType `json:"type"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// ParseValue checks a decoded JSON value against the attribute's type and
+// returns it in canonical text form, so that equal values compare equal
+func (a *Attribute) ParseValue(value any) (string, error) {
+	switch a.Type {
+	case AttributeTypeText:
+		if text, ok := value.(string); ok && text != "" {
+			return text, nil
+		}
+	case AttributeTypeNumber:
+		if number, ok := value.(float64); ok {
+			return strconv.FormatFloat(number, 'f', -1, 64), nil
+		}
+	case AttributeTypeBoolean:
+		if boolean, ok := value.(bool); ok {
+			return strconv.FormatBool(boolean), nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s must be a %s", ErrInvalidAttributeValue, a.Code, a.Type)
+}
+
+// CanonicalValue returns a value given as text, e.g. in a query string, in
+// canonical text form
+func (a *Attribute) CanonicalValue(value string) (string, error) {
+	switch a.Type {
+	case AttributeTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s must be a number", ErrInvalidAttributeValue, a.Code)
+		}
+		return strconv.FormatFloat(number, 'f', -1, 64), nil
+	case AttributeTypeBoolean:
+		boolean, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("%w: %s must be a boolean", ErrInvalidAttributeValue, a.Code)
+		}
+		return strconv.FormatBool(boolean), nil
+	default:
+		return a.ParseValue(value)
+	}
+}
+
+// TypedValue converts a canonical text value back to the attribute's type
+func (a *Attribute) TypedValue(value string) any {
+	switch a.Type {
+	case AttributeTypeNumber:
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	case AttributeTypeBoolean:
+		if boolean, err := strconv.ParseBool(value); err == nil {
+			return boolean
+		}
+	}
+	return value
+}
+
+// ProductAttribute is a product's value for one attribute
+type ProductAttribute struct {
+	Attribute Attribute
+	// Value is in the canonical text form of the attribute's type
+	Value string
+}
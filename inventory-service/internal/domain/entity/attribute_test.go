@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAttributeValues(t *testing.T) {
+	weight := Attribute{Code: "weight_kg", Type: AttributeTypeNumber}
+	organic := Attribute{Code: "organic", Type: AttributeTypeBoolean}
+	material := Attribute{Code: "material", Type: AttributeTypeText}
+
+	// Decoded JSON and query string values of the same number must compare equal
+	stored, err := weight.ParseValue(1.50)
+	if err != nil || stored != "1.5" {
+		t.Fatalf("ParseValue(1.50) = %q, %v", stored, err)
+	}
+	filter, err := weight.CanonicalValue("1.500")
+	if err != nil || filter != stored {
+		t.Fatalf("CanonicalValue(1.500) = %q, %v, want %q", filter, err, stored)
+	}
+	if got := weight.TypedValue(stored); got != 1.5 {
+		t.Errorf("TypedValue(%q) = %v, want 1.5", stored, got)
+	}
+
+	if filter, err := organic.CanonicalValue("1"); err != nil || filter != "true" {
+		t.Errorf("CanonicalValue(1) = %q, %v, want true", filter, err)
+	}
+
+	for _, test := range []struct {
+		attribute Attribute
+		value     any
+	}{
+		{weight, "heavy"},
+		{organic, "yes"},
+		{material, 3.0},
+		{material, ""},
+	} {
+		if _, err := test.attribute.ParseValue(test.value); !errors.Is(err, ErrInvalidAttributeValue) {
+			t.Errorf("%s: ParseValue(%v) error = %v, want ErrInvalidAttributeValue", test.attribute.Code, test.value, err)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+type BackorderStatus string
+
+const (
+	BackorderStatusPending   BackorderStatus = "pending"
+	BackorderStatusFulfilled BackorderStatus = "fulfilled"
+	BackorderStatusCancelled BackorderStatus = "cancelled"
+)
+
+// Backorder is the part of an order line that could not be reserved yet
+// and waits for stock to arrive
+type Backorder struct {
+	ID            string          `json:"id"`
+	OrderID       string          `json:"order_id"`
+	CorrelationID string          `json:"correlation_id"`
+	ProductID     string          `json:"product_id"`
+	Quantity      int32           `json:"quantity"`
+	Status        BackorderStatus `json:"status"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// BackorderAllocation is arriving stock reserved for a backordered order line
+type BackorderAllocation struct {
+	BackorderID       string `json:"backorder_id"`
+	OrderID           string `json:"order_id"`
+	CorrelationID     string `json:"correlation_id"`
+	ProductID         string `json:"product_id"`
+	WarehouseID       string `json:"warehouse_id"`
+	Quantity          int32  `json:"quantity"`
+	RemainingQuantity int32  `json:"remaining_quantity"`
+}
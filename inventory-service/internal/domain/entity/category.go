@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrCategoryNotFound  = errors.New("category not found")
+	ErrCategorySlugTaken = errors.New("category slug is already in use")
+	ErrCategoryCycle     = errors.New("a category cannot be nested under itself or its subcategories")
+)
+
+// Category groups products for browsing and search. Categories form a tree,
+// a product listed under a category is also found under its ancestors.
+type Category struct {
+	ID string `json:"id"`
+	// ParentID is empty for a top-level category
+	ParentID  string    `json:"parent_id,omitempty"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
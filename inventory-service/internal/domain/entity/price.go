@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrPriceNotFound            = errors.New("no price recorded for the product at that time")
+	ErrScheduledPriceNotFound   = errors.New("scheduled price not found")
+	ErrScheduledPriceOverlap    = errors.New("scheduled price overlaps another scheduled price of the product")
+	ErrScheduledPriceNotPending = errors.New("scheduled price has already started")
+	ErrInvalidPriceSchedule     = errors.New("a scheduled price must end after it starts and in the future")
+)
+
+// PriceSource tells what changed a product's price
+type PriceSource string
+
+const (
+	PriceSourceCreated   PriceSource = "created"
+	PriceSourceUpdated   PriceSource = "updated"
+	PriceSourceImported  PriceSource = "imported"
+	PriceSourceScheduled PriceSource = "scheduled"
+)
+
+// PriceHistoryEntry is a price the product had from EffectiveFrom until
+// EffectiveTo, which is nil for the current price
+type PriceHistoryEntry struct {
+	ID            string
+	ProductID     string
+	Price         float64
+	EffectiveFrom time.Time
+	EffectiveTo   *time.Time
+	Source        PriceSource
+	// ScheduleID is the scheduled price that set the price, if any
+	ScheduleID string
+}
+
+// ScheduledPriceStatus is where a scheduled price is in its lifecycle
+type ScheduledPriceStatus string
+
+const (
+	// ScheduledPriceScheduled has not started yet
+	ScheduledPriceScheduled ScheduledPriceStatus = "scheduled"
+	// ScheduledPriceActive has started and is waiting for its end
+	ScheduledPriceActive ScheduledPriceStatus = "active"
+	// ScheduledPriceCompleted has started and, if it had an end, ended
+	ScheduledPriceCompleted ScheduledPriceStatus = "completed"
+	// ScheduledPriceCancelled was cancelled before it started
+	ScheduledPriceCancelled ScheduledPriceStatus = "cancelled"
+)
+
+// ScheduledPrice is a future price change. Without EndsAt the new price
+// stays, with EndsAt the price it replaced is restored at the end, e.g. a sale.
+type ScheduledPrice struct {
+	ID        string
+	ProductID string
+	Price     float64
+	StartsAt  time.Time
+	EndsAt    *time.Time
+	// PreviousPrice is the price replaced when the change started
+	PreviousPrice *float64
+	Status        ScheduledPriceStatus
+	Reason        string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Validate checks a new scheduled price against the current time
+func (s *ScheduledPrice) Validate(now time.Time) error {
+	if s.EndsAt != nil && (!s.EndsAt.After(s.StartsAt) || !s.EndsAt.After(now)) {
+		return ErrInvalidPriceSchedule
+	}
+	return nil
+}
+
+// IsPending reports whether the change still has to start or end
+func (s *ScheduledPrice) IsPending() bool {
+	return s.Status == ScheduledPriceScheduled || s.Status == ScheduledPriceActive
+}
+
+// Overlaps reports whether the two changes would fight over the product's
+// price. A change without an end only takes up its start, so it may follow a
+// sale or fall between two sales but not start during one.
+func (s *ScheduledPrice) Overlaps(other *ScheduledPrice) bool {
+	switch {
+	case s.EndsAt == nil && other.EndsAt == nil:
+		return s.StartsAt.Equal(other.StartsAt)
+	case s.EndsAt == nil:
+		return other.covers(s.StartsAt)
+	case other.EndsAt == nil:
+		return s.covers(other.StartsAt)
+	default:
+		return s.StartsAt.Before(*other.EndsAt) && other.StartsAt.Before(*s.EndsAt)
+	}
+}
+
+// covers reports whether t falls within [StartsAt, EndsAt) of a change with an end
+func (s *ScheduledPrice) covers(t time.Time) bool {
+	return !t.Before(s.StartsAt) && t.Before(*s.EndsAt)
+}
+
+// ScheduledPriceTransition is a scheduled price started or ended by the scheduler
+type ScheduledPriceTransition struct {
+	Schedule ScheduledPrice
+	// Previous and Product are the product before and after the price change.
+	// Both are nil when the change ended but the price had been changed since
+	// it started, the newer price is kept then.
+	Previous *Product
+	Product  *Product
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScheduledPriceOverlaps(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 11, d, 0, 0, 0, 0, time.UTC) }
+	sale := func(from, to int) *ScheduledPrice {
+		endsAt := day(to)
+		return &ScheduledPrice{StartsAt: day(from), EndsAt: &endsAt}
+	}
+	change := func(at int) *ScheduledPrice { return &ScheduledPrice{StartsAt: day(at)} }
+
+	tests := []struct {
+		name  string
+		a, b  *ScheduledPrice
+		wants bool
+	}{
+		{"overlapping sales", sale(1, 5), sale(4, 8), true},
+		{"back to back sales", sale(1, 5), sale(5, 8), false},
+		{"change during a sale", sale(1, 5), change(3), true},
+		{"change at the start of a sale", sale(1, 5), change(1), true},
+		{"change when a sale ends", sale(1, 5), change(5), false},
+		{"changes at the same time", change(3), change(3), true},
+		{"changes at different times", change(3), change(4), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.a.Overlaps(test.b); got != test.wants {
+				t.Errorf("a.Overlaps(b) = %t, want %t", got, test.wants)
+			}
+			if got := test.b.Overlaps(test.a); got != test.wants {
+				t.Errorf("b.Overlaps(a) = %t, want %t", got, test.wants)
+			}
+		})
+	}
+}
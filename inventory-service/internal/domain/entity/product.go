@@ -2,15 +2,27 @@ package entity
 
 import (
 	"errors"
+	"sort"
+	"strings"
 	"time"
 )
 
 var (
+	ErrProductNotFound               = errors.New("product not found")
 	ErrInsufficientStock             = errors.New("insufficient stock available")
 	ErrProductNotActive              = errors.New("product is not active")
 	ErrInvalidQuantity               = errors.New("quantity must be positive")
 	ErrCannotReleaseMoreThanReserved = errors.New("cannot release more than reserved")
 	ErrCannotConfirmMoreThanReserved = errors.New("cannot confirm more than reserved")
+	ErrConcurrentModification        = errors.New("product was modified concurrently")
+	ErrStaleVersion                  = errors.New("product version does not match the current version")
+	ErrSKUTaken                      = errors.New("sku is already in use")
+	ErrVariantOfVariant              = errors.New("a variant cannot have variants of its own")
+	ErrProductHasVariants            = errors.New("product has variants, stock is held by its variants")
+	ErrParentHoldsStock              = errors.New("product holds stock, it cannot get variants")
+	ErrDuplicateVariantOptions       = errors.New("a variant with the same options already exists")
+	ErrOptionsRequireParent          = errors.New("only variants have options")
+	ErrMissingVariantOptions         = errors.New("a variant needs at least one option")
 )
 
 type Product struct {
@@ -23,12 +35,71 @@ type Product struct {
 	IsActive      bool      `json:"is_active"`
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
+	Version       int32     `json:"version"`
+	// ReorderThreshold is the available stock at or below which the product
+	// is reported as low on stock, zero only reports it once it runs out
+	ReorderThreshold int32 `json:"reorder_threshold"`
+	// ParentID is set for a variant of another product. Stock is held by
+	// standalone products and variants, never by a product with variants.
+	ParentID string `json:"parent_id,omitempty"`
+	// SKU is the optional stock keeping unit code, unique across products
+	SKU string `json:"sku,omitempty"`
+	// Options are the attributes that tell a product's variants apart, e.g. size and colour
+	Options map[string]string `json:"options,omitempty"`
+	// CategoryID is the category the product is listed under, empty when uncategorised
+	CategoryID string `json:"category_id,omitempty"`
+}
+
+// Activate makes the product available for new reservations
+func (p *Product) Activate() {
+	p.IsActive = true
+	p.UpdatedAt = time.Now().UTC()
+}
+
+// Deactivate stops new reservations; existing reservations are kept
+func (p *Product) Deactivate() {
+	p.IsActive = false
+	p.UpdatedAt = time.Now().UTC()
 }
 
 func (p *Product) AvailableStock() int32 {
 	return p.StockQuantity - p.ReservedStock
 }
 
+// IsVariant reports whether the product is a variant of another product
+func (p *Product) IsVariant() bool {
+	return p.ParentID != ""
+}
+
+// VariantName names a variant after its parent and its option values,
+// ordered by option name, e.g. "T-Shirt - L / red"
+func VariantName(parentName string, options map[string]string) string {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := make([]string, len(names))
+	for i, name := range names {
+		values[i] = options[name]
+	}
+	return parentName + " - " + strings.Join(values, " / ")
+}
+
+// StockStatus classifies the available stock against the reorder threshold
+func (p *Product) StockStatus() StockStatus {
+	available := p.AvailableStock()
+	switch {
+	case available <= 0:
+		return StockStatusOutOfStock
+	case available <= p.ReorderThreshold:
+		return StockStatusLowStock
+	default:
+		return StockStatusInStock
+	}
+}
+
 func (p *Product) CanReserve(quantity int32) error {
 	if !p.IsActive {
 		return ErrProductNotActive
@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidImportRow = errors.New("invalid import row")
+
+// ProductImportRow is a product and, optionally, its stock level in one
+// warehouse. A row with an ID updates that product or creates it under that
+// ID, a row without one always creates a new product.
+type ProductImportRow struct {
+	// Line is the row's position in the imported file, used in error reports
+	Line        int
+	ID          string
+	Name        string
+	Description string
+	Price       float64
+	// StockQuantity is the stock to hold in the warehouse, nil leaves the stock unchanged
+	StockQuantity *int32
+	// ReorderThreshold and IsActive keep the product's current values when nil
+	ReorderThreshold *int32
+	IsActive         *bool
+	// WarehouseCode selects the warehouse of StockQuantity, empty for the default warehouse
+	WarehouseCode string
+	// ParentID makes a new product a variant, it is ignored for existing products
+	ParentID string
+	// SKU and Options keep the product's current values when empty
+	SKU     string
+	Options map[string]string
+}
+
+// Validate checks the row before it reaches the database
+func (r *ProductImportRow) Validate() error {
+	switch {
+	case r.ID != "" && uuid.Validate(r.ID) != nil:
+		return fmt.Errorf("%w: id must be a UUID", ErrInvalidImportRow)
+	case r.ParentID != "" && uuid.Validate(r.ParentID) != nil:
+		return fmt.Errorf("%w: parent_id must be a UUID", ErrInvalidImportRow)
+	case len(r.SKU) > 64:
+		return fmt.Errorf("%w: sku must be at most 64 characters", ErrInvalidImportRow)
+	case r.ParentID != "" && len(r.Options) == 0:
+		return fmt.Errorf("%w: %v", ErrInvalidImportRow, ErrMissingVariantOptions)
+	case r.Name == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidImportRow)
+	case len(r.Name) > 255:
+		return fmt.Errorf("%w: name must be at most 255 characters", ErrInvalidImportRow)
+	case len(r.Description) > 5000:
+		return fmt.Errorf("%w: description must be at most 5000 characters", ErrInvalidImportRow)
+	case r.Price <= 0:
+		return fmt.Errorf("%w: price must be positive", ErrInvalidImportRow)
+	case r.StockQuantity != nil && *r.StockQuantity < 0:
+		return fmt.Errorf("%w: stock_quantity cannot be negative", ErrInvalidImportRow)
+	case r.ReorderThreshold != nil && *r.ReorderThreshold < 0:
+		return fmt.Errorf("%w: reorder_threshold cannot be negative", ErrInvalidImportRow)
+	}
+	return nil
+}
+
+// ProductImportAction is what an import did with a row
+type ProductImportAction string
+
+const (
+	ProductImportCreated   ProductImportAction = "created"
+	ProductImportUpdated   ProductImportAction = "updated"
+	ProductImportUnchanged ProductImportAction = "unchanged"
+	ProductImportFailed    ProductImportAction = "failed"
+)
+
+// ProductImportResult is the outcome of importing a single row
+type ProductImportResult struct {
+	Line      int
+	ProductID string
+	Action    ProductImportAction
+	// Err is set for failed rows, the rest of the batch is imported regardless
+	Err error
+	// Allocations is the imported stock handed to pending backorders
+	Allocations []BackorderAllocation
+	// Product is the product as created or updated by the row and Previous
+	// its state before an update, both are nil when the product was unchanged
+	Product  *Product
+	Previous *Product
+	// StockChanged is set when the row changed the product's stock level
+	StockChanged bool
+}
@@ -0,0 +1,77 @@
+package entity
+
+import "errors"
+
+var ErrInvalidPriceRange = errors.New("min_price cannot be greater than max_price")
+
+// ProductSort orders search results
+type ProductSort string
+
+const (
+	// ProductSortRelevance ranks name matches above description matches and
+	// falls back to newest first when there is no search text
+	ProductSortRelevance ProductSort = "relevance"
+	ProductSortPriceAsc  ProductSort = "price_asc"
+	ProductSortPriceDesc ProductSort = "price_desc"
+	ProductSortNewest    ProductSort = "newest"
+	ProductSortName      ProductSort = "name"
+)
+
+// PriceRangeBounds are the lower bounds of the price range facets, the last
+// range has no upper bound
+var PriceRangeBounds = []float64{0, 25, 50, 100, 250, 500}
+
+// ProductSearch selects active products that are not variants. Every filter
+// that is set must match.
+type ProductSearch struct {
+	// Text is matched against names and descriptions, web search syntax such
+	// as quoted phrases and -exclusions is supported
+	Text string
+	// CategoryID also matches products in the category's subcategories
+	CategoryID string
+	MinPrice   *float64
+	MaxPrice   *float64
+	// InStock only matches products with available stock, or with a variant that has some
+	InStock bool
+	// Attributes maps attribute codes to the canonical value products must have
+	Attributes map[string]string
+	Sort       ProductSort
+	Limit      int
+	Offset     int
+}
+
+// ProductSearchResult is a page of matching products along with the facet
+// counts over all of them
+type ProductSearchResult struct {
+	Products []*Product
+	Total    int64
+	Facets   ProductFacets
+}
+
+type ProductFacets struct {
+	Categories  []CategoryFacet
+	PriceRanges []PriceRangeFacet
+	Attributes  []AttributeFacet
+}
+
+// CategoryFacet counts the matching products listed directly under a category
+type CategoryFacet struct {
+	CategoryID string
+	Name       string
+	Count      int64
+}
+
+// PriceRangeFacet counts the matching products priced in [Min, Max), Max is
+// nil for the highest range
+type PriceRangeFacet struct {
+	Min   float64
+	Max   *float64
+	Count int64
+}
+
+// AttributeFacet counts the matching products having an attribute value
+type AttributeFacet struct {
+	Attribute Attribute
+	Value     string
+	Count     int64
+}
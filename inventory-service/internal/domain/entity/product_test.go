@@ -0,0 +1,54 @@
+package entity
+
+import "testing"
+
+func TestProductStockStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		stock     int32
+		reserved  int32
+		threshold int32
+		want      StockStatus
+	}{
+		{"above the threshold", 20, 0, 5, StockStatusInStock},
+		{"one above the threshold", 6, 0, 5, StockStatusInStock},
+		{"at the threshold", 5, 0, 5, StockStatusLowStock},
+		{"below the threshold", 3, 0, 5, StockStatusLowStock},
+		{"reservations take it to the threshold", 10, 5, 5, StockStatusLowStock},
+		{"one left", 1, 0, 5, StockStatusLowStock},
+		{"all reserved", 5, 5, 5, StockStatusOutOfStock},
+		{"no stock", 0, 0, 5, StockStatusOutOfStock},
+		{"one left without threshold", 1, 0, 0, StockStatusInStock},
+		{"no stock without threshold", 0, 0, 0, StockStatusOutOfStock},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			product := &Product{StockQuantity: test.stock, ReservedStock: test.reserved, ReorderThreshold: test.threshold}
+			if got := product.StockStatus(); got != test.want {
+				t.Errorf("StockStatus() with %d available and threshold %d = %s, want %s",
+					product.AvailableStock(), test.threshold, got, test.want)
+			}
+		})
+	}
+}
+
+func TestVariantName(t *testing.T) {
+	tests := []struct {
+		name    string
+		options map[string]string
+		want    string
+	}{
+		{"one option", map[string]string{"size": "L"}, "T-Shirt - L"},
+		{"ordered by option name", map[string]string{"size": "L", "colour": "red"}, "T-Shirt - red / L"},
+		{"three options", map[string]string{"size": "L", "colour": "red", "fit": "slim"}, "T-Shirt - red / slim / L"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := VariantName("T-Shirt", test.options); got != test.want {
+				t.Errorf("VariantName() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrNegativeReceipt  = errors.New("a receipt cannot remove stock")
+	ErrInvalidTimeRange = errors.New("from must be before to")
+)
+
+// StockMovementType classifies a change to a product's stock
+type StockMovementType string
+
+const (
+	// StockMovementReceipt is stock arriving at the warehouse
+	StockMovementReceipt StockMovementType = "receipt"
+	// StockMovementAdjustment is a manual correction of the stock quantity
+	StockMovementAdjustment StockMovementType = "adjustment"
+	// StockMovementReservation is stock set aside for an order
+	StockMovementReservation StockMovementType = "reservation"
+	// StockMovementRelease is reserved stock returned because the order did not go through
+	StockMovementRelease StockMovementType = "release"
+	// StockMovementSale is reserved stock leaving the warehouse for a completed order
+	StockMovementSale StockMovementType = "sale"
+)
+
+// Actors recorded in the ledger for changes not made by a user
+const (
+	ActorSystem = "system"
+	ActorAPI    = "api"
+)
+
+// StockMovement is a ledger entry. StockDelta and ReservedDelta are the
+// changes applied to the product's stock_quantity and reserved_stock, and to
+// its stock in the warehouse.
+type StockMovement struct {
+	ID            string            `json:"id"`
+	ProductID     string            `json:"product_id"`
+	WarehouseID   string            `json:"warehouse_id,omitempty"`
+	Type          StockMovementType `json:"type"`
+	StockDelta    int32             `json:"stock_delta"`
+	ReservedDelta int32             `json:"reserved_delta"`
+	Reason        string            `json:"reason"`
+	OrderID       string            `json:"order_id,omitempty"`
+	CorrelationID string            `json:"correlation_id,omitempty"`
+	Actor         string            `json:"actor"`
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+// StockChange is a receipt or manual adjustment of a product's stock.
+// Quantity is always positive, the direction is given by the operation.
+// An empty WarehouseID means the default warehouse.
+type StockChange struct {
+	ProductID   string
+	WarehouseID string
+	Quantity    int32
+	Type        StockMovementType
+	Actor       string
+	Reason      string
+}
+
+// StockMovementFilter selects the movements of a product in [From, To)
+type StockMovementFilter struct {
+	ProductID string
+	From      time.Time
+	To        time.Time
+	Limit     int
+	Offset    int
+}
@@ -0,0 +1,42 @@
+package entity
+
+// StockReconciliation compares a product's stock levels with the totals of
+// its stock ledger, which is the source of truth
+type StockReconciliation struct {
+	ProductID      string `json:"product_id"`
+	ProductName    string `json:"product_name"`
+	StockQuantity  int32  `json:"stock_quantity"`
+	LedgerStock    int32  `json:"ledger_stock"`
+	ReservedStock  int32  `json:"reserved_stock"`
+	LedgerReserved int32  `json:"ledger_reserved"`
+	// WarehouseMismatches lists the warehouses whose stock levels of the product drifted
+	WarehouseMismatches []WarehouseStockReconciliation `json:"warehouse_mismatches,omitempty"`
+}
+
+// StockDrift is how far stock_quantity is off from the ledger
+func (r StockReconciliation) StockDrift() int32 {
+	return r.StockQuantity - r.LedgerStock
+}
+
+// ReservedDrift is how far reserved_stock is off from the open reservations in the ledger
+func (r StockReconciliation) ReservedDrift() int32 {
+	return r.ReservedStock - r.LedgerReserved
+}
+
+func (r StockReconciliation) HasDrift() bool {
+	return r.StockDrift() != 0 || r.ReservedDrift() != 0 || len(r.WarehouseMismatches) > 0
+}
+
+// WarehouseStockReconciliation compares a product's stock levels in one
+// warehouse with the ledger entries booked against that warehouse
+type WarehouseStockReconciliation struct {
+	WarehouseID    string `json:"warehouse_id"`
+	StockQuantity  int32  `json:"stock_quantity"`
+	LedgerStock    int32  `json:"ledger_stock"`
+	ReservedStock  int32  `json:"reserved_stock"`
+	LedgerReserved int32  `json:"ledger_reserved"`
+}
+
+func (r WarehouseStockReconciliation) HasDrift() bool {
+	return r.StockQuantity != r.LedgerStock || r.ReservedStock != r.LedgerReserved
+}
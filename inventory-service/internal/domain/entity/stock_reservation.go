@@ -0,0 +1,39 @@
+package entity
+
+import "fmt"
+
+// StockReservation is a request to reserve a quantity of a single product
+type StockReservation struct {
+	ProductID string `json:"product_id"`
+	Quantity  int32  `json:"quantity"`
+}
+
+// ReservationOutcome reports how much of a requested item was reserved, in
+// which warehouses, and how much was backordered; the rest is unavailable
+type ReservationOutcome struct {
+	ProductID   string                `json:"product_id"`
+	SKU         string                `json:"sku,omitempty"`
+	Requested   int32                 `json:"requested"`
+	Reserved    int32                 `json:"reserved"`
+	Backordered int32                 `json:"backordered"`
+	Allocations []WarehouseAllocation `json:"allocations"`
+}
+
+// IsFulfillable reports whether any of the line will be delivered
+func (o ReservationOutcome) IsFulfillable() bool {
+	return o.Reserved > 0 || o.Backordered > 0
+}
+
+// StockReservationError reports which product could not be reserved and why
+type StockReservationError struct {
+	ProductID string
+	Err       error
+}
+
+func (e *StockReservationError) Error() string {
+	return fmt.Sprintf("cannot reserve product %s: %v", e.ProductID, e.Err)
+}
+
+func (e *StockReservationError) Unwrap() error {
+	return e.Err
+}
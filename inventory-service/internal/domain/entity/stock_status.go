@@ -0,0 +1,21 @@
+package entity
+
+// StockStatus is how a product's available stock compares to its reorder threshold
+type StockStatus string
+
+const (
+	StockStatusInStock    StockStatus = "in_stock"
+	StockStatusLowStock   StockStatus = "low_stock"
+	StockStatusOutOfStock StockStatus = "out_of_stock"
+)
+
+// StockStatusChange records a product's stock status moving across its reorder
+// threshold, compared to the status last reported for it
+type StockStatusChange struct {
+	ProductID        string
+	ProductName      string
+	PreviousStatus   StockStatus
+	Status           StockStatus
+	AvailableStock   int32
+	ReorderThreshold int32
+}
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+var (
+	ErrWarehouseNotFound    = errors.New("warehouse not found")
+	ErrWarehouseNotActive   = errors.New("warehouse is not active")
+	ErrWarehouseCodeTaken   = errors.New("warehouse code is already in use")
+	ErrNoActiveWarehouse    = errors.New("no active warehouse to hold the stock")
+	ErrIncompleteLocation   = errors.New("latitude and longitude must be given together")
+	ErrInvalidLocationRange = errors.New("latitude must be within [-90, 90] and longitude within [-180, 180]")
+)
+
+// earthRadiusKm is the mean radius used for great-circle distances
+const earthRadiusKm = 6371.0
+
+// Location is a point on the globe in decimal degrees
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// NewLocation returns nil when neither coordinate is given
+func NewLocation(latitude, longitude *float64) (*Location, error) {
+	if latitude == nil && longitude == nil {
+		return nil, nil
+	}
+	if latitude == nil || longitude == nil {
+		return nil, ErrIncompleteLocation
+	}
+	if math.Abs(*latitude) > 90 || math.Abs(*longitude) > 180 {
+		return nil, ErrInvalidLocationRange
+	}
+	return &Location{Latitude: *latitude, Longitude: *longitude}, nil
+}
+
+// DistanceKm is the great-circle distance between two locations
+func (l Location) DistanceKm(other Location) float64 {
+	lat1 := l.Latitude * math.Pi / 180
+	lat2 := other.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (other.Longitude - l.Longitude) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// Warehouse is a location holding stock. Lower priority values are preferred
+// when stock is allocated, and new stock without a warehouse goes to the
+// active warehouse with the lowest priority value.
+type Warehouse struct {
+	ID        string    `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Location  *Location `json:"location,omitempty"`
+	Priority  int32     `json:"priority"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WarehouseStock is a product's stock level in a single warehouse. The
+// product's own stock levels are the totals over all its warehouses.
+type WarehouseStock struct {
+	WarehouseID     string `json:"warehouse_id"`
+	WarehouseCode   string `json:"warehouse_code"`
+	WarehouseName   string `json:"warehouse_name"`
+	WarehouseActive bool   `json:"warehouse_active"`
+	ProductID       string `json:"product_id"`
+	StockQuantity   int32  `json:"stock_quantity"`
+	ReservedStock   int32  `json:"reserved_stock"`
+}
+
+func (s WarehouseStock) AvailableStock() int32 {
+	return s.StockQuantity - s.ReservedStock
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+)
+
+type AttributeRepository interface {
+	// Create returns entity.ErrAttributeCodeTaken if the code is already in use
+	Create(ctx context.Context, attribute *entity.Attribute) error
+	// List returns all attributes ordered by code
+	List(ctx context.Context) ([]*entity.Attribute, error)
+	GetProductAttributes(ctx context.Context, productID string) ([]entity.ProductAttribute, error)
+	// SetProductAttributes replaces all of the product's attribute values
+	SetProductAttributes(ctx context.Context, productID string, attributes []entity.ProductAttribute) error
+}
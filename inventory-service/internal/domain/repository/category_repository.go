@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+)
+
+type CategoryRepository interface {
+	// Create returns entity.ErrCategorySlugTaken if the slug is already in use
+	// and entity.ErrCategoryNotFound if the parent does not exist
+	Create(ctx context.Context, category *entity.Category) error
+	GetByID(ctx context.Context, id string) (*entity.Category, error)
+	// List returns all categories ordered by name, parents are not guaranteed to come first
+	List(ctx context.Context) ([]*entity.Category, error)
+	// Update returns entity.ErrCategoryCycle if the new parent is the category
+	// itself or one of its subcategories
+	Update(ctx context.Context, category *entity.Category) error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
 )
@@ -9,10 +10,66 @@ import (
 type InventoryRepository interface {
 	Create(ctx context.Context, product *entity.Product) error
 	GetByID(ctx context.Context, id string) (*entity.Product, error)
+	// Update only succeeds if the product is still at the version it was read at,
+	// otherwise it returns entity.ErrConcurrentModification
 	Update(ctx context.Context, product *entity.Product) error
 	Delete(ctx context.Context, id string) error
-	List(ctx context.Context, limit, offset int) ([]*entity.Product, error)
+	List(ctx context.Context, limit, offset int, includeInactive bool) ([]*entity.Product, error)
+	Count(ctx context.Context, includeInactive bool) (int64, error)
 	GetActiveProducts(ctx context.Context) ([]*entity.Product, error)
 	GetByIDs(ctx context.Context, ids []string) ([]*entity.Product, error)
+	// UpdateMultiple applies the same version check to every product in one transaction
 	UpdateMultiple(ctx context.Context, products []*entity.Product) error
+	// ReserveStock atomically reserves all items or none, allocating them to
+	// warehouses by the policy. It returns *entity.StockReservationError for the
+	// first item that cannot be reserved in full.
+	ReserveStock(ctx context.Context, orderID, correlationID string, items []entity.StockReservation, policy entity.AllocationPolicy) ([]entity.ReservationOutcome, error)
+	// ReserveAvailableStock reserves as much of each item as is in stock and,
+	// when backorder is set, records the shortfall as backorders in the same transaction
+	ReserveAvailableStock(ctx context.Context, orderID, correlationID string, items []entity.StockReservation, policy entity.AllocationPolicy, backorder bool) ([]entity.ReservationOutcome, error)
+	// ReceiveStock adds arriving stock to a warehouse and reserves it for
+	// pending backorders, oldest first
+	ReceiveStock(ctx context.Context, change entity.StockChange) ([]entity.BackorderAllocation, error)
+	// RemoveStock decreases stock in a warehouse, returning entity.ErrInsufficientStock
+	// if that would dip into stock reserved there
+	RemoveStock(ctx context.Context, change entity.StockChange) error
+	// GetStockLevels returns the product's stock in every warehouse that held it
+	GetStockLevels(ctx context.Context, productID string) ([]entity.WarehouseStock, error)
+	// Search returns a page of the products matching the search along with
+	// facet counts over all matching products
+	Search(ctx context.Context, search entity.ProductSearch) (*entity.ProductSearchResult, error)
+	// GetVariants returns the variants of each of the parent products, keyed by parent ID
+	GetVariants(ctx context.Context, parentIDs []string, includeInactive bool) (map[string][]*entity.Product, error)
+	// GetStockLevelsByProducts returns the stock per warehouse of each of the products
+	GetStockLevelsByProducts(ctx context.Context, productIDs []string) (map[string][]entity.WarehouseStock, error)
+	// ListAfter pages through the products that are not variants in ID order,
+	// starting after afterID
+	ListAfter(ctx context.Context, afterID string, limit int) ([]*entity.Product, error)
+	// ApplyScheduledPrice starts or ends the scheduled price if it is due at
+	// now, recording the new price in the product's price history. It returns
+	// nil if the change is no longer due, e.g. because another replica applied it.
+	ApplyScheduledPrice(ctx context.Context, scheduleID string, now time.Time) (*entity.ScheduledPriceTransition, error)
+	// ImportProducts upserts the rows in one transaction, reporting rows that
+	// fail without aborting the rest. With dryRun set nothing is committed.
+	ImportProducts(ctx context.Context, rows []entity.ProductImportRow, actor string, dryRun bool) ([]entity.ProductImportResult, error)
+	// ReleaseOrderReservations returns the order's outstanding reservations to available stock
+	ReleaseOrderReservations(ctx context.Context, orderID, correlationID, reason string) ([]entity.StockMovement, error)
+	// ConfirmOrderSale takes the order's outstanding reservations out of stock
+	ConfirmOrderSale(ctx context.Context, orderID, correlationID string) ([]entity.StockMovement, error)
+	// ListMovements returns the product's stock ledger entries, newest first.
+	// Every change to stock_quantity and reserved_stock is recorded in the same
+	// transaction as the change itself.
+	ListMovements(ctx context.Context, filter entity.StockMovementFilter) ([]*entity.StockMovement, error)
+	// GetStockReconciliations compares every product's stock levels, in total
+	// and per warehouse, with its ledger totals
+	GetStockReconciliations(ctx context.Context) ([]entity.StockReconciliation, error)
+	// UpdateStockStatuses stores the current stock status of each product and
+	// returns the products whose status differs from the one stored before
+	UpdateStockStatuses(ctx context.Context, productIDs []string) ([]entity.StockStatusChange, error)
+	// ListLowStock returns a page of active products at or below their reorder threshold
+	ListLowStock(ctx context.Context, limit, offset int) ([]*entity.Product, error)
+	CountLowStock(ctx context.Context) (int64, error)
+	// RepairStockLevels sets the product's stock levels, in total and per
+	// warehouse, to its ledger totals and returns the levels it found before the repair
+	RepairStockLevels(ctx context.Context, productID string) (*entity.StockReconciliation, error)
 }
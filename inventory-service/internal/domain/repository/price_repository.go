@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+)
+
+type PriceRepository interface {
+	// GetPriceAt returns the price the product had at the given time,
+	// entity.ErrPriceNotFound if it had none yet
+	GetPriceAt(ctx context.Context, productID string, at time.Time) (*entity.PriceHistoryEntry, error)
+	// ListHistory returns a page of the product's prices, newest first
+	ListHistory(ctx context.Context, productID string, limit, offset int) ([]entity.PriceHistoryEntry, error)
+	CountHistory(ctx context.Context, productID string) (int64, error)
+	// CreateSchedule returns entity.ErrScheduledPriceOverlap if the change
+	// overlaps a pending change of the product
+	CreateSchedule(ctx context.Context, schedule *entity.ScheduledPrice) error
+	// ListSchedules returns all scheduled prices of the product in start order
+	ListSchedules(ctx context.Context, productID string) ([]entity.ScheduledPrice, error)
+	// CancelSchedule cancels a change that has not started yet, otherwise it
+	// returns entity.ErrScheduledPriceNotPending
+	CancelSchedule(ctx context.Context, productID, scheduleID string) (*entity.ScheduledPrice, error)
+	// ListDueSchedules returns up to limit changes due to start or end at now
+	ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]entity.ScheduledPrice, error)
+}
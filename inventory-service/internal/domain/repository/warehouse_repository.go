@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+)
+
+type WarehouseRepository interface {
+	// Create returns entity.ErrWarehouseCodeTaken if the code is already in use
+	Create(ctx context.Context, warehouse *entity.Warehouse) error
+	GetByID(ctx context.Context, id string) (*entity.Warehouse, error)
+	// List returns all warehouses, preferred ones first
+	List(ctx context.Context) ([]*entity.Warehouse, error)
+	Update(ctx context.Context, warehouse *entity.Warehouse) error
+}
@@ -11,17 +11,20 @@ import (
 )
 
 type OrderEventConsumer struct {
-	consumer            *messaging.EventConsumer
-	reserveStockUseCase *usecase.ReserveStockUseCase
+	consumer                *messaging.EventConsumer
+	reserveStockUseCase     *usecase.ReserveStockUseCase
+	settleOrderStockUseCase *usecase.SettleOrderStockUseCase
 }
 
 func NewOrderEventConsumer(
 	consumer *messaging.EventConsumer,
 	reserveStockUseCase *usecase.ReserveStockUseCase,
+	settleOrderStockUseCase *usecase.SettleOrderStockUseCase,
 ) *OrderEventConsumer {
 	return &OrderEventConsumer{
-		consumer:            consumer,
-		reserveStockUseCase: reserveStockUseCase,
+		consumer:                consumer,
+		reserveStockUseCase:     reserveStockUseCase,
+		settleOrderStockUseCase: settleOrderStockUseCase,
 	}
 }
 
@@ -29,8 +32,23 @@ func NewOrderEventConsumer(
 func (c *OrderEventConsumer) Start() error {
 	log.Println("Starting Order Event Consumer...")
 
-	// Subscribe to order.created events
-	return c.consumer.Subscribe("order.created", c.handleOrderCreated)
+	return c.consumer.Consume(c.handleEvent)
+}
+
+// handleEvent dispatches order events by type
+func (c *OrderEventConsumer) handleEvent(eventType string, body []byte) error {
+	switch eventType {
+	case events.OrderCreatedEventType:
+		return c.handleOrderCreated(body)
+	case events.OrderFailedEventType:
+		return c.handleOrderFailed(body)
+	case events.OrderCancelledEventType:
+		return c.handleOrderCancelled(body)
+	case events.OrderCompletedEventType:
+		return c.handleOrderCompleted(body)
+	default:
+		return nil // Ignore events we're not interested in
+	}
 }
 
 // handleOrderCreated processes order.created events
@@ -51,10 +69,44 @@ func (c *OrderEventConsumer) handleOrderCreated(body []byte) error {
 	ctx := context.Background()
 	if err := c.reserveStockUseCase.Execute(ctx, event); err != nil {
 		log.Printf("ERROR: Failed to reserve stock for order %s: %v", event.OrderID, err)
-		// Error already handled in use case (failure event published)
+		// Rejected orders are answered with a failure event by the use case,
+		// errors reaching here are infrastructure failures worth retrying
 		return err
 	}
 
-	log.Printf("Successfully reserved stock for order %s", event.OrderID)
+	log.Printf("Finished stock reservation for order %s", event.OrderID)
 	return nil
 }
+
+// handleOrderFailed releases the stock of failed orders
+func (c *OrderEventConsumer) handleOrderFailed(body []byte) error {
+	var event events.OrderFailedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		log.Printf("ERROR: Failed to unmarshal order.failed event: %v", err)
+		return err
+	}
+
+	return c.settleOrderStockUseCase.Release(context.Background(), event.OrderID, event.CorrelationID, "order failed: "+event.Reason)
+}
+
+// handleOrderCancelled releases the stock of cancelled orders
+func (c *OrderEventConsumer) handleOrderCancelled(body []byte) error {
+	var event events.OrderCancelledEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		log.Printf("ERROR: Failed to unmarshal order.cancelled event: %v", err)
+		return err
+	}
+
+	return c.settleOrderStockUseCase.Release(context.Background(), event.OrderID, event.CorrelationID, "order cancelled: "+event.Reason)
+}
+
+// handleOrderCompleted turns the reservations of completed orders into sales
+func (c *OrderEventConsumer) handleOrderCompleted(body []byte) error {
+	var event events.OrderCompletedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		log.Printf("ERROR: Failed to unmarshal order.completed event: %v", err)
+		return err
+	}
+
+	return c.settleOrderStockUseCase.ConfirmSale(context.Background(), event.OrderID, event.CorrelationID)
+}
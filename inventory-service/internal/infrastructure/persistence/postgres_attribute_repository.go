@@ -0,0 +1,137 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+	sqlc "github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/infrastructure/persistence/sqlc"
+)
+
+type PostgresAttributeRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewPostgresAttributeRepository(db *sql.DB) repository.AttributeRepository {
+	return &PostgresAttributeRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *PostgresAttributeRepository) Create(ctx context.Context, attribute *entity.Attribute) error {
+	uid, err := parseStringToUUID(attribute.ID)
+	if err != nil {
+		return errors.New("invalid attribute ID format")
+	}
+
+	err = r.queries.CreateAttribute(ctx, sqlc.CreateAttributeParams{
+		ID:   uid,
+		Code: attribute.Code,
+		Name: attribute.Name,
+		Type: string(attribute.Type),
+	})
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return entity.ErrAttributeCodeTaken
+		}
+		return fmt.Errorf("could not create attribute: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresAttributeRepository) List(ctx context.Context) ([]*entity.Attribute, error) {
+	rows, err := r.queries.ListAttributes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list attributes: %w", err)
+	}
+
+	attributes := make([]*entity.Attribute, len(rows))
+	for i, row := range rows {
+		attributes[i] = &entity.Attribute{
+			ID:        row.ID.String(),
+			Code:      row.Code,
+			Name:      row.Name,
+			Type:      entity.AttributeType(row.Type),
+			CreatedAt: row.CreatedAt,
+		}
+	}
+	return attributes, nil
+}
+
+func (r *PostgresAttributeRepository) GetProductAttributes(ctx context.Context, productID string) ([]entity.ProductAttribute, error) {
+	uid, err := parseStringToUUID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", entity.ErrProductNotFound, productID)
+	}
+
+	rows, err := r.queries.ListProductAttributes(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("could not list product attributes: %w", err)
+	}
+
+	attributes := make([]entity.ProductAttribute, len(rows))
+	for i, row := range rows {
+		attributes[i] = entity.ProductAttribute{
+			Attribute: entity.Attribute{
+				ID:        row.ID.String(),
+				Code:      row.Code,
+				Name:      row.Name,
+				Type:      entity.AttributeType(row.Type),
+				CreatedAt: row.CreatedAt,
+			},
+			Value: row.Value,
+		}
+	}
+	return attributes, nil
+}
+
+func (r *PostgresAttributeRepository) SetProductAttributes(ctx context.Context, productID string, attributes []entity.ProductAttribute) error {
+	uid, err := parseStringToUUID(productID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", entity.ErrProductNotFound, productID)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+	if err := qtx.DeleteProductAttributes(ctx, uid); err != nil {
+		return fmt.Errorf("could not clear product attributes: %w", err)
+	}
+	for _, attribute := range attributes {
+		attributeID, err := parseStringToUUID(attribute.Attribute.ID)
+		if err != nil {
+			return fmt.Errorf("%w: %s", entity.ErrAttributeNotFound, attribute.Attribute.Code)
+		}
+		err = qtx.CreateProductAttribute(ctx, sqlc.CreateProductAttributeParams{
+			ProductID:   uid,
+			AttributeID: attributeID,
+			Value:       attribute.Value,
+		})
+		if err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+				if pqErr.Constraint == "product_attributes_product_id_fkey" {
+					return fmt.Errorf("%w: %s", entity.ErrProductNotFound, productID)
+				}
+				return fmt.Errorf("%w: %s", entity.ErrAttributeNotFound, attribute.Attribute.Code)
+			}
+			return fmt.Errorf("could not set product attribute: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+	sqlc "github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/infrastructure/persistence/sqlc"
+)
+
+type PostgresCategoryRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewPostgresCategoryRepository(db *sql.DB) repository.CategoryRepository {
+	return &PostgresCategoryRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *PostgresCategoryRepository) Create(ctx context.Context, category *entity.Category) error {
+	uid, err := parseStringToUUID(category.ID)
+	if err != nil {
+		return errors.New("invalid category ID format")
+	}
+
+	err = r.queries.CreateCategory(ctx, sqlc.CreateCategoryParams{
+		ID:       uid,
+		ParentID: stringToNullUUID(category.ParentID),
+		Name:     category.Name,
+		Slug:     category.Slug,
+	})
+	if err != nil {
+		return categoryWriteError(err, "could not create category")
+	}
+	return nil
+}
+
+func (r *PostgresCategoryRepository) GetByID(ctx context.Context, id string) (*entity.Category, error) {
+	uid, err := parseStringToUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", entity.ErrCategoryNotFound, id)
+	}
+
+	row, err := r.queries.GetCategoryByID(ctx, uid)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", entity.ErrCategoryNotFound, id)
+		}
+		return nil, fmt.Errorf("could not get category: %w", err)
+	}
+
+	category := toCategoryEntity(row)
+	return &category, nil
+}
+
+func (r *PostgresCategoryRepository) List(ctx context.Context) ([]*entity.Category, error) {
+	rows, err := r.queries.ListCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list categories: %w", err)
+	}
+
+	categories := make([]*entity.Category, len(rows))
+	for i, row := range rows {
+		category := toCategoryEntity(row)
+		categories[i] = &category
+	}
+	return categories, nil
+}
+
+func (r *PostgresCategoryRepository) Update(ctx context.Context, category *entity.Category) error {
+	uid, err := parseStringToUUID(category.ID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", entity.ErrCategoryNotFound, category.ID)
+	}
+
+	// Walk up from the new parent, reaching the category itself would close a loop
+	if category.ParentID != "" {
+		parentID, err := parseStringToUUID(category.ParentID)
+		if err != nil {
+			return fmt.Errorf("%w: %s", entity.ErrCategoryNotFound, category.ParentID)
+		}
+		ancestors, err := r.queries.ListCategoryAncestorIDs(ctx, parentID)
+		if err != nil {
+			return fmt.Errorf("could not check category parent: %w", err)
+		}
+		if len(ancestors) == 0 {
+			return fmt.Errorf("%w: %s", entity.ErrCategoryNotFound, category.ParentID)
+		}
+		for _, ancestor := range ancestors {
+			if ancestor == uid {
+				return entity.ErrCategoryCycle
+			}
+		}
+	}
+
+	rows, err := r.queries.UpdateCategory(ctx, sqlc.UpdateCategoryParams{
+		ID:       uid,
+		ParentID: stringToNullUUID(category.ParentID),
+		Name:     category.Name,
+		Slug:     category.Slug,
+	})
+	if err != nil {
+		return categoryWriteError(err, "could not update category")
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: %s", entity.ErrCategoryNotFound, category.ID)
+	}
+	return nil
+}
+
+// categoryWriteError translates the constraints on categories into domain errors
+func categoryWriteError(err error, message string) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case uniqueViolation:
+			return entity.ErrCategorySlugTaken
+		case foreignKeyViolation:
+			return entity.ErrCategoryNotFound
+		}
+	}
+	return fmt.Errorf("%s: %w", message, err)
+}
+
+func toCategoryEntity(row sqlc.Category) entity.Category {
+	return entity.Category{
+		ID:        row.ID.String(),
+		ParentID:  nullUUIDToString(row.ParentID),
+		Name:      row.Name,
+		Slug:      row.Slug,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
@@ -1,12 +1,18 @@
 package persistence
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
 	sqlc "github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/infrastructure/persistence/sqlc"
 )
@@ -23,14 +29,26 @@ func NewPostgresInventoryRepository(db *sql.DB) *PostgresInventoryRepository {
 	}
 }
 
-// Create creates a new product
+// Create creates a new product, recording its initial stock in the stock ledger
 func (r *PostgresInventoryRepository) Create(ctx context.Context, product *entity.Product) error {
 	uid, err := parseStringToUUID(product.ID)
 	if err != nil {
-		return errors.New("invalid user ID format")
+		return errors.New("invalid product ID format")
 	}
 
-	return r.queries.CreateProduct(ctx, sqlc.CreateProductParams{
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+	if product.IsVariant() {
+		if err := checkVariantParent(ctx, qtx, product.ParentID); err != nil {
+			return err
+		}
+	}
+	err = qtx.CreateProduct(ctx, sqlc.CreateProductParams{
 		ID:            uid,
 		Name:          product.Name,
 		Description:   sql.NullString{String: product.Description, Valid: product.Description != ""},
@@ -38,19 +56,68 @@ func (r *PostgresInventoryRepository) Create(ctx context.Context, product *entit
 		StockQuantity: product.StockQuantity,
 		ReservedStock: product.ReservedStock,
 		IsActive:      product.IsActive,
+		// The initial status is not alerted, only later changes to it are
+		ReorderThreshold: product.ReorderThreshold,
+		StockStatus:      string(product.StockStatus()),
+		ParentID:         stringToNullUUID(product.ParentID),
+		Sku:              sql.NullString{String: product.SKU, Valid: product.SKU != ""},
+		Options:          optionsToJSON(product.Options),
+		CategoryID:       stringToNullUUID(product.CategoryID),
 	})
+	if err != nil {
+		return productWriteError(err)
+	}
+	err = recordPrice(ctx, qtx, uid, fmt.Sprintf("%.2f", product.Price), entity.PriceSourceCreated, uuid.NullUUID{}, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	// Initial stock is booked into the default warehouse
+	if product.StockQuantity > 0 {
+		warehouse, err := resolveWarehouse(ctx, qtx, "")
+		if err != nil {
+			return err
+		}
+		err = qtx.IncreaseWarehouseStock(ctx, sqlc.IncreaseWarehouseStockParams{
+			WarehouseID: warehouse.ID,
+			ProductID:   uid,
+			Quantity:    product.StockQuantity,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to increase warehouse stock: %w", err)
+		}
+
+		err = recordMovement(ctx, qtx, entity.StockMovement{
+			ProductID:   product.ID,
+			WarehouseID: warehouse.ID.String(),
+			Type:        entity.StockMovementReceipt,
+			StockDelta:  product.StockQuantity,
+			Reason:      "initial stock",
+			Actor:       entity.ActorSystem,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	product.Version = 1
+	return nil
 }
 
 // GetByID retrieves a product by ID
 func (r *PostgresInventoryRepository) GetByID(ctx context.Context, id string) (*entity.Product, error) {
 	uid, err := parseStringToUUID(id)
 	if err != nil {
-		return nil, errors.New("invalid user ID format")
+		return nil, errors.New("invalid product ID format")
 	}
 	row, err := r.queries.GetProductByID(ctx, uid)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("product not found: %s", id)
+			return nil, fmt.Errorf("%w: %s", entity.ErrProductNotFound, id)
 		}
 		return nil, err
 	}
@@ -62,32 +129,64 @@ func (r *PostgresInventoryRepository) GetByID(ctx context.Context, id string) (*
 func (r *PostgresInventoryRepository) Update(ctx context.Context, product *entity.Product) error {
 	uid, err := parseStringToUUID(product.ID)
 	if err != nil {
-		return errors.New("invalid user ID format")
+		return errors.New("invalid product ID format")
 	}
-	return r.queries.UpdateProduct(ctx, sqlc.UpdateProductParams{
-		ID:            uid,
-		Name:          product.Name,
-		Description:   sql.NullString{String: product.Description, Valid: product.Description != ""},
-		Price:         fmt.Sprintf("%.2f", product.Price),
-		StockQuantity: product.StockQuantity,
-		ReservedStock: product.ReservedStock,
-		IsActive:      product.IsActive,
-	})
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+	params := toUpdateProductParams(uid, product)
+	rows, err := qtx.UpdateProduct(ctx, params)
+	if err != nil {
+		return productWriteError(err)
+	}
+	// No row matched the version we read, someone else updated the product first
+	if rows == 0 {
+		return entity.ErrConcurrentModification
+	}
+	err = recordPrice(ctx, qtx, uid, params.Price, entity.PriceSourceUpdated, uuid.NullUUID{}, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	product.Version++
+	return nil
 }
 
 func (r *PostgresInventoryRepository) Delete(ctx context.Context, id string) error {
 	uid, err := parseStringToUUID(id)
 	if err != nil {
-		return errors.New("invalid user ID format")
+		return errors.New("invalid product ID format")
 	}
 	return r.queries.DeleteProduct(ctx, uid)
 }
 
-func (r *PostgresInventoryRepository) List(ctx context.Context, limit, offset int) ([]*entity.Product, error) {
-	rows, err := r.queries.ListProducts(ctx, sqlc.ListProductsParams{
-		Limit:  int32(limit),
-		Offset: int32(offset),
-	})
+// List returns a page of products, newest first. Inactive products are only
+// included when includeInactive is set.
+func (r *PostgresInventoryRepository) List(ctx context.Context, limit, offset int, includeInactive bool) ([]*entity.Product, error) {
+	var (
+		rows []sqlc.Product
+		err  error
+	)
+	if includeInactive {
+		rows, err = r.queries.ListAllProducts(ctx, sqlc.ListAllProductsParams{
+			Limit:  int32(limit),
+			Offset: int32(offset),
+		})
+	} else {
+		rows, err = r.queries.ListProducts(ctx, sqlc.ListProductsParams{
+			Limit:  int32(limit),
+			Offset: int32(offset),
+		})
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -100,6 +199,14 @@ func (r *PostgresInventoryRepository) List(ctx context.Context, limit, offset in
 	return products, nil
 }
 
+// Count returns the number of products List pages through
+func (r *PostgresInventoryRepository) Count(ctx context.Context, includeInactive bool) (int64, error) {
+	if includeInactive {
+		return r.queries.CountAllProducts(ctx)
+	}
+	return r.queries.CountProducts(ctx)
+}
+
 func (r *PostgresInventoryRepository) GetActiveProducts(ctx context.Context) ([]*entity.Product, error) {
 	rows, err := r.queries.GetActiveProducts(ctx)
 	if err != nil {
@@ -119,7 +226,7 @@ func (r *PostgresInventoryRepository) GetByIDs(ctx context.Context, ids []string
 	for i, id := range ids {
 		uid, err := parseStringToUUID(id)
 		if err != nil {
-			return nil, errors.New("invalid user ID format")
+			return nil, errors.New("invalid product ID format")
 		}
 		uids[i] = uid
 	}
@@ -151,20 +258,20 @@ func (r *PostgresInventoryRepository) UpdateMultiple(ctx context.Context, produc
 	for _, product := range products {
 		uid, err := parseStringToUUID(product.ID)
 		if err != nil {
-			return fmt.Errorf("invalid user ID format: %w", err)
+			return fmt.Errorf("invalid product ID format: %w", err)
 		}
-		err = qtx.UpdateProduct(ctx, sqlc.UpdateProductParams{
-			ID:            uid,
-			Name:          product.Name,
-			Description:   sql.NullString{String: product.Description, Valid: product.Description != ""},
-			Price:         fmt.Sprintf("%.2f", product.Price),
-			StockQuantity: product.StockQuantity,
-			ReservedStock: product.ReservedStock,
-			IsActive:      product.IsActive,
-		})
+		params := toUpdateProductParams(uid, product)
+		rows, err := qtx.UpdateProduct(ctx, params)
 		if err != nil {
 			// Transaction will auto-rollback due to defer
-			return fmt.Errorf("failed to update product %s: %w", product.ID, err)
+			return fmt.Errorf("failed to update product %s: %w", product.ID, productWriteError(err))
+		}
+		if rows == 0 {
+			return fmt.Errorf("failed to update product %s: %w", product.ID, entity.ErrConcurrentModification)
+		}
+		err = recordPrice(ctx, qtx, uid, params.Price, entity.PriceSourceUpdated, uuid.NullUUID{}, time.Now().UTC())
+		if err != nil {
+			return err
 		}
 	}
 
@@ -173,30 +280,1574 @@ func (r *PostgresInventoryRepository) UpdateMultiple(ctx context.Context, produc
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	for _, product := range products {
+		product.Version++
+	}
 	return nil
 }
 
-func (r *PostgresInventoryRepository) rowToEntity(row interface{}) *entity.Product {
-	var product entity.Product
-	// Type assertion to handle both single row and multiple rows
-	switch v := row.(type) {
-	case sqlc.Product:
-		product.ID = v.ID.String()
-		product.Name = v.Name
-		product.Description = v.Description.String
-		// Parse price from string to float64
-		_, _ = fmt.Sscanf(v.Price, "%f", &product.Price)
-		product.StockQuantity = v.StockQuantity
-		product.ReservedStock = v.ReservedStock
-		product.IsActive = v.IsActive
-		product.CreatedAt = v.CreatedAt
-		product.UpdatedAt = v.UpdatedAt
+// ReserveStock reserves stock for all items in a single transaction. The
+// products and their warehouse stock are locked first, so the allocation is
+// computed from current stock and concurrent reservations on other replicas
+// cannot oversell. If any item cannot be reserved in full the whole
+// reservation is rolled back.
+func (r *PostgresInventoryRepository) ReserveStock(
+	ctx context.Context,
+	orderID, correlationID string,
+	items []entity.StockReservation,
+	policy entity.AllocationPolicy,
+) ([]entity.ReservationOutcome, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	return &product
+	qtx := r.queries.WithTx(tx)
+	outcomes, products, err := allocateReservations(ctx, qtx, items, policy)
+	if err != nil {
+		return nil, err
+	}
+	for i, outcome := range outcomes {
+		if outcome.Reserved < outcome.Requested {
+			return nil, &entity.StockReservationError{
+				ProductID: items[i].ProductID,
+				Err:       reservationFailureReason(products, items[i].ProductID),
+			}
+		}
+	}
+
+	for _, outcome := range outcomes {
+		if err := reserveAllocations(ctx, qtx, orderID, correlationID, outcome); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return outcomes, nil
 }
 
-// parseStringToUUID converts string to uuid.UUID
-func parseStringToUUID(id string) (uuid.UUID, error) {
-	return uuid.Parse(id)
+// ReserveAvailableStock reserves min(requested, available) for every item.
+// Like ReserveStock it allocates from locked stock; inactive or unknown
+// products are reported as unavailable.
+func (r *PostgresInventoryRepository) ReserveAvailableStock(
+	ctx context.Context,
+	orderID, correlationID string,
+	items []entity.StockReservation,
+	policy entity.AllocationPolicy,
+	backorder bool,
+) ([]entity.ReservationOutcome, error) {
+	orderUUID, err := parseStringToUUID(orderID)
+	if err != nil {
+		return nil, errors.New("invalid order ID format")
+	}
+	correlationUUID, err := parseStringToUUID(correlationID)
+	if err != nil {
+		return nil, errors.New("invalid correlation ID format")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+	outcomes, products, err := allocateReservations(ctx, qtx, items, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range outcomes {
+		outcome := &outcomes[i]
+		if err := reserveAllocations(ctx, qtx, orderID, correlationID, *outcome); err != nil {
+			return nil, err
+		}
+
+		product, found := products[outcome.ProductID]
+		if backorder && found && product.IsActive && !product.hasVariants && outcome.Reserved < outcome.Requested {
+			outcome.Backordered = outcome.Requested - outcome.Reserved
+			err := qtx.CreateBackorder(ctx, sqlc.CreateBackorderParams{
+				ID:            uuid.New(),
+				OrderID:       orderUUID,
+				CorrelationID: correlationUUID,
+				ProductID:     product.ID,
+				Quantity:      outcome.Backordered,
+				Status:        string(entity.BackorderStatusPending),
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to backorder product %s: %w", outcome.ProductID, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return outcomes, nil
+}
+
+// lockedProduct is a product row locked for a reservation
+type lockedProduct struct {
+	sqlc.Product
+	// hasVariants marks a parent product, which holds no stock of its own
+	hasVariants bool
+}
+
+// allocateReservations locks the items' products and their stock in active
+// warehouses and decides which warehouses each item is reserved in. Nothing is
+// reserved yet; the locked products are returned by item product ID.
+func allocateReservations(
+	ctx context.Context,
+	qtx *sqlc.Queries,
+	items []entity.StockReservation,
+	policy entity.AllocationPolicy,
+) ([]entity.ReservationOutcome, map[string]lockedProduct, error) {
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return nil, nil, &entity.StockReservationError{ProductID: item.ProductID, Err: entity.ErrInvalidQuantity}
+		}
+	}
+
+	// Lock rows in a stable order to avoid deadlocks
+	productIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+	}
+	sort.Strings(productIDs)
+
+	products := make(map[string]lockedProduct, len(productIDs))
+	activeIDs := make(map[uuid.UUID]string, len(productIDs))
+	for _, id := range productIDs {
+		if _, locked := products[id]; locked {
+			continue
+		}
+		uid, err := parseStringToUUID(id)
+		if err != nil {
+			continue
+		}
+		row, err := qtx.GetProductForUpdate(ctx, uid)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to lock product %s: %w", id, err)
+		}
+		variants, err := qtx.CountProductVariants(ctx, uuid.NullUUID{UUID: uid, Valid: true})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to count variants of product %s: %w", id, err)
+		}
+		products[id] = lockedProduct{Product: row, hasVariants: variants > 0}
+		if row.IsActive && variants == 0 {
+			activeIDs[row.ID] = id
+		}
+	}
+
+	warehouseRows, err := qtx.GetActiveWarehouses(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get warehouses: %w", err)
+	}
+	warehouses := make([]entity.Warehouse, len(warehouseRows))
+	for i, row := range warehouseRows {
+		warehouses[i] = toWarehouseEntity(row)
+	}
+
+	uids := make([]uuid.UUID, 0, len(activeIDs))
+	for uid := range activeIDs {
+		uids = append(uids, uid)
+	}
+	levelRows, err := qtx.GetWarehouseStockForUpdate(ctx, uids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to lock warehouse stock: %w", err)
+	}
+	levels := make([]entity.WarehouseStock, len(levelRows))
+	for i, row := range levelRows {
+		levels[i] = entity.WarehouseStock{
+			WarehouseID:   row.WarehouseID.String(),
+			ProductID:     activeIDs[row.ProductID],
+			StockQuantity: row.StockQuantity,
+			ReservedStock: row.ReservedStock,
+		}
+	}
+
+	allocations := entity.AllocateStock(policy, items, warehouses, levels)
+	outcomes := make([]entity.ReservationOutcome, len(items))
+	for i, item := range items {
+		outcomes[i] = entity.ReservationOutcome{
+			ProductID:   item.ProductID,
+			SKU:         products[item.ProductID].Sku.String,
+			Requested:   item.Quantity,
+			Allocations: allocations[i],
+		}
+		for _, allocation := range allocations[i] {
+			outcomes[i].Reserved += allocation.Quantity
+		}
+	}
+	return outcomes, products, nil
+}
+
+// reserveAllocations reserves an outcome's allocations in their warehouses
+func reserveAllocations(ctx context.Context, qtx *sqlc.Queries, orderID, correlationID string, outcome entity.ReservationOutcome) error {
+	for _, allocation := range outcome.Allocations {
+		err := reserveWarehouseStock(ctx, qtx, entity.StockMovement{
+			ProductID:     outcome.ProductID,
+			WarehouseID:   allocation.WarehouseID,
+			Type:          entity.StockMovementReservation,
+			ReservedDelta: allocation.Quantity,
+			Reason:        "order reservation",
+			OrderID:       orderID,
+			CorrelationID: correlationID,
+			Actor:         entity.ActorSystem,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reserveWarehouseStock reserves movement.ReservedDelta of the product in the
+// warehouse and in the product's totals, and records the reservation in the ledger
+func reserveWarehouseStock(ctx context.Context, qtx *sqlc.Queries, movement entity.StockMovement) error {
+	productUUID, err := parseStringToUUID(movement.ProductID)
+	if err != nil {
+		return errors.New("invalid product ID format")
+	}
+	warehouseUUID, err := parseStringToUUID(movement.WarehouseID)
+	if err != nil {
+		return errors.New("invalid warehouse ID format")
+	}
+
+	rows, err := qtx.ReserveWarehouseStock(ctx, sqlc.ReserveWarehouseStockParams{
+		Quantity:    movement.ReservedDelta,
+		WarehouseID: warehouseUUID,
+		ProductID:   productUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reserve product %s in warehouse %s: %w", movement.ProductID, movement.WarehouseID, err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("failed to reserve product %s in warehouse %s: %w", movement.ProductID, movement.WarehouseID, entity.ErrInsufficientStock)
+	}
+
+	rows, err = qtx.ReserveProductStock(ctx, sqlc.ReserveProductStockParams{
+		Quantity: movement.ReservedDelta,
+		ID:       productUUID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reserve product %s: %w", movement.ProductID, err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("failed to reserve product %s: %w", movement.ProductID, entity.ErrInsufficientStock)
+	}
+
+	return recordMovement(ctx, qtx, movement)
+}
+
+// ReceiveStock increases the product's stock in the warehouse and reserves as
+// much of it as possible for pending backorders, oldest first, in one transaction
+func (r *PostgresInventoryRepository) ReceiveStock(ctx context.Context, change entity.StockChange) ([]entity.BackorderAllocation, error) {
+	if change.Quantity <= 0 {
+		return nil, entity.ErrInvalidQuantity
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	allocations, err := receiveStock(ctx, r.queries.WithTx(tx), change)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return allocations, nil
+}
+
+// receiveStock books arriving stock and allocates it to backorders within the caller's transaction
+func receiveStock(ctx context.Context, qtx *sqlc.Queries, change entity.StockChange) ([]entity.BackorderAllocation, error) {
+	productID := change.ProductID
+	uid, err := parseStringToUUID(productID)
+	if err != nil {
+		return nil, entity.ErrProductNotFound
+	}
+
+	variants, err := qtx.CountProductVariants(ctx, uuid.NullUUID{UUID: uid, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count variants: %w", err)
+	}
+	if variants > 0 {
+		return nil, entity.ErrProductHasVariants
+	}
+
+	warehouse, err := resolveWarehouse(ctx, qtx, change.WarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	if !warehouse.IsActive {
+		return nil, entity.ErrWarehouseNotActive
+	}
+	warehouseID := warehouse.ID.String()
+
+	rows, err := qtx.IncreaseProductStock(ctx, sqlc.IncreaseProductStockParams{
+		Quantity: change.Quantity,
+		ID:       uid,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to increase stock: %w", err)
+	}
+	if rows == 0 {
+		return nil, entity.ErrProductNotFound
+	}
+	err = qtx.IncreaseWarehouseStock(ctx, sqlc.IncreaseWarehouseStockParams{
+		WarehouseID: warehouse.ID,
+		ProductID:   uid,
+		Quantity:    change.Quantity,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to increase warehouse stock: %w", err)
+	}
+
+	err = recordMovement(ctx, qtx, entity.StockMovement{
+		ProductID:   productID,
+		WarehouseID: warehouseID,
+		Type:        change.Type,
+		StockDelta:  change.Quantity,
+		Reason:      change.Reason,
+		Actor:       change.Actor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The update above already holds the row lock, read the new stock level
+	row, err := qtx.GetProductForUpdate(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	// Backorders are served from the warehouse the stock arrived at
+	var available int32
+	if row.IsActive {
+		levels, err := qtx.GetWarehouseStockForUpdate(ctx, []uuid.UUID{uid})
+		if err != nil {
+			return nil, fmt.Errorf("failed to lock warehouse stock: %w", err)
+		}
+		for _, level := range levels {
+			if level.WarehouseID == warehouse.ID {
+				available = level.StockQuantity - level.ReservedStock
+			}
+		}
+	}
+
+	allocations := []entity.BackorderAllocation{}
+	if available > 0 {
+		backorders, err := qtx.GetPendingBackordersForUpdate(ctx, uid)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pending backorders: %w", err)
+		}
+
+		for _, backorder := range backorders {
+			if available == 0 {
+				break
+			}
+			allocated := min(backorder.Quantity, available)
+
+			err := reserveWarehouseStock(ctx, qtx, entity.StockMovement{
+				ProductID:     productID,
+				WarehouseID:   warehouseID,
+				Type:          entity.StockMovementReservation,
+				ReservedDelta: allocated,
+				Reason:        "backorder allocation",
+				OrderID:       backorder.OrderID.String(),
+				CorrelationID: backorder.CorrelationID.String(),
+				Actor:         entity.ActorSystem,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to reserve stock for backorder %s: %w", backorder.ID, err)
+			}
+			if err := qtx.AllocateBackorder(ctx, sqlc.AllocateBackorderParams{
+				Allocated: allocated,
+				ID:        backorder.ID,
+			}); err != nil {
+				return nil, fmt.Errorf("failed to allocate backorder %s: %w", backorder.ID, err)
+			}
+
+			available -= allocated
+			allocations = append(allocations, entity.BackorderAllocation{
+				BackorderID:       backorder.ID.String(),
+				OrderID:           backorder.OrderID.String(),
+				CorrelationID:     backorder.CorrelationID.String(),
+				ProductID:         productID,
+				WarehouseID:       warehouseID,
+				Quantity:          allocated,
+				RemainingQuantity: backorder.Quantity - allocated,
+			})
+		}
+	}
+
+	return allocations, nil
+}
+
+// RemoveStock takes stock out of a warehouse. Stock that is reserved for
+// orders cannot be removed.
+func (r *PostgresInventoryRepository) RemoveStock(ctx context.Context, change entity.StockChange) error {
+	if change.Quantity <= 0 {
+		return entity.ErrInvalidQuantity
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := removeStock(ctx, r.queries.WithTx(tx), change); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// removeStock takes unreserved stock out of a warehouse within the caller's transaction
+func removeStock(ctx context.Context, qtx *sqlc.Queries, change entity.StockChange) error {
+	uid, err := parseStringToUUID(change.ProductID)
+	if err != nil {
+		return entity.ErrProductNotFound
+	}
+
+	warehouse, err := resolveWarehouse(ctx, qtx, change.WarehouseID)
+	if err != nil {
+		return err
+	}
+
+	rows, err := qtx.DecreaseProductStock(ctx, sqlc.DecreaseProductStockParams{
+		Quantity: change.Quantity,
+		ID:       uid,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to decrease stock: %w", err)
+	}
+	if rows == 0 {
+		if _, err := qtx.GetProductByID(ctx, uid); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return entity.ErrProductNotFound
+			}
+			return err
+		}
+		return entity.ErrInsufficientStock
+	}
+	rows, err = qtx.DecreaseWarehouseStock(ctx, sqlc.DecreaseWarehouseStockParams{
+		Quantity:    change.Quantity,
+		WarehouseID: warehouse.ID,
+		ProductID:   uid,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to decrease warehouse stock: %w", err)
+	}
+	// The product has enough unreserved stock in total, but not in this warehouse
+	if rows == 0 {
+		return entity.ErrInsufficientStock
+	}
+
+	return recordMovement(ctx, qtx, entity.StockMovement{
+		ProductID:   change.ProductID,
+		WarehouseID: warehouse.ID.String(),
+		Type:        change.Type,
+		StockDelta:  -change.Quantity,
+		Reason:      change.Reason,
+		Actor:       change.Actor,
+	})
+}
+
+// GetStockLevels returns the product's stock per warehouse, preferred warehouses first
+func (r *PostgresInventoryRepository) GetStockLevels(ctx context.Context, productID string) ([]entity.WarehouseStock, error) {
+	uid, err := parseStringToUUID(productID)
+	if err != nil {
+		return nil, errors.New("invalid product ID format")
+	}
+
+	rows, err := r.queries.GetProductWarehouseStock(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make([]entity.WarehouseStock, len(rows))
+	for i, row := range rows {
+		levels[i] = entity.WarehouseStock{
+			WarehouseID:     row.WarehouseID.String(),
+			WarehouseCode:   row.WarehouseCode,
+			WarehouseName:   row.WarehouseName,
+			WarehouseActive: row.WarehouseActive,
+			ProductID:       productID,
+			StockQuantity:   row.StockQuantity,
+			ReservedStock:   row.ReservedStock,
+		}
+	}
+	return levels, nil
+}
+
+// GetVariants returns the variants of each of the parent products, keyed by parent ID
+func (r *PostgresInventoryRepository) GetVariants(ctx context.Context, parentIDs []string, includeInactive bool) (map[string][]*entity.Product, error) {
+	uids := make([]uuid.UUID, 0, len(parentIDs))
+	for _, id := range parentIDs {
+		uid, err := parseStringToUUID(id)
+		if err != nil {
+			continue
+		}
+		uids = append(uids, uid)
+	}
+
+	rows, err := r.queries.ListProductVariants(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	variants := make(map[string][]*entity.Product, len(parentIDs))
+	for _, row := range rows {
+		if !row.IsActive && !includeInactive {
+			continue
+		}
+		variant := r.rowToEntity(row)
+		variants[variant.ParentID] = append(variants[variant.ParentID], variant)
+	}
+	return variants, nil
+}
+
+// Search runs the page and facet queries over the same filters. Products with
+// variants are matched by their own price, their variants are not listed.
+func (r *PostgresInventoryRepository) Search(ctx context.Context, search entity.ProductSearch) (*entity.ProductSearchResult, error) {
+	filters := sqlc.SearchProductFacetsParams{
+		CategoryID: stringToNullUUID(search.CategoryID),
+		SearchText: sql.NullString{String: search.Text, Valid: search.Text != ""},
+		MinPrice:   priceToNullString(search.MinPrice),
+		MaxPrice:   priceToNullString(search.MaxPrice),
+		InStock:    search.InStock,
+		// Non-nil so that no attribute filters is an empty array rather than NULL
+		AttributeCodes:  []string{},
+		AttributeValues: []string{},
+		PriceBounds:     make([]string, len(entity.PriceRangeBounds)),
+	}
+	for code, value := range search.Attributes {
+		filters.AttributeCodes = append(filters.AttributeCodes, code)
+		filters.AttributeValues = append(filters.AttributeValues, value)
+	}
+	for i, bound := range entity.PriceRangeBounds {
+		filters.PriceBounds[i] = fmt.Sprintf("%.2f", bound)
+	}
+
+	rows, err := r.queries.SearchProducts(ctx, sqlc.SearchProductsParams{
+		CategoryID:      filters.CategoryID,
+		SearchText:      filters.SearchText,
+		MinPrice:        filters.MinPrice,
+		MaxPrice:        filters.MaxPrice,
+		InStock:         filters.InStock,
+		AttributeCodes:  filters.AttributeCodes,
+		AttributeValues: filters.AttributeValues,
+		Sort:            string(search.Sort),
+		LimitCount:      int32(search.Limit),
+		OffsetCount:     int32(search.Offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search products: %w", err)
+	}
+	facetRows, err := r.queries.SearchProductFacets(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count product facets: %w", err)
+	}
+
+	result := &entity.ProductSearchResult{
+		Products: make([]*entity.Product, len(rows)),
+	}
+	for i, row := range rows {
+		result.Products[i] = r.rowToEntity(row)
+	}
+	for _, row := range facetRows {
+		switch row.Facet {
+		case "total":
+			result.Total = row.ProductCount
+		case "category":
+			result.Facets.Categories = append(result.Facets.Categories, entity.CategoryFacet{
+				CategoryID: row.FacetValue,
+				Count:      row.ProductCount,
+			})
+		case "price":
+			// width_bucket numbers the ranges from 1, 0 would be below the first bound
+			bucket, err := strconv.Atoi(row.FacetValue)
+			if err != nil || bucket < 1 || bucket > len(entity.PriceRangeBounds) {
+				continue
+			}
+			facet := entity.PriceRangeFacet{Min: entity.PriceRangeBounds[bucket-1], Count: row.ProductCount}
+			if bucket < len(entity.PriceRangeBounds) {
+				upper := entity.PriceRangeBounds[bucket]
+				facet.Max = &upper
+			}
+			result.Facets.PriceRanges = append(result.Facets.PriceRanges, facet)
+		case "attribute":
+			result.Facets.Attributes = append(result.Facets.Attributes, entity.AttributeFacet{
+				Attribute: entity.Attribute{Code: row.FacetKey},
+				Value:     row.FacetValue,
+				Count:     row.ProductCount,
+			})
+		}
+	}
+	sort.Slice(result.Facets.PriceRanges, func(i, j int) bool {
+		return result.Facets.PriceRanges[i].Min < result.Facets.PriceRanges[j].Min
+	})
+	return result, nil
+}
+
+// GetStockLevelsByProducts returns the stock per warehouse of each product
+// that holds any, keyed by product ID
+func (r *PostgresInventoryRepository) GetStockLevelsByProducts(ctx context.Context, productIDs []string) (map[string][]entity.WarehouseStock, error) {
+	uids := make([]uuid.UUID, 0, len(productIDs))
+	for _, id := range productIDs {
+		uid, err := parseStringToUUID(id)
+		if err != nil {
+			continue
+		}
+		uids = append(uids, uid)
+	}
+
+	rows, err := r.queries.GetWarehouseStockByProducts(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make(map[string][]entity.WarehouseStock, len(productIDs))
+	for _, row := range rows {
+		productID := row.ProductID.String()
+		levels[productID] = append(levels[productID], entity.WarehouseStock{
+			WarehouseID:     row.WarehouseID.String(),
+			WarehouseCode:   row.WarehouseCode,
+			WarehouseName:   row.WarehouseName,
+			WarehouseActive: row.WarehouseActive,
+			ProductID:       productID,
+			StockQuantity:   row.StockQuantity,
+			ReservedStock:   row.ReservedStock,
+		})
+	}
+	return levels, nil
+}
+
+// ListAfter returns up to limit products ordered by ID, starting after afterID.
+// Variants are left out, they are read with GetVariants.
+// Paging by key keeps a full scan consistent while products are being added.
+func (r *PostgresInventoryRepository) ListAfter(ctx context.Context, afterID string, limit int) ([]*entity.Product, error) {
+	var after uuid.UUID
+	if afterID != "" {
+		uid, err := parseStringToUUID(afterID)
+		if err != nil {
+			return nil, errors.New("invalid product ID format")
+		}
+		after = uid
+	}
+
+	rows, err := r.queries.ListProductsAfter(ctx, sqlc.ListProductsAfterParams{
+		AfterID:    after,
+		LimitCount: int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]*entity.Product, len(rows))
+	for i, row := range rows {
+		products[i] = r.rowToEntity(row)
+	}
+
+	return products, nil
+}
+
+// ImportProducts upserts the rows in one transaction. Each row runs in its own
+// savepoint, so a row that fails is reported and skipped without affecting the
+// others. With dryRun set every row is applied and the transaction rolled back.
+func (r *PostgresInventoryRepository) ImportProducts(ctx context.Context, rows []entity.ProductImportRow, actor string, dryRun bool) ([]entity.ProductImportResult, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+	results := make([]entity.ProductImportResult, len(rows))
+	for i, row := range rows {
+		if _, err := tx.ExecContext(ctx, "SAVEPOINT import_row"); err != nil {
+			return nil, fmt.Errorf("failed to create savepoint: %w", err)
+		}
+
+		result, err := r.importProduct(ctx, qtx, row, actor)
+		if err != nil {
+			if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT import_row"); rbErr != nil {
+				return nil, fmt.Errorf("failed to roll back row %d: %w", row.Line, rbErr)
+			}
+			result = entity.ProductImportResult{
+				Line:      row.Line,
+				ProductID: row.ID,
+				Action:    entity.ProductImportFailed,
+				Err:       err,
+			}
+		} else if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT import_row"); err != nil {
+			return nil, fmt.Errorf("failed to release savepoint: %w", err)
+		}
+		results[i] = result
+	}
+
+	if dryRun {
+		return results, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return results, nil
+}
+
+// importProduct creates or updates a single product and sets its stock level
+// through the ledger, within the caller's transaction
+func (r *PostgresInventoryRepository) importProduct(ctx context.Context, qtx *sqlc.Queries, row entity.ProductImportRow, actor string) (entity.ProductImportResult, error) {
+	result := entity.ProductImportResult{Line: row.Line, ProductID: row.ID}
+	if result.ProductID == "" {
+		result.ProductID = uuid.New().String()
+	}
+	uid, err := parseStringToUUID(result.ProductID)
+	if err != nil {
+		return result, entity.ErrInvalidImportRow
+	}
+
+	existing, err := qtx.GetProductForUpdate(ctx, uid)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		product := &entity.Product{
+			ID:          result.ProductID,
+			Name:        row.Name,
+			Description: row.Description,
+			Price:       row.Price,
+			IsActive:    true,
+			ParentID:    row.ParentID,
+			SKU:         row.SKU,
+			Options:     row.Options,
+		}
+		if row.IsActive != nil {
+			product.IsActive = *row.IsActive
+		}
+		if row.ReorderThreshold != nil {
+			product.ReorderThreshold = *row.ReorderThreshold
+		}
+		if product.IsVariant() {
+			if err := checkVariantParent(ctx, qtx, product.ParentID); err != nil {
+				return result, err
+			}
+		}
+		err = qtx.CreateProduct(ctx, sqlc.CreateProductParams{
+			ID:               uid,
+			Name:             product.Name,
+			Description:      sql.NullString{String: product.Description, Valid: product.Description != ""},
+			Price:            fmt.Sprintf("%.2f", product.Price),
+			IsActive:         product.IsActive,
+			ReorderThreshold: product.ReorderThreshold,
+			StockStatus:      string(product.StockStatus()),
+			ParentID:         stringToNullUUID(product.ParentID),
+			Sku:              sql.NullString{String: product.SKU, Valid: product.SKU != ""},
+			Options:          optionsToJSON(product.Options),
+			CategoryID:       stringToNullUUID(product.CategoryID),
+		})
+		if err != nil {
+			return result, productWriteError(err)
+		}
+		err = recordPrice(ctx, qtx, uid, fmt.Sprintf("%.2f", product.Price), entity.PriceSourceImported, uuid.NullUUID{}, time.Now().UTC())
+		if err != nil {
+			return result, err
+		}
+		product.Version = 1
+		result.Action = entity.ProductImportCreated
+		result.Product = product
+	case err != nil:
+		return result, fmt.Errorf("failed to lock product: %w", err)
+	default:
+		product := r.rowToEntity(existing)
+		updated := *product
+		updated.Name = row.Name
+		updated.Description = row.Description
+		updated.Price = row.Price
+		if row.IsActive != nil {
+			updated.IsActive = *row.IsActive
+		}
+		if row.ReorderThreshold != nil {
+			updated.ReorderThreshold = *row.ReorderThreshold
+		}
+		// A product keeps its parent, the SKU and options only change when given
+		if row.SKU != "" {
+			updated.SKU = row.SKU
+		}
+		if row.Options != nil {
+			if !updated.IsVariant() {
+				return result, entity.ErrOptionsRequireParent
+			}
+			updated.Options = row.Options
+		}
+
+		// Compare what would be stored, prices are kept to the cent
+		params := toUpdateProductParams(uid, &updated)
+		result.Action = entity.ProductImportUnchanged
+		if !sameUpdateProductParams(params, toUpdateProductParams(uid, product)) {
+			if _, err := qtx.UpdateProduct(ctx, params); err != nil {
+				return result, productWriteError(err)
+			}
+			err = recordPrice(ctx, qtx, uid, params.Price, entity.PriceSourceImported, uuid.NullUUID{}, time.Now().UTC())
+			if err != nil {
+				return result, err
+			}
+			updated.Version++
+			result.Action = entity.ProductImportUpdated
+			result.Product = &updated
+			result.Previous = product
+		}
+	}
+
+	if row.StockQuantity == nil {
+		return result, nil
+	}
+
+	var warehouse sqlc.Warehouse
+	if row.WarehouseCode == "" {
+		warehouse, err = resolveWarehouse(ctx, qtx, "")
+	} else {
+		warehouse, err = qtx.GetWarehouseByCode(ctx, row.WarehouseCode)
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("%w: %s", entity.ErrWarehouseNotFound, row.WarehouseCode)
+		}
+	}
+	if err != nil {
+		return result, err
+	}
+
+	levels, err := qtx.GetProductWarehouseStock(ctx, uid)
+	if err != nil {
+		return result, fmt.Errorf("failed to get warehouse stock: %w", err)
+	}
+	var current int32
+	for _, level := range levels {
+		if level.WarehouseID == warehouse.ID {
+			current = level.StockQuantity
+		}
+	}
+
+	change := entity.StockChange{
+		ProductID:   result.ProductID,
+		WarehouseID: warehouse.ID.String(),
+		Actor:       actor,
+		Reason:      "bulk import",
+	}
+	switch delta := *row.StockQuantity - current; {
+	case delta > 0:
+		change.Type = entity.StockMovementReceipt
+		change.Quantity = delta
+		result.Allocations, err = receiveStock(ctx, qtx, change)
+	case delta < 0:
+		change.Type = entity.StockMovementAdjustment
+		change.Quantity = -delta
+		err = removeStock(ctx, qtx, change)
+	default:
+		return result, nil
+	}
+	if err != nil {
+		return result, err
+	}
+
+	result.StockChanged = true
+	if result.Action == entity.ProductImportUnchanged {
+		result.Action = entity.ProductImportUpdated
+	}
+	// A new product starts at the status of its imported stock instead of alerting on it
+	if result.Action == entity.ProductImportCreated {
+		created, err := qtx.GetProductForUpdate(ctx, uid)
+		if err != nil {
+			return result, fmt.Errorf("failed to get product: %w", err)
+		}
+		err = qtx.SetProductStockStatus(ctx, sqlc.SetProductStockStatusParams{
+			ID:          uid,
+			StockStatus: string(r.rowToEntity(created).StockStatus()),
+		})
+		if err != nil {
+			return result, fmt.Errorf("failed to set stock status: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// ReleaseOrderReservations returns everything still reserved for the order to
+// available stock and cancels its pending backorders
+func (r *PostgresInventoryRepository) ReleaseOrderReservations(ctx context.Context, orderID, correlationID, reason string) ([]entity.StockMovement, error) {
+	return r.settleOrderReservations(ctx, orderID, correlationID, entity.StockMovementRelease, reason)
+}
+
+// ConfirmOrderSale removes everything still reserved for the order from stock
+// and cancels its pending backorders, which will not ship with the order
+func (r *PostgresInventoryRepository) ConfirmOrderSale(ctx context.Context, orderID, correlationID string) ([]entity.StockMovement, error) {
+	return r.settleOrderReservations(ctx, orderID, correlationID, entity.StockMovementSale, "order completed")
+}
+
+// settleOrderReservations releases or sells the order's outstanding
+// reservations, which are derived from the ledger. Repeating it for the same
+// order is a no-op, so redelivered events are safe.
+func (r *PostgresInventoryRepository) settleOrderReservations(
+	ctx context.Context,
+	orderID, correlationID string,
+	movementType entity.StockMovementType,
+	reason string,
+) ([]entity.StockMovement, error) {
+	orderUUID, err := parseStringToUUID(orderID)
+	if err != nil {
+		return nil, errors.New("invalid order ID format")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+
+	// Cancel backorders first so arriving stock can no longer be allocated to the order
+	if _, err := qtx.CancelOrderBackorders(ctx, orderUUID); err != nil {
+		return nil, fmt.Errorf("failed to cancel backorders: %w", err)
+	}
+
+	// Lock the reserved products, then re-read the outstanding reservations so
+	// a concurrent settlement of the same order is seen after it commits
+	orderRef := uuid.NullUUID{UUID: orderUUID, Valid: true}
+	outstanding, err := qtx.GetOutstandingOrderReservations(ctx, orderRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order reservations: %w", err)
+	}
+	for _, row := range outstanding {
+		if _, err := qtx.GetProductForUpdate(ctx, row.ProductID); err != nil {
+			return nil, fmt.Errorf("failed to lock product %s: %w", row.ProductID, err)
+		}
+	}
+	outstanding, err = qtx.GetOutstandingOrderReservations(ctx, orderRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order reservations: %w", err)
+	}
+
+	movements := make([]entity.StockMovement, 0, len(outstanding))
+	for _, row := range outstanding {
+		movement := entity.StockMovement{
+			ProductID:     row.ProductID.String(),
+			WarehouseID:   nullUUIDToString(row.WarehouseID),
+			Type:          movementType,
+			ReservedDelta: -row.Reserved,
+			Reason:        reason,
+			OrderID:       orderID,
+			CorrelationID: correlationID,
+			Actor:         entity.ActorSystem,
+		}
+
+		var rows int64
+		if movementType == entity.StockMovementSale {
+			movement.StockDelta = -row.Reserved
+			rows, err = qtx.SellProductStock(ctx, sqlc.SellProductStockParams{Quantity: row.Reserved, ID: row.ProductID})
+		} else {
+			rows, err = qtx.ReleaseProductStock(ctx, sqlc.ReleaseProductStockParams{Quantity: row.Reserved, ID: row.ProductID})
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to settle product %s: %w", row.ProductID, err)
+		}
+		// The product holds less reserved stock than the ledger says it should
+		if rows == 0 {
+			return nil, fmt.Errorf("failed to settle product %s: %w", row.ProductID, entity.ErrCannotReleaseMoreThanReserved)
+		}
+
+		// Ledger entries from before warehouses existed have no warehouse to settle
+		if row.WarehouseID.Valid {
+			if movementType == entity.StockMovementSale {
+				rows, err = qtx.SellWarehouseStock(ctx, sqlc.SellWarehouseStockParams{
+					Quantity:    row.Reserved,
+					WarehouseID: row.WarehouseID.UUID,
+					ProductID:   row.ProductID,
+				})
+			} else {
+				rows, err = qtx.ReleaseWarehouseStock(ctx, sqlc.ReleaseWarehouseStockParams{
+					Quantity:    row.Reserved,
+					WarehouseID: row.WarehouseID.UUID,
+					ProductID:   row.ProductID,
+				})
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to settle product %s in warehouse %s: %w", row.ProductID, row.WarehouseID.UUID, err)
+			}
+			if rows == 0 {
+				return nil, fmt.Errorf("failed to settle product %s in warehouse %s: %w", row.ProductID, row.WarehouseID.UUID, entity.ErrCannotReleaseMoreThanReserved)
+			}
+		}
+
+		if err := recordMovement(ctx, qtx, movement); err != nil {
+			return nil, err
+		}
+		movements = append(movements, movement)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return movements, nil
+}
+
+// ListMovements returns a page of the product's ledger entries, newest first
+func (r *PostgresInventoryRepository) ListMovements(ctx context.Context, filter entity.StockMovementFilter) ([]*entity.StockMovement, error) {
+	uid, err := parseStringToUUID(filter.ProductID)
+	if err != nil {
+		return nil, errors.New("invalid product ID format")
+	}
+
+	rows, err := r.queries.ListStockMovements(ctx, sqlc.ListStockMovementsParams{
+		ProductID:   uid,
+		CreatedAt:   filter.From,
+		CreatedAt_2: filter.To,
+		Limit:       int32(filter.Limit),
+		Offset:      int32(filter.Offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	movements := make([]*entity.StockMovement, len(rows))
+	for i, row := range rows {
+		movements[i] = &entity.StockMovement{
+			ID:            row.ID.String(),
+			ProductID:     row.ProductID.String(),
+			WarehouseID:   nullUUIDToString(row.WarehouseID),
+			Type:          entity.StockMovementType(row.MovementType),
+			StockDelta:    row.StockDelta,
+			ReservedDelta: row.ReservedDelta,
+			Reason:        row.Reason,
+			OrderID:       nullUUIDToString(row.OrderID),
+			CorrelationID: nullUUIDToString(row.CorrelationID),
+			Actor:         row.Actor,
+			CreatedAt:     row.CreatedAt,
+		}
+	}
+	return movements, nil
+}
+
+// GetStockReconciliations compares every product's stock levels, in total and
+// per warehouse, with its ledger totals
+func (r *PostgresInventoryRepository) GetStockReconciliations(ctx context.Context) ([]entity.StockReconciliation, error) {
+	rows, err := r.queries.GetStockLedgerTotals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mismatchRows, err := r.queries.GetWarehouseStockMismatches(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	warehouseMismatches := make(map[uuid.UUID][]entity.WarehouseStockReconciliation)
+	for _, row := range mismatchRows {
+		warehouseMismatches[row.ProductID] = append(warehouseMismatches[row.ProductID], entity.WarehouseStockReconciliation{
+			WarehouseID:    row.WarehouseID.String(),
+			StockQuantity:  row.StockQuantity,
+			LedgerStock:    row.LedgerStock,
+			ReservedStock:  row.ReservedStock,
+			LedgerReserved: row.LedgerReserved,
+		})
+	}
+
+	reconciliations := make([]entity.StockReconciliation, len(rows))
+	for i, row := range rows {
+		reconciliations[i] = entity.StockReconciliation{
+			ProductID:           row.ID.String(),
+			ProductName:         row.Name,
+			StockQuantity:       row.StockQuantity,
+			LedgerStock:         row.LedgerStock,
+			ReservedStock:       row.ReservedStock,
+			LedgerReserved:      row.LedgerReserved,
+			WarehouseMismatches: warehouseMismatches[row.ID],
+		}
+	}
+	return reconciliations, nil
+}
+
+// RepairStockLevels overwrites the product's stock levels, in total and per
+// warehouse, with its ledger totals. The comparison is redone under the row lock so changes made since
+// the report was taken are not undone; the state found is returned.
+func (r *PostgresInventoryRepository) RepairStockLevels(ctx context.Context, productID string) (*entity.StockReconciliation, error) {
+	uid, err := parseStringToUUID(productID)
+	if err != nil {
+		return nil, entity.ErrProductNotFound
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+	product, err := qtx.GetProductForUpdate(ctx, uid)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to lock product: %w", err)
+	}
+	totals, err := qtx.GetProductLedgerTotals(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger totals: %w", err)
+	}
+	warehouseTotals, err := qtx.GetWarehouseLedgerTotals(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get warehouse ledger totals: %w", err)
+	}
+
+	found := &entity.StockReconciliation{
+		ProductID:      productID,
+		ProductName:    product.Name,
+		StockQuantity:  product.StockQuantity,
+		LedgerStock:    totals.LedgerStock,
+		ReservedStock:  product.ReservedStock,
+		LedgerReserved: totals.LedgerReserved,
+	}
+	for _, row := range warehouseTotals {
+		warehouse := entity.WarehouseStockReconciliation{
+			WarehouseID:    row.WarehouseID.String(),
+			StockQuantity:  row.StockQuantity,
+			LedgerStock:    row.LedgerStock,
+			ReservedStock:  row.ReservedStock,
+			LedgerReserved: row.LedgerReserved,
+		}
+		if warehouse.HasDrift() {
+			found.WarehouseMismatches = append(found.WarehouseMismatches, warehouse)
+		}
+	}
+	if !found.HasDrift() {
+		return found, nil
+	}
+
+	if found.StockDrift() != 0 || found.ReservedDrift() != 0 {
+		err = qtx.SetProductStockLevels(ctx, sqlc.SetProductStockLevelsParams{
+			ID:            uid,
+			StockQuantity: totals.LedgerStock,
+			ReservedStock: totals.LedgerReserved,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to set stock levels: %w", err)
+		}
+	}
+	for _, row := range warehouseTotals {
+		if row.StockQuantity == row.LedgerStock && row.ReservedStock == row.LedgerReserved {
+			continue
+		}
+		err = qtx.SetWarehouseStockLevels(ctx, sqlc.SetWarehouseStockLevelsParams{
+			WarehouseID:   row.WarehouseID,
+			ProductID:     uid,
+			StockQuantity: row.LedgerStock,
+			ReservedStock: row.LedgerReserved,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to set stock levels in warehouse %s: %w", row.WarehouseID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return found, nil
+}
+
+// ApplyScheduledPrice starts a due scheduled price by making it the product's
+// price, or ends a due one by restoring the price it replaced. The replaced
+// price is only restored if the price was not changed while the change ran.
+func (r *PostgresInventoryRepository) ApplyScheduledPrice(ctx context.Context, scheduleID string, now time.Time) (*entity.ScheduledPriceTransition, error) {
+	uid, err := parseStringToUUID(scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", entity.ErrScheduledPriceNotFound, scheduleID)
+	}
+	now = now.UTC()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+	row, err := qtx.GetScheduledPriceForUpdate(ctx, uid)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", entity.ErrScheduledPriceNotFound, scheduleID)
+		}
+		return nil, fmt.Errorf("failed to lock scheduled price: %w", err)
+	}
+
+	schedule := toScheduledPriceEntity(row)
+	starting := schedule.Status == entity.ScheduledPriceScheduled && !schedule.StartsAt.After(now)
+	ending := schedule.Status == entity.ScheduledPriceActive && schedule.EndsAt != nil && !schedule.EndsAt.After(now)
+	if !starting && !ending {
+		return nil, nil
+	}
+
+	current, err := qtx.GetProductForUpdate(ctx, row.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock product: %w", err)
+	}
+
+	status := entity.ScheduledPriceCompleted
+	previousPrice := row.PreviousPrice
+	var price string
+	if starting {
+		price = row.Price
+		// A change with an end keeps the price it replaces to restore it later
+		if schedule.EndsAt != nil {
+			status = entity.ScheduledPriceActive
+			previousPrice = sql.NullString{String: current.Price, Valid: true}
+		}
+	} else if current.Price == row.Price {
+		price = row.PreviousPrice.String
+	}
+
+	transition := &entity.ScheduledPriceTransition{}
+	if price != "" && price != current.Price {
+		err = qtx.SetProductPrice(ctx, sqlc.SetProductPriceParams{
+			ID:    row.ProductID,
+			Price: price,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to set product price: %w", err)
+		}
+		err = recordPrice(ctx, qtx, row.ProductID, price, entity.PriceSourceScheduled, uuid.NullUUID{UUID: uid, Valid: true}, now)
+		if err != nil {
+			return nil, err
+		}
+
+		previous := r.rowToEntity(current)
+		product := *previous
+		product.Price = parsePrice(price)
+		product.Version++
+		transition.Previous = previous
+		transition.Product = &product
+	}
+
+	err = qtx.UpdateScheduledPriceStatus(ctx, sqlc.UpdateScheduledPriceStatusParams{
+		ID:            uid,
+		Status:        string(status),
+		PreviousPrice: previousPrice,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update scheduled price: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	row.Status = string(status)
+	row.PreviousPrice = previousPrice
+	transition.Schedule = toScheduledPriceEntity(row)
+	return transition, nil
+}
+
+// UpdateStockStatuses compares each product's stock status with the status last
+// reported for it, stores the new status and returns the products whose status changed
+func (r *PostgresInventoryRepository) UpdateStockStatuses(ctx context.Context, productIDs []string) ([]entity.StockStatusChange, error) {
+	// Lock rows in a stable order to avoid deadlocks
+	ids := append([]string(nil), productIDs...)
+	sort.Strings(ids)
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+	var changes []entity.StockStatusChange
+	for i, id := range ids {
+		if i > 0 && ids[i-1] == id {
+			continue
+		}
+		uid, err := parseStringToUUID(id)
+		if err != nil {
+			continue
+		}
+		row, err := qtx.GetProductForUpdate(ctx, uid)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to lock product %s: %w", id, err)
+		}
+
+		product := r.rowToEntity(row)
+		status := product.StockStatus()
+		if string(status) == row.StockStatus {
+			continue
+		}
+		err = qtx.SetProductStockStatus(ctx, sqlc.SetProductStockStatusParams{
+			ID:          uid,
+			StockStatus: string(status),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to set stock status: %w", err)
+		}
+		changes = append(changes, entity.StockStatusChange{
+			ProductID:        product.ID,
+			ProductName:      product.Name,
+			PreviousStatus:   entity.StockStatus(row.StockStatus),
+			Status:           status,
+			AvailableStock:   product.AvailableStock(),
+			ReorderThreshold: product.ReorderThreshold,
+		})
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return changes, nil
+}
+
+// ListLowStock returns a page of active products at or below their reorder
+// threshold, furthest below it first
+func (r *PostgresInventoryRepository) ListLowStock(ctx context.Context, limit, offset int) ([]*entity.Product, error) {
+	rows, err := r.queries.ListLowStockProducts(ctx, sqlc.ListLowStockProductsParams{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]*entity.Product, len(rows))
+	for i, row := range rows {
+		products[i] = r.rowToEntity(row)
+	}
+
+	return products, nil
+}
+
+// CountLowStock returns the number of products ListLowStock pages through
+func (r *PostgresInventoryRepository) CountLowStock(ctx context.Context) (int64, error) {
+	return r.queries.CountLowStockProducts(ctx)
+}
+
+// recordMovement appends an entry to the stock ledger within the caller's transaction
+func recordMovement(ctx context.Context, qtx *sqlc.Queries, movement entity.StockMovement) error {
+	productUUID, err := parseStringToUUID(movement.ProductID)
+	if err != nil {
+		return errors.New("invalid product ID format")
+	}
+
+	err = qtx.CreateStockMovement(ctx, sqlc.CreateStockMovementParams{
+		ID:            uuid.New(),
+		ProductID:     productUUID,
+		MovementType:  string(movement.Type),
+		StockDelta:    movement.StockDelta,
+		ReservedDelta: movement.ReservedDelta,
+		Reason:        movement.Reason,
+		OrderID:       stringToNullUUID(movement.OrderID),
+		CorrelationID: stringToNullUUID(movement.CorrelationID),
+		Actor:         movement.Actor,
+		CreatedAt:     time.Now().UTC(),
+		WarehouseID:   stringToNullUUID(movement.WarehouseID),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record stock movement: %w", err)
+	}
+	return nil
+}
+
+// reservationFailureReason explains why an item could not be reserved in full
+func reservationFailureReason(products map[string]lockedProduct, productID string) error {
+	product, found := products[productID]
+	if !found {
+		return entity.ErrProductNotFound
+	}
+	if !product.IsActive {
+		return entity.ErrProductNotActive
+	}
+	if product.hasVariants {
+		return entity.ErrProductHasVariants
+	}
+	return entity.ErrInsufficientStock
+}
+
+// resolveWarehouse returns the warehouse stock is booked against; an empty ID
+// means the default warehouse
+func resolveWarehouse(ctx context.Context, qtx *sqlc.Queries, warehouseID string) (sqlc.Warehouse, error) {
+	if warehouseID == "" {
+		row, err := qtx.GetDefaultWarehouse(ctx)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return sqlc.Warehouse{}, entity.ErrNoActiveWarehouse
+			}
+			return sqlc.Warehouse{}, fmt.Errorf("failed to get default warehouse: %w", err)
+		}
+		return row, nil
+	}
+
+	uid, err := parseStringToUUID(warehouseID)
+	if err != nil {
+		return sqlc.Warehouse{}, fmt.Errorf("%w: %s", entity.ErrWarehouseNotFound, warehouseID)
+	}
+	row, err := qtx.GetWarehouseByID(ctx, uid)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sqlc.Warehouse{}, fmt.Errorf("%w: %s", entity.ErrWarehouseNotFound, warehouseID)
+		}
+		return sqlc.Warehouse{}, fmt.Errorf("failed to get warehouse: %w", err)
+	}
+	return row, nil
+}
+
+// toUpdateProductParams builds a conditional update against the version the product was read at
+func toUpdateProductParams(uid uuid.UUID, product *entity.Product) sqlc.UpdateProductParams {
+	return sqlc.UpdateProductParams{
+		ID:               uid,
+		Name:             product.Name,
+		Description:      sql.NullString{String: product.Description, Valid: product.Description != ""},
+		Price:            fmt.Sprintf("%.2f", product.Price),
+		StockQuantity:    product.StockQuantity,
+		ReservedStock:    product.ReservedStock,
+		IsActive:         product.IsActive,
+		ReorderThreshold: product.ReorderThreshold,
+		Version:          product.Version,
+		Sku:              sql.NullString{String: product.SKU, Valid: product.SKU != ""},
+		Options:          optionsToJSON(product.Options),
+		CategoryID:       stringToNullUUID(product.CategoryID),
+	}
+}
+
+// sameUpdateProductParams reports whether two updates would store the same product
+func sameUpdateProductParams(a, b sqlc.UpdateProductParams) bool {
+	return a.ID == b.ID &&
+		a.Name == b.Name &&
+		a.Description == b.Description &&
+		a.Price == b.Price &&
+		a.StockQuantity == b.StockQuantity &&
+		a.ReservedStock == b.ReservedStock &&
+		a.IsActive == b.IsActive &&
+		a.ReorderThreshold == b.ReorderThreshold &&
+		a.Version == b.Version &&
+		a.Sku == b.Sku &&
+		a.CategoryID == b.CategoryID &&
+		bytes.Equal(a.Options, b.Options)
+}
+
+// optionsToJSON encodes variant options for the jsonb column, no options as an empty object
+func optionsToJSON(options map[string]string) json.RawMessage {
+	if len(options) == 0 {
+		return json.RawMessage("{}")
+	}
+	data, _ := json.Marshal(options)
+	return data
+}
+
+// productWriteError translates the constraints on products into domain errors
+func productWriteError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	switch {
+	case pqErr.Code == uniqueViolation && pqErr.Constraint == "idx_products_sku":
+		return entity.ErrSKUTaken
+	case pqErr.Code == uniqueViolation && pqErr.Constraint == "idx_products_parent_options":
+		return entity.ErrDuplicateVariantOptions
+	case pqErr.Code == foreignKeyViolation && pqErr.Constraint == "products_category_id_fkey":
+		return entity.ErrCategoryNotFound
+	}
+	return err
+}
+
+// checkVariantParent locks the parent of a new variant and checks it can have
+// variants: it must exist, not be a variant itself and hold no stock
+func checkVariantParent(ctx context.Context, qtx *sqlc.Queries, parentID string) error {
+	uid, err := parseStringToUUID(parentID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", entity.ErrProductNotFound, parentID)
+	}
+	parent, err := qtx.GetProductForUpdate(ctx, uid)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: %s", entity.ErrProductNotFound, parentID)
+		}
+		return fmt.Errorf("failed to lock parent product: %w", err)
+	}
+	if parent.ParentID.Valid {
+		return entity.ErrVariantOfVariant
+	}
+	if parent.StockQuantity > 0 || parent.ReservedStock > 0 {
+		return entity.ErrParentHoldsStock
+	}
+	return nil
+}
+
+func (r *PostgresInventoryRepository) rowToEntity(row interface{}) *entity.Product {
+	var product entity.Product
+	// Type assertion to handle both single row and multiple rows
+	switch v := row.(type) {
+	case sqlc.Product:
+		product.ID = v.ID.String()
+		product.Name = v.Name
+		product.Description = v.Description.String
+		// Parse price from string to float64
+		_, _ = fmt.Sscanf(v.Price, "%f", &product.Price)
+		product.StockQuantity = v.StockQuantity
+		product.ReservedStock = v.ReservedStock
+		product.IsActive = v.IsActive
+		product.CreatedAt = v.CreatedAt
+		product.UpdatedAt = v.UpdatedAt
+		product.Version = v.Version
+		product.ReorderThreshold = v.ReorderThreshold
+		product.ParentID = nullUUIDToString(v.ParentID)
+		product.SKU = v.Sku.String
+		// Options are only written by optionsToJSON, an empty object stays nil
+		_ = json.Unmarshal(v.Options, &product.Options)
+		if len(product.Options) == 0 {
+			product.Options = nil
+		}
+		product.CategoryID = nullUUIDToString(v.CategoryID)
+	}
+
+	return &product
+}
+
+// priceToNullString formats an optional price for a numeric parameter
+func priceToNullString(price *float64) sql.NullString {
+	if price == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: fmt.Sprintf("%.2f", *price), Valid: true}
+}
+
+// parseStringToUUID converts string to uuid.UUID
+func parseStringToUUID(id string) (uuid.UUID, error) {
+	return uuid.Parse(id)
+}
+
+// stringToNullUUID converts an optional ID, empty or malformed IDs become NULL
+func stringToNullUUID(id string) uuid.NullUUID {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: uid, Valid: true}
+}
+
+// nullUUIDToString converts an optional ID, NULL becomes an empty string
+func nullUUIDToString(id uuid.NullUUID) string {
+	if !id.Valid {
+		return ""
+	}
+	return id.UUID.String()
 }
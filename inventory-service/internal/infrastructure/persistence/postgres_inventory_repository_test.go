@@ -0,0 +1,594 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+)
+
+// openTestDatabase connects to a migrated inventory database, e.g. the one
+// `make test-integration` starts:
+// INVENTORY_TEST_DATABASE_URL="host=localhost port=55434 user=postgres password=postgres dbname=inventorydb sslmode=disable"
+func openTestDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv("INVENTORY_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("INVENTORY_TEST_DATABASE_URL is not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	if err := db.Ping(); err != nil {
+		t.Fatalf("failed to ping database: %v", err)
+	}
+	db.SetMaxOpenConns(20)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// testPolicy allocates like the service does by default
+var testPolicy = entity.AllocationPolicy{Strategy: entity.AllocationSingleLocationFirst}
+
+func createTestProduct(t *testing.T, repo *PostgresInventoryRepository, stock int32) *entity.Product {
+	t.Helper()
+
+	product := &entity.Product{
+		ID:            uuid.New().String(),
+		Name:          "reservation test product",
+		Price:         9.99,
+		StockQuantity: stock,
+		IsActive:      true,
+	}
+	if err := repo.Create(context.Background(), product); err != nil {
+		t.Fatalf("failed to create product: %v", err)
+	}
+	t.Cleanup(func() {
+		repo.db.Exec("DELETE FROM stock_movements WHERE product_id = $1", product.ID)
+		repo.db.Exec("DELETE FROM warehouse_stock WHERE product_id = $1", product.ID)
+		repo.db.Exec("DELETE FROM backorders WHERE product_id = $1", product.ID)
+		repo.db.Exec("DELETE FROM products WHERE id = $1", product.ID)
+	})
+	return product
+}
+
+func TestReserveStockConcurrentReservationsDoNotOversell(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := NewPostgresInventoryRepository(db)
+	ctx := context.Background()
+
+	const stock = 10
+	const orders = 50
+	product := createTestProduct(t, repo, stock)
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+	)
+	for i := 0; i < orders; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := repo.ReserveStock(ctx, uuid.New().String(), uuid.New().String(),
+				[]entity.StockReservation{{ProductID: product.ID, Quantity: 1}}, testPolicy)
+			if err != nil {
+				if !errors.Is(err, entity.ErrInsufficientStock) {
+					t.Errorf("unexpected reservation error: %v", err)
+				}
+				return
+			}
+			mu.Lock()
+			succeeded++
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != stock {
+		t.Errorf("expected %d successful reservations, got %d", stock, succeeded)
+	}
+
+	reloaded, err := repo.GetByID(ctx, product.ID)
+	if err != nil {
+		t.Fatalf("failed to reload product: %v", err)
+	}
+	if reloaded.ReservedStock != stock {
+		t.Errorf("expected reserved stock %d, got %d", stock, reloaded.ReservedStock)
+	}
+	if reloaded.AvailableStock() != 0 {
+		t.Errorf("expected no available stock, got %d", reloaded.AvailableStock())
+	}
+}
+
+func TestReserveStockIsAllOrNothing(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := NewPostgresInventoryRepository(db)
+	ctx := context.Background()
+
+	plenty := createTestProduct(t, repo, 10)
+	scarce := createTestProduct(t, repo, 1)
+
+	_, err := repo.ReserveStock(ctx, uuid.New().String(), uuid.New().String(), []entity.StockReservation{
+		{ProductID: plenty.ID, Quantity: 5},
+		{ProductID: scarce.ID, Quantity: 2},
+	}, testPolicy)
+
+	var reservationErr *entity.StockReservationError
+	if !errors.As(err, &reservationErr) {
+		t.Fatalf("expected StockReservationError, got %v", err)
+	}
+	if reservationErr.ProductID != scarce.ID {
+		t.Errorf("expected failure for product %s, got %s", scarce.ID, reservationErr.ProductID)
+	}
+
+	reloaded, err := repo.GetByID(ctx, plenty.ID)
+	if err != nil {
+		t.Fatalf("failed to reload product: %v", err)
+	}
+	if reloaded.ReservedStock != 0 {
+		t.Errorf("expected reservation to be rolled back, reserved stock is %d", reloaded.ReservedStock)
+	}
+}
+
+func TestStockMovementsReconcileWithProduct(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := NewPostgresInventoryRepository(db)
+	ctx := context.Background()
+
+	product := createTestProduct(t, repo, 0)
+	received := entity.StockChange{
+		ProductID: product.ID,
+		Quantity:  10,
+		Type:      entity.StockMovementReceipt,
+		Actor:     entity.ActorAPI,
+		Reason:    "initial delivery",
+	}
+	if _, err := repo.ReceiveStock(ctx, received); err != nil {
+		t.Fatalf("failed to receive stock: %v", err)
+	}
+
+	orderID := uuid.New().String()
+	items := []entity.StockReservation{{ProductID: product.ID, Quantity: 4}}
+	if _, err := repo.ReserveStock(ctx, orderID, uuid.New().String(), items, testPolicy); err != nil {
+		t.Fatalf("failed to reserve stock: %v", err)
+	}
+	if _, err := repo.ReleaseOrderReservations(ctx, orderID, "", "test"); err != nil {
+		t.Fatalf("failed to release reservation: %v", err)
+	}
+	// Releasing again must not release the stock twice
+	if _, err := repo.ReleaseOrderReservations(ctx, orderID, "", "test"); err != nil {
+		t.Fatalf("failed to repeat release: %v", err)
+	}
+
+	movements, err := repo.ListMovements(ctx, entity.StockMovementFilter{
+		ProductID: product.ID,
+		From:      time.Now().UTC().Add(-time.Hour),
+		To:        time.Now().UTC().Add(time.Hour),
+		Limit:     100,
+	})
+	if err != nil {
+		t.Fatalf("failed to list movements: %v", err)
+	}
+	if len(movements) != 3 {
+		t.Fatalf("expected 3 movements, got %d", len(movements))
+	}
+
+	var stock, reserved int32
+	for _, movement := range movements {
+		stock += movement.StockDelta
+		reserved += movement.ReservedDelta
+	}
+
+	reloaded, err := repo.GetByID(ctx, product.ID)
+	if err != nil {
+		t.Fatalf("failed to reload product: %v", err)
+	}
+	if reloaded.StockQuantity != stock || reloaded.ReservedStock != reserved {
+		t.Errorf("ledger (stock %d, reserved %d) does not match product (stock %d, reserved %d)",
+			stock, reserved, reloaded.StockQuantity, reloaded.ReservedStock)
+	}
+}
+
+func createTestWarehouse(t *testing.T, db *sql.DB, priority int32) *entity.Warehouse {
+	t.Helper()
+
+	id := uuid.New().String()
+	warehouse := &entity.Warehouse{
+		ID:       id,
+		Code:     "test-" + id[:8],
+		Name:     "allocation test warehouse",
+		Priority: priority,
+		IsActive: true,
+	}
+	if err := NewPostgresWarehouseRepository(db).Create(context.Background(), warehouse); err != nil {
+		t.Fatalf("failed to create warehouse: %v", err)
+	}
+	// Registered before the product cleanups, so it runs after them
+	t.Cleanup(func() {
+		db.Exec("DELETE FROM warehouses WHERE id = $1", warehouse.ID)
+	})
+	return warehouse
+}
+
+func TestReserveStockSplitsAcrossWarehouses(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := NewPostgresInventoryRepository(db)
+	ctx := context.Background()
+
+	first := createTestWarehouse(t, db, 0)
+	second := createTestWarehouse(t, db, 1)
+	product := createTestProduct(t, repo, 0)
+	for _, warehouse := range []*entity.Warehouse{first, second} {
+		_, err := repo.ReceiveStock(ctx, entity.StockChange{
+			ProductID:   product.ID,
+			WarehouseID: warehouse.ID,
+			Quantity:    3,
+			Type:        entity.StockMovementReceipt,
+			Actor:       entity.ActorAPI,
+			Reason:      "delivery",
+		})
+		if err != nil {
+			t.Fatalf("failed to receive stock: %v", err)
+		}
+	}
+
+	outcomes, err := repo.ReserveStock(ctx, uuid.New().String(), uuid.New().String(),
+		[]entity.StockReservation{{ProductID: product.ID, Quantity: 5}}, testPolicy)
+	if err != nil {
+		t.Fatalf("failed to reserve stock: %v", err)
+	}
+
+	allocations := outcomes[0].Allocations
+	if len(allocations) != 2 || allocations[0].WarehouseID != first.ID || allocations[0].Quantity != 3 ||
+		allocations[1].WarehouseID != second.ID || allocations[1].Quantity != 2 {
+		t.Fatalf("expected 3 from the first and 2 from the second warehouse, got %+v", allocations)
+	}
+
+	levels, err := repo.GetStockLevels(ctx, product.ID)
+	if err != nil {
+		t.Fatalf("failed to get stock levels: %v", err)
+	}
+	var total int32
+	for _, level := range levels {
+		total += level.ReservedStock
+	}
+	reloaded, err := repo.GetByID(ctx, product.ID)
+	if err != nil {
+		t.Fatalf("failed to reload product: %v", err)
+	}
+	if total != 5 || reloaded.ReservedStock != 5 {
+		t.Errorf("expected 5 reserved in warehouses and product, got %d and %d", total, reloaded.ReservedStock)
+	}
+}
+
+func TestImportProductsSkipsFailedRowsAndHonoursDryRun(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := NewPostgresInventoryRepository(db)
+	ctx := context.Background()
+
+	product := createTestProduct(t, repo, 5)
+	if _, err := repo.ReserveStock(ctx, uuid.New().String(), uuid.New().String(),
+		[]entity.StockReservation{{ProductID: product.ID, Quantity: 4}}, testPolicy); err != nil {
+		t.Fatalf("failed to reserve stock: %v", err)
+	}
+
+	restock, tooLow := int32(20), int32(1)
+	rows := []entity.ProductImportRow{
+		{Line: 2, ID: product.ID, Name: "renamed", Price: 12, StockQuantity: &restock},
+		// 4 units are reserved, the stock cannot drop to 1
+		{Line: 3, ID: product.ID, Name: "renamed", Price: 12, StockQuantity: &tooLow},
+	}
+
+	for _, dryRun := range []bool{true, false} {
+		results, err := repo.ImportProducts(ctx, rows, entity.ActorAPI, dryRun)
+		if err != nil {
+			t.Fatalf("import failed: %v", err)
+		}
+		if results[0].Action != entity.ProductImportUpdated {
+			t.Errorf("dry run %v: expected the first row to be updated, got %s (%v)", dryRun, results[0].Action, results[0].Err)
+		}
+		if results[1].Action != entity.ProductImportFailed || !errors.Is(results[1].Err, entity.ErrInsufficientStock) {
+			t.Errorf("dry run %v: expected the second row to fail with insufficient stock, got %s (%v)", dryRun, results[1].Action, results[1].Err)
+		}
+
+		stored, err := repo.GetByID(ctx, product.ID)
+		if err != nil {
+			t.Fatalf("failed to get product: %v", err)
+		}
+		wantStock, wantName := int32(5), product.Name
+		if !dryRun {
+			wantStock, wantName = restock, "renamed"
+		}
+		if stored.StockQuantity != wantStock || stored.Name != wantName {
+			t.Errorf("dry run %v: expected stock %d and name %q, got %d and %q", dryRun, wantStock, wantName, stored.StockQuantity, stored.Name)
+		}
+	}
+}
+
+func TestSearchFiltersByCategoryTreeAndCountsFacets(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := NewPostgresInventoryRepository(db)
+	categories := NewPostgresCategoryRepository(db)
+	ctx := context.Background()
+
+	suffix := uuid.New().String()[:8]
+	kitchen := &entity.Category{ID: uuid.New().String(), Name: "Kitchen", Slug: "kitchen-" + suffix}
+	teaware := &entity.Category{ID: uuid.New().String(), ParentID: kitchen.ID, Name: "Teaware", Slug: "teaware-" + suffix}
+	for _, category := range []*entity.Category{kitchen, teaware} {
+		if err := categories.Create(ctx, category); err != nil {
+			t.Fatalf("failed to create category: %v", err)
+		}
+	}
+	t.Cleanup(func() {
+		db.Exec("UPDATE products SET category_id = NULL WHERE category_id IN ($1, $2)", kitchen.ID, teaware.ID)
+		db.Exec("DELETE FROM categories WHERE id = $1", teaware.ID)
+		db.Exec("DELETE FROM categories WHERE id = $1", kitchen.ID)
+	})
+
+	teapot := createTestProduct(t, repo, 3)
+	teapot.Name = "Porcelain teapot " + suffix
+	teapot.Price = 30
+	teapot.CategoryID = teaware.ID
+	kettle := createTestProduct(t, repo, 0)
+	kettle.Name = "Steel kettle " + suffix
+	kettle.Price = 60
+	kettle.CategoryID = kitchen.ID
+	for _, product := range []*entity.Product{teapot, kettle} {
+		if err := repo.Update(ctx, product); err != nil {
+			t.Fatalf("failed to update product: %v", err)
+		}
+	}
+
+	// The kitchen category also matches the teapot in its teaware subcategory
+	result, err := repo.Search(ctx, entity.ProductSearch{
+		CategoryID: kitchen.ID,
+		Sort:       entity.ProductSortPriceAsc,
+		Limit:      10,
+	})
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if result.Total != 2 || len(result.Products) != 2 || result.Products[0].ID != teapot.ID {
+		t.Fatalf("expected teapot then kettle, got %d products of %d", len(result.Products), result.Total)
+	}
+	if len(result.Facets.Categories) != 2 || len(result.Facets.PriceRanges) != 2 {
+		t.Errorf("expected two category and two price range facets, got %+v", result.Facets)
+	}
+
+	result, err = repo.Search(ctx, entity.ProductSearch{
+		Text:       "teapots " + suffix,
+		CategoryID: kitchen.ID,
+		InStock:    true,
+		Sort:       entity.ProductSortRelevance,
+		Limit:      10,
+	})
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if result.Total != 1 || result.Products[0].ID != teapot.ID {
+		t.Errorf("expected only the teapot to match, got %d products", result.Total)
+	}
+}
+
+func TestScheduledSaleRecordsPriceHistory(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := NewPostgresInventoryRepository(db)
+	prices := NewPostgresPriceRepository(db)
+	ctx := context.Background()
+
+	product := createTestProduct(t, repo, 0)
+	startsAt := time.Now().UTC().Add(time.Hour).Truncate(time.Microsecond)
+	endsAt := startsAt.Add(time.Hour)
+	sale := &entity.ScheduledPrice{
+		ID:        uuid.New().String(),
+		ProductID: product.ID,
+		Price:     4.99,
+		StartsAt:  startsAt,
+		EndsAt:    &endsAt,
+	}
+	if err := prices.CreateSchedule(ctx, sale); err != nil {
+		t.Fatalf("failed to schedule sale: %v", err)
+	}
+
+	overlapping := &entity.ScheduledPrice{ID: uuid.New().String(), ProductID: product.ID, Price: 5.99, StartsAt: startsAt.Add(time.Minute)}
+	if err := prices.CreateSchedule(ctx, overlapping); !errors.Is(err, entity.ErrScheduledPriceOverlap) {
+		t.Fatalf("expected ErrScheduledPriceOverlap, got %v", err)
+	}
+
+	started, err := repo.ApplyScheduledPrice(ctx, sale.ID, startsAt)
+	if err != nil {
+		t.Fatalf("failed to start sale: %v", err)
+	}
+	if started == nil || started.Product.Price != 4.99 || started.Schedule.Status != entity.ScheduledPriceActive {
+		t.Fatalf("expected the sale to start at 4.99, got %+v", started)
+	}
+	// Applying it again before its end is a no-op, as for a second replica
+	if again, err := repo.ApplyScheduledPrice(ctx, sale.ID, startsAt); err != nil || again != nil {
+		t.Fatalf("expected no transition, got %+v, %v", again, err)
+	}
+
+	ended, err := repo.ApplyScheduledPrice(ctx, sale.ID, endsAt)
+	if err != nil {
+		t.Fatalf("failed to end sale: %v", err)
+	}
+	if ended == nil || ended.Product.Price != 9.99 || ended.Schedule.Status != entity.ScheduledPriceCompleted {
+		t.Fatalf("expected the sale to end at 9.99, got %+v", ended)
+	}
+
+	for _, test := range []struct {
+		at    time.Time
+		price float64
+	}{
+		{startsAt.Add(-time.Second), 9.99},
+		{startsAt.Add(time.Second), 4.99},
+		{endsAt, 9.99},
+	} {
+		entry, err := prices.GetPriceAt(ctx, product.ID, test.at)
+		if err != nil {
+			t.Fatalf("failed to get price at %s: %v", test.at, err)
+		}
+		if entry.Price != test.price {
+			t.Errorf("price at %s = %.2f, want %.2f", test.at, entry.Price, test.price)
+		}
+	}
+}
+
+func TestUpdateStockStatusesReportsEachCrossingOnce(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := NewPostgresInventoryRepository(db)
+	ctx := context.Background()
+
+	product := createTestProduct(t, repo, 10)
+
+	changes, err := repo.UpdateStockStatuses(ctx, []string{product.ID})
+	if err != nil || len(changes) != 0 {
+		t.Fatalf("UpdateStockStatuses() in stock = %+v, %v, want no change", changes, err)
+	}
+
+	_, err = repo.ReserveStock(ctx, uuid.New().String(), uuid.New().String(),
+		[]entity.StockReservation{{ProductID: product.ID, Quantity: 10}}, testPolicy)
+	if err != nil {
+		t.Fatalf("failed to reserve stock: %v", err)
+	}
+
+	// Listed twice, still reported once
+	changes, err = repo.UpdateStockStatuses(ctx, []string{product.ID, product.ID})
+	if err != nil {
+		t.Fatalf("UpdateStockStatuses() error = %v", err)
+	}
+	if len(changes) != 1 || changes[0].PreviousStatus != entity.StockStatusInStock || changes[0].Status != entity.StockStatusOutOfStock {
+		t.Fatalf("UpdateStockStatuses() sold out = %+v, want one change from in_stock to out_of_stock", changes)
+	}
+
+	changes, err = repo.UpdateStockStatuses(ctx, []string{product.ID})
+	if err != nil || len(changes) != 0 {
+		t.Errorf("UpdateStockStatuses() still sold out = %+v, %v, want no change", changes, err)
+	}
+}
+
+// createTestVariant adds a variant to parent, removing it after the test when it was created
+func createTestVariant(t *testing.T, repo *PostgresInventoryRepository, parentID, sku string, options map[string]string, stock int32) (*entity.Product, error) {
+	t.Helper()
+
+	variant := &entity.Product{
+		ID:            uuid.New().String(),
+		Name:          "variant test product",
+		Price:         9.99,
+		StockQuantity: stock,
+		IsActive:      true,
+		ParentID:      parentID,
+		SKU:           sku,
+		Options:       options,
+	}
+	if err := repo.Create(context.Background(), variant); err != nil {
+		return nil, err
+	}
+	t.Cleanup(func() {
+		repo.db.Exec("DELETE FROM stock_movements WHERE product_id = $1", variant.ID)
+		repo.db.Exec("DELETE FROM warehouse_stock WHERE product_id = $1", variant.ID)
+		repo.db.Exec("DELETE FROM backorders WHERE product_id = $1", variant.ID)
+		repo.db.Exec("DELETE FROM products WHERE id = $1", variant.ID)
+	})
+	return variant, nil
+}
+
+func TestCreateVariantChecksParentAndUniqueness(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := NewPostgresInventoryRepository(db)
+	ctx := context.Background()
+
+	parent := createTestProduct(t, repo, 0)
+	otherParent := createTestProduct(t, repo, 0)
+	stocked := createTestProduct(t, repo, 3)
+	sku := "VARIANT-" + uuid.New().String()
+
+	large, err := createTestVariant(t, repo, parent.ID, sku, map[string]string{"size": "L"}, 5)
+	if err != nil {
+		t.Fatalf("failed to create variant: %v", err)
+	}
+	reloaded, err := repo.GetByID(ctx, large.ID)
+	if err != nil || reloaded.ParentID != parent.ID || reloaded.Options["size"] != "L" || reloaded.StockQuantity != 5 {
+		t.Fatalf("GetByID(variant) = %+v, %v, want a variant of %s with 5 in stock", reloaded, err, parent.ID)
+	}
+
+	tests := []struct {
+		name     string
+		parentID string
+		sku      string
+		options  map[string]string
+		wantErr  error
+	}{
+		{name: "same options under the same parent", parentID: parent.ID, options: map[string]string{"size": "L"}, wantErr: entity.ErrDuplicateVariantOptions},
+		{name: "SKU of another variant", parentID: parent.ID, sku: sku, options: map[string]string{"size": "M"}, wantErr: entity.ErrSKUTaken},
+		{name: "same options under another parent", parentID: otherParent.ID, options: map[string]string{"size": "L"}},
+		{name: "other options under the same parent", parentID: parent.ID, options: map[string]string{"size": "S"}},
+		{name: "parent holding stock", parentID: stocked.ID, options: map[string]string{"size": "L"}, wantErr: entity.ErrParentHoldsStock},
+		{name: "variant of a variant", parentID: large.ID, options: map[string]string{"colour": "red"}, wantErr: entity.ErrVariantOfVariant},
+		{name: "unknown parent", parentID: uuid.New().String(), options: map[string]string{"size": "L"}, wantErr: entity.ErrProductNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := createTestVariant(t, repo, tt.parentID, tt.sku, tt.options, 0); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReserveStockReservesVariantsNotTheirParent(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := NewPostgresInventoryRepository(db)
+	ctx := context.Background()
+
+	parent := createTestProduct(t, repo, 0)
+	large, err := createTestVariant(t, repo, parent.ID, "", map[string]string{"size": "L"}, 5)
+	if err != nil {
+		t.Fatalf("failed to create variant: %v", err)
+	}
+	small, err := createTestVariant(t, repo, parent.ID, "", map[string]string{"size": "S"}, 5)
+	if err != nil {
+		t.Fatalf("failed to create variant: %v", err)
+	}
+
+	allocations, err := repo.ReserveStock(ctx, uuid.New().String(), uuid.New().String(),
+		[]entity.StockReservation{{ProductID: large.ID, Quantity: 2}}, testPolicy)
+	if err != nil {
+		t.Fatalf("failed to reserve variant: %v", err)
+	}
+	for _, allocation := range allocations {
+		if allocation.ProductID != large.ID {
+			t.Errorf("allocated %+v, want only the reserved variant", allocation)
+		}
+	}
+
+	for _, check := range []struct {
+		product      *entity.Product
+		wantReserved int32
+	}{{large, 2}, {small, 0}, {parent, 0}} {
+		reloaded, err := repo.GetByID(ctx, check.product.ID)
+		if err != nil {
+			t.Fatalf("failed to reload product: %v", err)
+		}
+		if reloaded.ReservedStock != check.wantReserved {
+			t.Errorf("product %s reserved %d, want %d", reloaded.ID, reloaded.ReservedStock, check.wantReserved)
+		}
+	}
+
+	// The parent holds no stock of its own, orders have to name a variant
+	_, err = repo.ReserveStock(ctx, uuid.New().String(), uuid.New().String(),
+		[]entity.StockReservation{{ProductID: parent.ID, Quantity: 1}}, testPolicy)
+	if !errors.Is(err, entity.ErrProductHasVariants) {
+		t.Errorf("ReserveStock(parent) error = %v, want ErrProductHasVariants", err)
+	}
+}
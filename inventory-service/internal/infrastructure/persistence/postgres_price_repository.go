@@ -0,0 +1,300 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+	sqlc "github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/infrastructure/persistence/sqlc"
+)
+
+type PostgresPriceRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+func NewPostgresPriceRepository(db *sql.DB) repository.PriceRepository {
+	return &PostgresPriceRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *PostgresPriceRepository) GetPriceAt(ctx context.Context, productID string, at time.Time) (*entity.PriceHistoryEntry, error) {
+	uid, err := parseStringToUUID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", entity.ErrProductNotFound, productID)
+	}
+
+	row, err := r.queries.GetPriceAt(ctx, sqlc.GetPriceAtParams{
+		ProductID: uid,
+		At:        at.UTC(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrPriceNotFound
+		}
+		return nil, fmt.Errorf("could not get price: %w", err)
+	}
+
+	entry := toPriceHistoryEntry(row)
+	return &entry, nil
+}
+
+func (r *PostgresPriceRepository) ListHistory(ctx context.Context, productID string, limit, offset int) ([]entity.PriceHistoryEntry, error) {
+	uid, err := parseStringToUUID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", entity.ErrProductNotFound, productID)
+	}
+
+	rows, err := r.queries.ListPriceHistory(ctx, sqlc.ListPriceHistoryParams{
+		ProductID: uid,
+		Limit:     int32(limit),
+		Offset:    int32(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list price history: %w", err)
+	}
+
+	entries := make([]entity.PriceHistoryEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = toPriceHistoryEntry(row)
+	}
+	return entries, nil
+}
+
+func (r *PostgresPriceRepository) CountHistory(ctx context.Context, productID string) (int64, error) {
+	uid, err := parseStringToUUID(productID)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", entity.ErrProductNotFound, productID)
+	}
+	return r.queries.CountPriceHistory(ctx, uid)
+}
+
+// CreateSchedule locks the product while checking for overlaps, so two
+// overlapping changes created at the same time cannot both be accepted
+func (r *PostgresPriceRepository) CreateSchedule(ctx context.Context, schedule *entity.ScheduledPrice) error {
+	uid, err := parseStringToUUID(schedule.ID)
+	if err != nil {
+		return errors.New("invalid scheduled price ID format")
+	}
+	productUID, err := parseStringToUUID(schedule.ProductID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", entity.ErrProductNotFound, schedule.ProductID)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+	if _, err := qtx.GetProductForUpdate(ctx, productUID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: %s", entity.ErrProductNotFound, schedule.ProductID)
+		}
+		return fmt.Errorf("failed to lock product: %w", err)
+	}
+
+	pending, err := qtx.ListPendingScheduledPrices(ctx, productUID)
+	if err != nil {
+		return fmt.Errorf("failed to list scheduled prices: %w", err)
+	}
+	for _, row := range pending {
+		other := toScheduledPriceEntity(row)
+		if schedule.Overlaps(&other) {
+			return fmt.Errorf("%w: %s", entity.ErrScheduledPriceOverlap, other.ID)
+		}
+	}
+
+	err = qtx.CreateScheduledPrice(ctx, sqlc.CreateScheduledPriceParams{
+		ID:        uid,
+		ProductID: productUID,
+		Price:     fmt.Sprintf("%.2f", schedule.Price),
+		StartsAt:  schedule.StartsAt.UTC(),
+		EndsAt:    timeToNullTime(schedule.EndsAt),
+		Status:    string(entity.ScheduledPriceScheduled),
+		Reason:    schedule.Reason,
+	})
+	if err != nil {
+		return fmt.Errorf("could not create scheduled price: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	schedule.Status = entity.ScheduledPriceScheduled
+	return nil
+}
+
+func (r *PostgresPriceRepository) ListSchedules(ctx context.Context, productID string) ([]entity.ScheduledPrice, error) {
+	uid, err := parseStringToUUID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", entity.ErrProductNotFound, productID)
+	}
+
+	rows, err := r.queries.ListScheduledPrices(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("could not list scheduled prices: %w", err)
+	}
+
+	schedules := make([]entity.ScheduledPrice, len(rows))
+	for i, row := range rows {
+		schedules[i] = toScheduledPriceEntity(row)
+	}
+	return schedules, nil
+}
+
+func (r *PostgresPriceRepository) CancelSchedule(ctx context.Context, productID, scheduleID string) (*entity.ScheduledPrice, error) {
+	uid, err := parseStringToUUID(scheduleID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", entity.ErrScheduledPriceNotFound, scheduleID)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+	row, err := qtx.GetScheduledPriceForUpdate(ctx, uid)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", entity.ErrScheduledPriceNotFound, scheduleID)
+		}
+		return nil, fmt.Errorf("failed to lock scheduled price: %w", err)
+	}
+	// A change is only found under the product it was scheduled for
+	if row.ProductID.String() != productID {
+		return nil, fmt.Errorf("%w: %s", entity.ErrScheduledPriceNotFound, scheduleID)
+	}
+
+	schedule := toScheduledPriceEntity(row)
+	if schedule.Status != entity.ScheduledPriceScheduled {
+		return nil, entity.ErrScheduledPriceNotPending
+	}
+
+	err = qtx.UpdateScheduledPriceStatus(ctx, sqlc.UpdateScheduledPriceStatusParams{
+		ID:     uid,
+		Status: string(entity.ScheduledPriceCancelled),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not cancel scheduled price: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	schedule.Status = entity.ScheduledPriceCancelled
+	return &schedule, nil
+}
+
+func (r *PostgresPriceRepository) ListDueSchedules(ctx context.Context, now time.Time, limit int) ([]entity.ScheduledPrice, error) {
+	rows, err := r.queries.ListDueScheduledPrices(ctx, sqlc.ListDueScheduledPricesParams{
+		Now:        now.UTC(),
+		LimitCount: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not list due scheduled prices: %w", err)
+	}
+
+	schedules := make([]entity.ScheduledPrice, len(rows))
+	for i, row := range rows {
+		schedules[i] = toScheduledPriceEntity(row)
+	}
+	return schedules, nil
+}
+
+// recordPrice makes the given price the product's current price in its price
+// history, within the caller's transaction. It does nothing if the price did
+// not change, so it can be called after every product write.
+func recordPrice(ctx context.Context, qtx *sqlc.Queries, productID uuid.UUID, price string, source entity.PriceSource, scheduleID uuid.NullUUID, at time.Time) error {
+	current, err := qtx.GetOpenPriceHistory(ctx, productID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return fmt.Errorf("failed to get current price: %w", err)
+	case current.Price == price:
+		return nil
+	default:
+		err = qtx.ClosePriceHistory(ctx, sqlc.ClosePriceHistoryParams{
+			ProductID:   productID,
+			EffectiveTo: sql.NullTime{Time: at, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to close price history: %w", err)
+		}
+	}
+
+	err = qtx.CreatePriceHistory(ctx, sqlc.CreatePriceHistoryParams{
+		ID:            uuid.New(),
+		ProductID:     productID,
+		Price:         price,
+		EffectiveFrom: at,
+		Source:        string(source),
+		ScheduleID:    scheduleID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record price: %w", err)
+	}
+	return nil
+}
+
+func toPriceHistoryEntry(row sqlc.ProductPriceHistory) entity.PriceHistoryEntry {
+	entry := entity.PriceHistoryEntry{
+		ID:            row.ID.String(),
+		ProductID:     row.ProductID.String(),
+		Price:         parsePrice(row.Price),
+		EffectiveFrom: row.EffectiveFrom,
+		Source:        entity.PriceSource(row.Source),
+		ScheduleID:    nullUUIDToString(row.ScheduleID),
+	}
+	if row.EffectiveTo.Valid {
+		entry.EffectiveTo = &row.EffectiveTo.Time
+	}
+	return entry
+}
+
+func toScheduledPriceEntity(row sqlc.ScheduledPrice) entity.ScheduledPrice {
+	schedule := entity.ScheduledPrice{
+		ID:        row.ID.String(),
+		ProductID: row.ProductID.String(),
+		Price:     parsePrice(row.Price),
+		StartsAt:  row.StartsAt,
+		Status:    entity.ScheduledPriceStatus(row.Status),
+		Reason:    row.Reason,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+	if row.EndsAt.Valid {
+		schedule.EndsAt = &row.EndsAt.Time
+	}
+	if row.PreviousPrice.Valid {
+		previous := parsePrice(row.PreviousPrice.String)
+		schedule.PreviousPrice = &previous
+	}
+	return schedule
+}
+
+// parsePrice reads a numeric column, which is always a valid decimal
+func parsePrice(price string) float64 {
+	value, _ := strconv.ParseFloat(price, 64)
+	return value
+}
+
+func timeToNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: t.UTC(), Valid: true}
+}
@@ -0,0 +1,139 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+	sqlc "github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/infrastructure/persistence/sqlc"
+)
+
+// PostgreSQL error codes for violated constraints
+const (
+	uniqueViolation     = "23505"
+	foreignKeyViolation = "23503"
+)
+
+type PostgresWarehouseRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewPostgresWarehouseRepository(db *sql.DB) repository.WarehouseRepository {
+	return &PostgresWarehouseRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (r *PostgresWarehouseRepository) Create(ctx context.Context, warehouse *entity.Warehouse) error {
+	uid, err := parseStringToUUID(warehouse.ID)
+	if err != nil {
+		return errors.New("invalid warehouse ID format")
+	}
+
+	latitude, longitude := locationToNullFloats(warehouse.Location)
+	err = r.queries.CreateWarehouse(ctx, sqlc.CreateWarehouseParams{
+		ID:        uid,
+		Code:      warehouse.Code,
+		Name:      warehouse.Name,
+		Latitude:  latitude,
+		Longitude: longitude,
+		Priority:  warehouse.Priority,
+		IsActive:  warehouse.IsActive,
+	})
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return entity.ErrWarehouseCodeTaken
+		}
+		return fmt.Errorf("could not create warehouse: %w", err)
+	}
+	return nil
+}
+
+func (r *PostgresWarehouseRepository) GetByID(ctx context.Context, id string) (*entity.Warehouse, error) {
+	uid, err := parseStringToUUID(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", entity.ErrWarehouseNotFound, id)
+	}
+
+	row, err := r.queries.GetWarehouseByID(ctx, uid)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", entity.ErrWarehouseNotFound, id)
+		}
+		return nil, fmt.Errorf("could not get warehouse: %w", err)
+	}
+
+	warehouse := toWarehouseEntity(row)
+	return &warehouse, nil
+}
+
+func (r *PostgresWarehouseRepository) List(ctx context.Context) ([]*entity.Warehouse, error) {
+	rows, err := r.queries.ListWarehouses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("could not list warehouses: %w", err)
+	}
+
+	warehouses := make([]*entity.Warehouse, len(rows))
+	for i, row := range rows {
+		warehouse := toWarehouseEntity(row)
+		warehouses[i] = &warehouse
+	}
+	return warehouses, nil
+}
+
+func (r *PostgresWarehouseRepository) Update(ctx context.Context, warehouse *entity.Warehouse) error {
+	uid, err := parseStringToUUID(warehouse.ID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", entity.ErrWarehouseNotFound, warehouse.ID)
+	}
+
+	latitude, longitude := locationToNullFloats(warehouse.Location)
+	rows, err := r.queries.UpdateWarehouse(ctx, sqlc.UpdateWarehouseParams{
+		ID:        uid,
+		Name:      warehouse.Name,
+		Latitude:  latitude,
+		Longitude: longitude,
+		Priority:  warehouse.Priority,
+		IsActive:  warehouse.IsActive,
+	})
+	if err != nil {
+		return fmt.Errorf("could not update warehouse: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("%w: %s", entity.ErrWarehouseNotFound, warehouse.ID)
+	}
+	return nil
+}
+
+func toWarehouseEntity(row sqlc.Warehouse) entity.Warehouse {
+	warehouse := entity.Warehouse{
+		ID:        row.ID.String(),
+		Code:      row.Code,
+		Name:      row.Name,
+		Priority:  row.Priority,
+		IsActive:  row.IsActive,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+	if row.Latitude.Valid && row.Longitude.Valid {
+		warehouse.Location = &entity.Location{
+			Latitude:  row.Latitude.Float64,
+			Longitude: row.Longitude.Float64,
+		}
+	}
+	return warehouse
+}
+
+// locationToNullFloats converts an optional location, nil becomes NULL coordinates
+func locationToNullFloats(location *entity.Location) (sql.NullFloat64, sql.NullFloat64) {
+	if location == nil {
+		return sql.NullFloat64{}, sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: location.Latitude, Valid: true},
+		sql.NullFloat64{Float64: location.Longitude, Valid: true}
+}
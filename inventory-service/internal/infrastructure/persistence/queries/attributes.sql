@@ -0,0 +1,29 @@
+-- name: CreateAttribute :exec
+INSERT INTO attributes (
+    id, code, name, type
+) VALUES (
+             $1, $2, $3, $4
+         );
+
+-- name: ListAttributes :many
+SELECT id, code, name, type, created_at
+FROM attributes
+ORDER BY code ASC;
+
+-- name: ListProductAttributes :many
+SELECT a.id, a.code, a.name, a.type, a.created_at, pa.value
+FROM product_attributes pa
+         JOIN attributes a ON a.id = pa.attribute_id
+WHERE pa.product_id = $1
+ORDER BY a.code ASC;
+
+-- name: DeleteProductAttributes :exec
+DELETE FROM product_attributes
+WHERE product_id = $1;
+
+-- name: CreateProductAttribute :exec
+INSERT INTO product_attributes (
+    product_id, attribute_id, value
+) VALUES (
+             $1, $2, $3
+         );
@@ -0,0 +1,24 @@
+-- name: CreateBackorder :exec
+INSERT INTO backorders (
+    id, order_id, correlation_id, product_id, quantity, status
+) VALUES (
+             $1, $2, $3, $4, $5, $6
+         );
+
+-- name: GetPendingBackordersForUpdate :many
+SELECT id, order_id, correlation_id, product_id, quantity, status, created_at, updated_at
+FROM backorders
+WHERE product_id = $1 AND status = 'pending'
+ORDER BY created_at ASC
+FOR UPDATE;
+
+-- name: AllocateBackorder :exec
+UPDATE backorders
+SET quantity = quantity - sqlc.arg(allocated)::int,
+    status = CASE WHEN quantity - sqlc.arg(allocated)::int = 0 THEN 'fulfilled' ELSE status END
+WHERE id = sqlc.arg(id);
+
+-- name: CancelOrderBackorders :execrows
+UPDATE backorders
+SET status = 'cancelled'
+WHERE order_id = $1 AND status = 'pending';
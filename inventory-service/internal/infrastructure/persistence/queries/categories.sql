@@ -0,0 +1,35 @@
+-- name: CreateCategory :exec
+INSERT INTO categories (
+    id, parent_id, name, slug
+) VALUES (
+             $1, $2, $3, $4
+         );
+
+-- name: GetCategoryByID :one
+SELECT id, parent_id, name, slug, created_at, updated_at
+FROM categories
+WHERE id = $1;
+
+-- name: ListCategories :many
+SELECT id, parent_id, name, slug, created_at, updated_at
+FROM categories
+ORDER BY name ASC;
+
+-- name: ListCategoryAncestorIDs :many
+WITH RECURSIVE ancestors AS (
+    SELECT c.id, c.parent_id
+    FROM categories c
+    WHERE c.id = $1
+    UNION ALL
+    SELECT c.id, c.parent_id
+    FROM categories c
+             JOIN ancestors a ON c.id = a.parent_id
+)
+SELECT id FROM ancestors;
+
+-- name: UpdateCategory :execrows
+UPDATE categories
+SET parent_id = $2,
+    name = $3,
+    slug = $4
+WHERE id = $1;
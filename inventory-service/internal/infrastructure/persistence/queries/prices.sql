@@ -0,0 +1,81 @@
+-- name: CreatePriceHistory :exec
+INSERT INTO product_price_history (
+    id, product_id, price, effective_from, source, schedule_id
+) VALUES (
+             $1, $2, $3, $4, $5, $6
+         );
+
+-- name: GetOpenPriceHistory :one
+SELECT id, product_id, price, effective_from, effective_to, source, schedule_id
+FROM product_price_history
+WHERE product_id = $1 AND effective_to IS NULL;
+
+-- name: ClosePriceHistory :exec
+UPDATE product_price_history
+SET effective_to = $2
+WHERE product_id = $1 AND effective_to IS NULL;
+
+-- name: GetPriceAt :one
+SELECT id, product_id, price, effective_from, effective_to, source, schedule_id
+FROM product_price_history
+WHERE product_id = sqlc.arg(product_id)
+  AND effective_from <= sqlc.arg(at)::timestamp
+  AND (effective_to IS NULL OR effective_to > sqlc.arg(at)::timestamp)
+ORDER BY effective_from DESC
+    LIMIT 1;
+
+-- name: ListPriceHistory :many
+SELECT id, product_id, price, effective_from, effective_to, source, schedule_id
+FROM product_price_history
+WHERE product_id = $1
+ORDER BY effective_from DESC, id
+    LIMIT $2 OFFSET $3;
+
+-- name: CountPriceHistory :one
+SELECT COUNT(*) FROM product_price_history
+WHERE product_id = $1;
+
+-- name: SetProductPrice :exec
+UPDATE products
+SET price = $2,
+    version = version + 1
+WHERE id = $1;
+
+-- name: CreateScheduledPrice :exec
+INSERT INTO scheduled_prices (
+    id, product_id, price, starts_at, ends_at, status, reason
+) VALUES (
+             $1, $2, $3, $4, $5, $6, $7
+         );
+
+-- name: GetScheduledPriceForUpdate :one
+SELECT id, product_id, price, starts_at, ends_at, previous_price, status, reason, created_at, updated_at
+FROM scheduled_prices
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListScheduledPrices :many
+SELECT id, product_id, price, starts_at, ends_at, previous_price, status, reason, created_at, updated_at
+FROM scheduled_prices
+WHERE product_id = $1
+ORDER BY starts_at, created_at;
+
+-- name: ListPendingScheduledPrices :many
+SELECT id, product_id, price, starts_at, ends_at, previous_price, status, reason, created_at, updated_at
+FROM scheduled_prices
+WHERE product_id = $1 AND status IN ('scheduled', 'active')
+ORDER BY starts_at;
+
+-- name: ListDueScheduledPrices :many
+SELECT id, product_id, price, starts_at, ends_at, previous_price, status, reason, created_at, updated_at
+FROM scheduled_prices
+WHERE (status = 'scheduled' AND starts_at <= sqlc.arg(now)::timestamp)
+   OR (status = 'active' AND ends_at <= sqlc.arg(now)::timestamp)
+ORDER BY CASE WHEN status = 'active' THEN ends_at ELSE starts_at END, id
+    LIMIT sqlc.arg(limit_count);
+
+-- name: UpdateScheduledPriceStatus :exec
+UPDATE scheduled_prices
+SET status = $2,
+    previous_price = $3
+WHERE id = $1;
@@ -1,48 +1,169 @@
 -- name: CreateProduct :exec
 INSERT INTO products (
-    id, name, description, price, stock_quantity, reserved_stock, is_active
+    id, name, description, price, stock_quantity, reserved_stock, is_active,
+    reorder_threshold, stock_status, parent_id, sku, options, category_id
 ) VALUES (
-             $1, $2, $3, $4, $5, $6, $7
+             $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
          );
 
 -- name: GetProductByID :one
 SELECT id, name, description, price, stock_quantity, reserved_stock,
-       is_active, created_at, updated_at
+       is_active, created_at, updated_at, version, reorder_threshold, stock_status,
+       parent_id, sku, options, category_id
 FROM products
 WHERE id = $1;
 
--- name: UpdateProduct :exec
+-- name: UpdateProduct :execrows
 UPDATE products
 SET name = $2,
     description = $3,
     price = $4,
     stock_quantity = $5,
     reserved_stock = $6,
-    is_active = $7
-WHERE id = $1;
+    is_active = $7,
+    reorder_threshold = $8,
+    sku = $10,
+    options = $11,
+    category_id = $12,
+    version = version + 1
+WHERE id = $1 AND version = $9;
 
 -- name: DeleteProduct :exec
 UPDATE products
-SET is_active = false
+SET is_active = false, version = version + 1
 WHERE id = $1;
 
 -- name: ListProducts :many
 SELECT id, name, description, price, stock_quantity, reserved_stock,
-       is_active, created_at, updated_at
+       is_active, created_at, updated_at, version, reorder_threshold, stock_status,
+       parent_id, sku, options, category_id
 FROM products
-WHERE is_active = true
+WHERE is_active = true AND parent_id IS NULL
 ORDER BY created_at DESC
     LIMIT $1 OFFSET $2;
 
 -- name: GetActiveProducts :many
 SELECT id, name, description, price, stock_quantity, reserved_stock,
-       is_active, created_at, updated_at
+       is_active, created_at, updated_at, version, reorder_threshold, stock_status,
+       parent_id, sku, options, category_id
 FROM products
 WHERE is_active = true
 ORDER BY name ASC;
 
 -- name: GetProductsByIDs :many
 SELECT id, name, description, price, stock_quantity, reserved_stock,
-       is_active, created_at, updated_at
+       is_active, created_at, updated_at, version, reorder_threshold, stock_status,
+       parent_id, sku, options, category_id
+FROM products
+WHERE id = ANY($1::uuid[]);
+
+-- name: ReserveProductStock :execrows
+UPDATE products
+SET reserved_stock = reserved_stock + sqlc.arg(quantity)::int,
+    version = version + 1
+WHERE id = sqlc.arg(id)
+  AND is_active = true
+  AND stock_quantity - reserved_stock >= sqlc.arg(quantity)::int;
+
+-- name: GetProductForUpdate :one
+SELECT id, name, description, price, stock_quantity, reserved_stock,
+       is_active, created_at, updated_at, version, reorder_threshold, stock_status,
+       parent_id, sku, options, category_id
+FROM products
+WHERE id = $1
+FOR UPDATE;
+
+-- name: IncreaseProductStock :execrows
+UPDATE products
+SET stock_quantity = stock_quantity + sqlc.arg(quantity)::int,
+    version = version + 1
+WHERE id = sqlc.arg(id);
+
+-- name: DecreaseProductStock :execrows
+UPDATE products
+SET stock_quantity = stock_quantity - sqlc.arg(quantity)::int,
+    version = version + 1
+WHERE id = sqlc.arg(id)
+  AND stock_quantity - reserved_stock >= sqlc.arg(quantity)::int;
+
+-- name: ListAllProducts :many
+SELECT id, name, description, price, stock_quantity, reserved_stock,
+       is_active, created_at, updated_at, version, reorder_threshold, stock_status,
+       parent_id, sku, options, category_id
+FROM products
+WHERE parent_id IS NULL
+ORDER BY created_at DESC
+    LIMIT $1 OFFSET $2;
+
+-- name: ListProductsAfter :many
+SELECT id, name, description, price, stock_quantity, reserved_stock,
+       is_active, created_at, updated_at, version, reorder_threshold, stock_status,
+       parent_id, sku, options, category_id
+FROM products
+WHERE id > sqlc.arg(after_id) AND parent_id IS NULL
+ORDER BY id ASC
+    LIMIT sqlc.arg(limit_count);
+
+-- name: CountProducts :one
+SELECT COUNT(*) FROM products
+WHERE is_active = true AND parent_id IS NULL;
+
+-- name: CountAllProducts :one
+SELECT COUNT(*) FROM products
+WHERE parent_id IS NULL;
+
+-- name: ReleaseProductStock :execrows
+UPDATE products
+SET reserved_stock = reserved_stock - sqlc.arg(quantity)::int,
+    version = version + 1
+WHERE id = sqlc.arg(id)
+  AND reserved_stock >= sqlc.arg(quantity)::int;
+
+-- name: SellProductStock :execrows
+UPDATE products
+SET stock_quantity = stock_quantity - sqlc.arg(quantity)::int,
+    reserved_stock = reserved_stock - sqlc.arg(quantity)::int,
+    version = version + 1
+WHERE id = sqlc.arg(id)
+  AND reserved_stock >= sqlc.arg(quantity)::int;
+
+-- name: SetProductStockLevels :exec
+UPDATE products
+SET stock_quantity = $2,
+    reserved_stock = $3,
+    version = version + 1
+WHERE id = $1;
+
+-- name: SetProductStockStatus :exec
+UPDATE products
+SET stock_status = $2
+WHERE id = $1;
+
+-- name: ListLowStockProducts :many
+SELECT id, name, description, price, stock_quantity, reserved_stock,
+       is_active, created_at, updated_at, version, reorder_threshold, stock_status,
+       parent_id, sku, options, category_id
+FROM products
+WHERE is_active = true
+  AND stock_quantity - reserved_stock <= reorder_threshold
+  AND NOT EXISTS (SELECT 1 FROM products v WHERE v.parent_id = products.id)
+ORDER BY stock_quantity - reserved_stock - reorder_threshold ASC, name ASC
+    LIMIT $1 OFFSET $2;
+
+-- name: CountLowStockProducts :one
+SELECT COUNT(*) FROM products
+WHERE is_active = true
+  AND stock_quantity - reserved_stock <= reorder_threshold
+  AND NOT EXISTS (SELECT 1 FROM products v WHERE v.parent_id = products.id);
+
+-- name: ListProductVariants :many
+SELECT id, name, description, price, stock_quantity, reserved_stock,
+       is_active, created_at, updated_at, version, reorder_threshold, stock_status,
+       parent_id, sku, options, category_id
 FROM products
-WHERE id = ANY($1::uuid[]);
\ No newline at end of file
+WHERE parent_id = ANY($1::uuid[])
+ORDER BY parent_id, sku ASC, created_at ASC;
+
+-- name: CountProductVariants :one
+SELECT COUNT(*) FROM products
+WHERE parent_id = $1;
@@ -0,0 +1,111 @@
+-- Product search. Both queries apply the same filters, keep them in step.
+-- The full-text expression matches idx_products_search.
+
+-- name: SearchProducts :many
+WITH RECURSIVE category_tree AS (
+    SELECT c.id
+    FROM categories c
+    WHERE c.id = sqlc.narg(category_id)::uuid
+    UNION ALL
+    SELECT c.id
+    FROM categories c
+             JOIN category_tree t ON c.parent_id = t.id
+)
+SELECT p.id, p.name, p.description, p.price, p.stock_quantity, p.reserved_stock,
+       p.is_active, p.created_at, p.updated_at, p.version, p.reorder_threshold, p.stock_status,
+       p.parent_id, p.sku, p.options, p.category_id
+FROM products p
+WHERE p.is_active = true
+  AND p.parent_id IS NULL
+  AND (sqlc.narg(category_id)::uuid IS NULL OR p.category_id IN (SELECT id FROM category_tree))
+  AND (sqlc.narg(search_text)::text IS NULL OR
+       (setweight(to_tsvector('english', p.name), 'A') ||
+        setweight(to_tsvector('english', coalesce(p.description, '')), 'B'))
+           @@ websearch_to_tsquery('english', sqlc.narg(search_text)::text))
+  AND (sqlc.narg(min_price)::numeric IS NULL OR p.price >= sqlc.narg(min_price)::numeric)
+  AND (sqlc.narg(max_price)::numeric IS NULL OR p.price <= sqlc.narg(max_price)::numeric)
+  AND (NOT sqlc.arg(in_stock)::boolean
+    OR p.stock_quantity - p.reserved_stock > 0
+    OR EXISTS (
+           SELECT 1 FROM products v
+           WHERE v.parent_id = p.id AND v.is_active = true AND v.stock_quantity - v.reserved_stock > 0
+       ))
+  AND NOT EXISTS (
+    SELECT 1
+    FROM unnest(sqlc.arg(attribute_codes)::text[], sqlc.arg(attribute_values)::text[]) AS f(code, value)
+    WHERE NOT EXISTS (
+        SELECT 1
+        FROM product_attributes pa
+                 JOIN attributes a ON a.id = pa.attribute_id
+        WHERE pa.product_id = p.id AND a.code = f.code AND pa.value = f.value
+    )
+)
+ORDER BY
+    CASE WHEN sqlc.arg(sort)::text = 'relevance' THEN
+             ts_rank(setweight(to_tsvector('english', p.name), 'A') ||
+                     setweight(to_tsvector('english', coalesce(p.description, '')), 'B'),
+                     websearch_to_tsquery('english', sqlc.narg(search_text)::text))
+        END DESC,
+    CASE WHEN sqlc.arg(sort)::text = 'price_asc' THEN p.price END ASC,
+    CASE WHEN sqlc.arg(sort)::text = 'price_desc' THEN p.price END DESC,
+    CASE WHEN sqlc.arg(sort)::text = 'name' THEN p.name END ASC,
+    p.created_at DESC, p.id ASC
+    LIMIT sqlc.arg(limit_count) OFFSET sqlc.arg(offset_count);
+
+-- name: SearchProductFacets :many
+WITH RECURSIVE category_tree AS (
+    SELECT c.id
+    FROM categories c
+    WHERE c.id = sqlc.narg(category_id)::uuid
+    UNION ALL
+    SELECT c.id
+    FROM categories c
+             JOIN category_tree t ON c.parent_id = t.id
+),
+matches AS (
+    SELECT p.id, p.price, p.category_id
+    FROM products p
+    WHERE p.is_active = true
+      AND p.parent_id IS NULL
+      AND (sqlc.narg(category_id)::uuid IS NULL OR p.category_id IN (SELECT id FROM category_tree))
+      AND (sqlc.narg(search_text)::text IS NULL OR
+           (setweight(to_tsvector('english', p.name), 'A') ||
+            setweight(to_tsvector('english', coalesce(p.description, '')), 'B'))
+               @@ websearch_to_tsquery('english', sqlc.narg(search_text)::text))
+      AND (sqlc.narg(min_price)::numeric IS NULL OR p.price >= sqlc.narg(min_price)::numeric)
+      AND (sqlc.narg(max_price)::numeric IS NULL OR p.price <= sqlc.narg(max_price)::numeric)
+      AND (NOT sqlc.arg(in_stock)::boolean
+        OR p.stock_quantity - p.reserved_stock > 0
+        OR EXISTS (
+               SELECT 1 FROM products v
+               WHERE v.parent_id = p.id AND v.is_active = true AND v.stock_quantity - v.reserved_stock > 0
+           ))
+      AND NOT EXISTS (
+        SELECT 1
+        FROM unnest(sqlc.arg(attribute_codes)::text[], sqlc.arg(attribute_values)::text[]) AS f(code, value)
+        WHERE NOT EXISTS (
+            SELECT 1
+            FROM product_attributes pa
+                     JOIN attributes a ON a.id = pa.attribute_id
+            WHERE pa.product_id = p.id AND a.code = f.code AND pa.value = f.value
+        )
+    )
+)
+SELECT 'total'::text AS facet, ''::text AS facet_key, ''::text AS facet_value, COUNT(*) AS product_count
+FROM matches
+UNION ALL
+SELECT 'category', '', m.category_id::text, COUNT(*)
+FROM matches m
+WHERE m.category_id IS NOT NULL
+GROUP BY m.category_id
+UNION ALL
+SELECT 'price', '', width_bucket(m.price, sqlc.arg(price_bounds)::numeric[])::text, COUNT(*)
+FROM matches m
+GROUP BY 3
+UNION ALL
+SELECT 'attribute', a.code, pa.value, COUNT(*)
+FROM matches m
+         JOIN product_attributes pa ON pa.product_id = m.id
+         JOIN attributes a ON a.id = pa.attribute_id
+GROUP BY a.code, pa.value
+ORDER BY facet, facet_key, product_count DESC, facet_value;
@@ -0,0 +1,39 @@
+-- name: CreateStockMovement :exec
+INSERT INTO stock_movements (
+    id, product_id, movement_type, stock_delta, reserved_delta, reason, order_id, correlation_id, actor, created_at, warehouse_id
+) VALUES (
+             $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+         );
+
+-- name: ListStockMovements :many
+SELECT id, product_id, movement_type, stock_delta, reserved_delta, reason,
+       order_id, correlation_id, actor, created_at, warehouse_id
+FROM stock_movements
+WHERE product_id = $1
+  AND created_at >= $2
+  AND created_at < $3
+ORDER BY created_at DESC, id
+    LIMIT $4 OFFSET $5;
+
+-- name: GetOutstandingOrderReservations :many
+SELECT product_id, warehouse_id, SUM(reserved_delta)::int AS reserved
+FROM stock_movements
+WHERE order_id = $1
+GROUP BY product_id, warehouse_id
+HAVING SUM(reserved_delta) > 0
+ORDER BY product_id, warehouse_id;
+
+-- name: GetStockLedgerTotals :many
+SELECT p.id, p.name, p.stock_quantity, p.reserved_stock,
+       COALESCE(SUM(m.stock_delta), 0)::int AS ledger_stock,
+       COALESCE(SUM(m.reserved_delta), 0)::int AS ledger_reserved
+FROM products p
+LEFT JOIN stock_movements m ON m.product_id = p.id
+GROUP BY p.id
+ORDER BY p.id;
+
+-- name: GetProductLedgerTotals :one
+SELECT COALESCE(SUM(stock_delta), 0)::int AS ledger_stock,
+       COALESCE(SUM(reserved_delta), 0)::int AS ledger_reserved
+FROM stock_movements
+WHERE product_id = $1;
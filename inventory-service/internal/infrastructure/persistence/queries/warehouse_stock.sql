@@ -0,0 +1,109 @@
+-- name: GetProductWarehouseStock :many
+SELECT ws.warehouse_id, w.code AS warehouse_code, w.name AS warehouse_name, w.is_active AS warehouse_active,
+       ws.stock_quantity, ws.reserved_stock
+FROM warehouse_stock ws
+JOIN warehouses w ON w.id = ws.warehouse_id
+WHERE ws.product_id = $1
+ORDER BY w.priority ASC, w.code ASC;
+
+-- name: GetWarehouseStockByProducts :many
+SELECT ws.product_id, ws.warehouse_id, w.code AS warehouse_code, w.name AS warehouse_name,
+       w.is_active AS warehouse_active, ws.stock_quantity, ws.reserved_stock
+FROM warehouse_stock ws
+JOIN warehouses w ON w.id = ws.warehouse_id
+WHERE ws.product_id = ANY($1::uuid[])
+ORDER BY ws.product_id, w.priority ASC, w.code ASC;
+
+-- name: GetWarehouseStockForUpdate :many
+SELECT ws.warehouse_id, ws.product_id, ws.stock_quantity, ws.reserved_stock
+FROM warehouse_stock ws
+JOIN warehouses w ON w.id = ws.warehouse_id
+WHERE ws.product_id = ANY($1::uuid[])
+  AND w.is_active = true
+ORDER BY ws.product_id, ws.warehouse_id
+FOR UPDATE OF ws;
+
+-- name: IncreaseWarehouseStock :exec
+INSERT INTO warehouse_stock (
+    warehouse_id, product_id, stock_quantity
+) VALUES (
+             sqlc.arg(warehouse_id), sqlc.arg(product_id), sqlc.arg(quantity)::int
+         )
+ON CONFLICT (warehouse_id, product_id)
+DO UPDATE SET stock_quantity = warehouse_stock.stock_quantity + EXCLUDED.stock_quantity;
+
+-- name: DecreaseWarehouseStock :execrows
+UPDATE warehouse_stock
+SET stock_quantity = stock_quantity - sqlc.arg(quantity)::int
+WHERE warehouse_id = sqlc.arg(warehouse_id)
+  AND product_id = sqlc.arg(product_id)
+  AND stock_quantity - reserved_stock >= sqlc.arg(quantity)::int;
+
+-- name: ReserveWarehouseStock :execrows
+UPDATE warehouse_stock
+SET reserved_stock = reserved_stock + sqlc.arg(quantity)::int
+WHERE warehouse_id = sqlc.arg(warehouse_id)
+  AND product_id = sqlc.arg(product_id)
+  AND stock_quantity - reserved_stock >= sqlc.arg(quantity)::int;
+
+-- name: ReleaseWarehouseStock :execrows
+UPDATE warehouse_stock
+SET reserved_stock = reserved_stock - sqlc.arg(quantity)::int
+WHERE warehouse_id = sqlc.arg(warehouse_id)
+  AND product_id = sqlc.arg(product_id)
+  AND reserved_stock >= sqlc.arg(quantity)::int;
+
+-- name: SellWarehouseStock :execrows
+UPDATE warehouse_stock
+SET stock_quantity = stock_quantity - sqlc.arg(quantity)::int,
+    reserved_stock = reserved_stock - sqlc.arg(quantity)::int
+WHERE warehouse_id = sqlc.arg(warehouse_id)
+  AND product_id = sqlc.arg(product_id)
+  AND reserved_stock >= sqlc.arg(quantity)::int;
+
+-- name: SetWarehouseStockLevels :exec
+INSERT INTO warehouse_stock (
+    warehouse_id, product_id, stock_quantity, reserved_stock
+) VALUES (
+             $1, $2, $3, $4
+         )
+ON CONFLICT (warehouse_id, product_id)
+DO UPDATE SET stock_quantity = EXCLUDED.stock_quantity,
+              reserved_stock = EXCLUDED.reserved_stock;
+
+-- name: GetWarehouseLedgerTotals :many
+SELECT COALESCE(ws.warehouse_id, l.warehouse_id)::uuid AS warehouse_id,
+       COALESCE(ws.stock_quantity, 0)::int AS stock_quantity,
+       COALESCE(ws.reserved_stock, 0)::int AS reserved_stock,
+       COALESCE(l.ledger_stock, 0)::int AS ledger_stock,
+       COALESCE(l.ledger_reserved, 0)::int AS ledger_reserved
+FROM (
+    SELECT warehouse_id, stock_quantity, reserved_stock
+    FROM warehouse_stock
+    WHERE warehouse_stock.product_id = sqlc.arg(product_id)
+) ws
+FULL OUTER JOIN (
+    SELECT warehouse_id, SUM(stock_delta) AS ledger_stock, SUM(reserved_delta) AS ledger_reserved
+    FROM stock_movements
+    WHERE stock_movements.product_id = sqlc.arg(product_id) AND warehouse_id IS NOT NULL
+    GROUP BY warehouse_id
+) l ON l.warehouse_id = ws.warehouse_id
+ORDER BY 1;
+
+-- name: GetWarehouseStockMismatches :many
+SELECT COALESCE(ws.product_id, l.product_id)::uuid AS product_id,
+       COALESCE(ws.warehouse_id, l.warehouse_id)::uuid AS warehouse_id,
+       COALESCE(ws.stock_quantity, 0)::int AS stock_quantity,
+       COALESCE(ws.reserved_stock, 0)::int AS reserved_stock,
+       COALESCE(l.ledger_stock, 0)::int AS ledger_stock,
+       COALESCE(l.ledger_reserved, 0)::int AS ledger_reserved
+FROM warehouse_stock ws
+FULL OUTER JOIN (
+    SELECT product_id, warehouse_id, SUM(stock_delta) AS ledger_stock, SUM(reserved_delta) AS ledger_reserved
+    FROM stock_movements
+    WHERE warehouse_id IS NOT NULL
+    GROUP BY product_id, warehouse_id
+) l ON l.product_id = ws.product_id AND l.warehouse_id = ws.warehouse_id
+WHERE COALESCE(ws.stock_quantity, 0) <> COALESCE(l.ledger_stock, 0)
+   OR COALESCE(ws.reserved_stock, 0) <> COALESCE(l.ledger_reserved, 0)
+ORDER BY 1, 2;
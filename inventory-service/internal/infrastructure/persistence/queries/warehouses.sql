@@ -0,0 +1,43 @@
+-- name: CreateWarehouse :exec
+INSERT INTO warehouses (
+    id, code, name, latitude, longitude, priority, is_active
+) VALUES (
+             $1, $2, $3, $4, $5, $6, $7
+         );
+
+-- name: GetWarehouseByID :one
+SELECT id, code, name, latitude, longitude, priority, is_active, created_at, updated_at
+FROM warehouses
+WHERE id = $1;
+
+-- name: GetWarehouseByCode :one
+SELECT id, code, name, latitude, longitude, priority, is_active, created_at, updated_at
+FROM warehouses
+WHERE code = $1;
+
+-- name: ListWarehouses :many
+SELECT id, code, name, latitude, longitude, priority, is_active, created_at, updated_at
+FROM warehouses
+ORDER BY priority ASC, code ASC;
+
+-- name: GetActiveWarehouses :many
+SELECT id, code, name, latitude, longitude, priority, is_active, created_at, updated_at
+FROM warehouses
+WHERE is_active = true
+ORDER BY priority ASC, code ASC;
+
+-- name: GetDefaultWarehouse :one
+SELECT id, code, name, latitude, longitude, priority, is_active, created_at, updated_at
+FROM warehouses
+WHERE is_active = true
+ORDER BY priority ASC, created_at ASC
+    LIMIT 1;
+
+-- name: UpdateWarehouse :execrows
+UPDATE warehouses
+SET name = $2,
+    latitude = $3,
+    longitude = $4,
+    priority = $5,
+    is_active = $6
+WHERE id = $1;
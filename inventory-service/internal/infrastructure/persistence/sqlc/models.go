@@ -6,19 +6,118 @@ package persistence
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type Attribute struct {
+	ID        uuid.UUID `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Backorder struct {
+	ID            uuid.UUID `json:"id"`
+	OrderID       uuid.UUID `json:"order_id"`
+	CorrelationID uuid.UUID `json:"correlation_id"`
+	ProductID     uuid.UUID `json:"product_id"`
+	Quantity      int32     `json:"quantity"`
+	Status        string    `json:"status"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type Category struct {
+	ID        uuid.UUID     `json:"id"`
+	ParentID  uuid.NullUUID `json:"parent_id"`
+	Name      string        `json:"name"`
+	Slug      string        `json:"slug"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
 type Product struct {
+	ID               uuid.UUID       `json:"id"`
+	Name             string          `json:"name"`
+	Description      sql.NullString  `json:"description"`
+	Price            string          `json:"price"`
+	StockQuantity    int32           `json:"stock_quantity"`
+	ReservedStock    int32           `json:"reserved_stock"`
+	IsActive         bool            `json:"is_active"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+	Version          int32           `json:"version"`
+	ReorderThreshold int32           `json:"reorder_threshold"`
+	StockStatus      string          `json:"stock_status"`
+	ParentID         uuid.NullUUID   `json:"parent_id"`
+	Sku              sql.NullString  `json:"sku"`
+	Options          json.RawMessage `json:"options"`
+	CategoryID       uuid.NullUUID   `json:"category_id"`
+}
+
+type ProductAttribute struct {
+	ProductID   uuid.UUID `json:"product_id"`
+	AttributeID uuid.UUID `json:"attribute_id"`
+	Value       string    `json:"value"`
+}
+
+type ProductPriceHistory struct {
+	ID            uuid.UUID     `json:"id"`
+	ProductID     uuid.UUID     `json:"product_id"`
+	Price         string        `json:"price"`
+	EffectiveFrom time.Time     `json:"effective_from"`
+	EffectiveTo   sql.NullTime  `json:"effective_to"`
+	Source        string        `json:"source"`
+	ScheduleID    uuid.NullUUID `json:"schedule_id"`
+}
+
+type ScheduledPrice struct {
 	ID            uuid.UUID      `json:"id"`
-	Name          string         `json:"name"`
-	Description   sql.NullString `json:"description"`
+	ProductID     uuid.UUID      `json:"product_id"`
 	Price         string         `json:"price"`
-	StockQuantity int32          `json:"stock_quantity"`
-	ReservedStock int32          `json:"reserved_stock"`
-	IsActive      bool           `json:"is_active"`
+	StartsAt      time.Time      `json:"starts_at"`
+	EndsAt        sql.NullTime   `json:"ends_at"`
+	PreviousPrice sql.NullString `json:"previous_price"`
+	Status        string         `json:"status"`
+	Reason        string         `json:"reason"`
 	CreatedAt     time.Time      `json:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
 }
+
+type StockMovement struct {
+	ID            uuid.UUID     `json:"id"`
+	ProductID     uuid.UUID     `json:"product_id"`
+	MovementType  string        `json:"movement_type"`
+	StockDelta    int32         `json:"stock_delta"`
+	ReservedDelta int32         `json:"reserved_delta"`
+	Reason        string        `json:"reason"`
+	OrderID       uuid.NullUUID `json:"order_id"`
+	CorrelationID uuid.NullUUID `json:"correlation_id"`
+	Actor         string        `json:"actor"`
+	CreatedAt     time.Time     `json:"created_at"`
+	WarehouseID   uuid.NullUUID `json:"warehouse_id"`
+}
+
+type Warehouse struct {
+	ID        uuid.UUID       `json:"id"`
+	Code      string          `json:"code"`
+	Name      string          `json:"name"`
+	Latitude  sql.NullFloat64 `json:"latitude"`
+	Longitude sql.NullFloat64 `json:"longitude"`
+	Priority  int32           `json:"priority"`
+	IsActive  bool            `json:"is_active"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+type WarehouseStock struct {
+	WarehouseID   uuid.UUID `json:"warehouse_id"`
+	ProductID     uuid.UUID `json:"product_id"`
+	StockQuantity int32     `json:"stock_quantity"`
+	ReservedStock int32     `json:"reserved_stock"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
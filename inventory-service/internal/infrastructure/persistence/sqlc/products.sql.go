@@ -8,27 +8,85 @@ package persistence
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
 )
 
+const countAllProducts = `-- name: CountAllProducts :one
+SELECT COUNT(*) FROM products
+WHERE parent_id IS NULL
+`
+
+func (q *Queries) CountAllProducts(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAllProducts)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countLowStockProducts = `-- name: CountLowStockProducts :one
+SELECT COUNT(*) FROM products
+WHERE is_active = true
+  AND stock_quantity - reserved_stock <= reorder_threshold
+  AND NOT EXISTS (SELECT 1 FROM products v WHERE v.parent_id = products.id)
+`
+
+func (q *Queries) CountLowStockProducts(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countLowStockProducts)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countProducts = `-- name: CountProducts :one
+SELECT COUNT(*) FROM products
+WHERE is_active = true AND parent_id IS NULL
+`
+
+func (q *Queries) CountProducts(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countProducts)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countProductVariants = `-- name: CountProductVariants :one
+SELECT COUNT(*) FROM products
+WHERE parent_id = $1
+`
+
+func (q *Queries) CountProductVariants(ctx context.Context, parentID uuid.NullUUID) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countProductVariants, parentID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createProduct = `-- name: CreateProduct :exec
 INSERT INTO products (
-    id, name, description, price, stock_quantity, reserved_stock, is_active
+    id, name, description, price, stock_quantity, reserved_stock, is_active,
+    reorder_threshold, stock_status, parent_id, sku, options, category_id
 ) VALUES (
-             $1, $2, $3, $4, $5, $6, $7
+             $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13
          )
 `
 
 type CreateProductParams struct {
-	ID            uuid.UUID      `json:"id"`
-	Name          string         `json:"name"`
-	Description   sql.NullString `json:"description"`
-	Price         string         `json:"price"`
-	StockQuantity int32          `json:"stock_quantity"`
-	ReservedStock int32          `json:"reserved_stock"`
-	IsActive      bool           `json:"is_active"`
+	ID               uuid.UUID       `json:"id"`
+	Name             string          `json:"name"`
+	Description      sql.NullString  `json:"description"`
+	Price            string          `json:"price"`
+	StockQuantity    int32           `json:"stock_quantity"`
+	ReservedStock    int32           `json:"reserved_stock"`
+	IsActive         bool            `json:"is_active"`
+	ReorderThreshold int32           `json:"reorder_threshold"`
+	StockStatus      string          `json:"stock_status"`
+	ParentID         uuid.NullUUID   `json:"parent_id"`
+	Sku              sql.NullString  `json:"sku"`
+	Options          json.RawMessage `json:"options"`
+	CategoryID       uuid.NullUUID   `json:"category_id"`
 }
 
 func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) error {
@@ -40,13 +98,40 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) er
 		arg.StockQuantity,
 		arg.ReservedStock,
 		arg.IsActive,
+		arg.ReorderThreshold,
+		arg.StockStatus,
+		arg.ParentID,
+		arg.Sku,
+		arg.Options,
+		arg.CategoryID,
 	)
 	return err
 }
 
+const decreaseProductStock = `-- name: DecreaseProductStock :execrows
+UPDATE products
+SET stock_quantity = stock_quantity - $1::int,
+    version = version + 1
+WHERE id = $2
+  AND stock_quantity - reserved_stock >= $1::int
+`
+
+type DecreaseProductStockParams struct {
+	Quantity int32     `json:"quantity"`
+	ID       uuid.UUID `json:"id"`
+}
+
+func (q *Queries) DecreaseProductStock(ctx context.Context, arg DecreaseProductStockParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, decreaseProductStock, arg.Quantity, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const deleteProduct = `-- name: DeleteProduct :exec
 UPDATE products
-SET is_active = false
+SET is_active = false, version = version + 1
 WHERE id = $1
 `
 
@@ -57,7 +142,8 @@ func (q *Queries) DeleteProduct(ctx context.Context, id uuid.UUID) error {
 
 const getActiveProducts = `-- name: GetActiveProducts :many
 SELECT id, name, description, price, stock_quantity, reserved_stock,
-       is_active, created_at, updated_at
+       is_active, created_at, updated_at, version, reorder_threshold, stock_status,
+       parent_id, sku, options, category_id
 FROM products
 WHERE is_active = true
 ORDER BY name ASC
@@ -82,6 +168,13 @@ func (q *Queries) GetActiveProducts(ctx context.Context) ([]Product, error) {
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+			&i.ReorderThreshold,
+			&i.StockStatus,
+			&i.ParentID,
+			&i.Sku,
+			&i.Options,
+			&i.CategoryID,
 		); err != nil {
 			return nil, err
 		}
@@ -98,7 +191,8 @@ func (q *Queries) GetActiveProducts(ctx context.Context) ([]Product, error) {
 
 const getProductByID = `-- name: GetProductByID :one
 SELECT id, name, description, price, stock_quantity, reserved_stock,
-       is_active, created_at, updated_at
+       is_active, created_at, updated_at, version, reorder_threshold, stock_status,
+       parent_id, sku, options, category_id
 FROM products
 WHERE id = $1
 `
@@ -116,13 +210,54 @@ func (q *Queries) GetProductByID(ctx context.Context, id uuid.UUID) (Product, er
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.ReorderThreshold,
+		&i.StockStatus,
+		&i.ParentID,
+		&i.Sku,
+		&i.Options,
+		&i.CategoryID,
+	)
+	return i, err
+}
+
+const getProductForUpdate = `-- name: GetProductForUpdate :one
+SELECT id, name, description, price, stock_quantity, reserved_stock,
+       is_active, created_at, updated_at, version, reorder_threshold, stock_status,
+       parent_id, sku, options, category_id
+FROM products
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetProductForUpdate(ctx context.Context, id uuid.UUID) (Product, error) {
+	row := q.db.QueryRowContext(ctx, getProductForUpdate, id)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Description,
+		&i.Price,
+		&i.StockQuantity,
+		&i.ReservedStock,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.ReorderThreshold,
+		&i.StockStatus,
+		&i.ParentID,
+		&i.Sku,
+		&i.Options,
+		&i.CategoryID,
 	)
 	return i, err
 }
 
 const getProductsByIDs = `-- name: GetProductsByIDs :many
 SELECT id, name, description, price, stock_quantity, reserved_stock,
-       is_active, created_at, updated_at
+       is_active, created_at, updated_at, version, reorder_threshold, stock_status,
+       parent_id, sku, options, category_id
 FROM products
 WHERE id = ANY($1::uuid[])
 `
@@ -146,6 +281,13 @@ func (q *Queries) GetProductsByIDs(ctx context.Context, dollar_1 []uuid.UUID) ([
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+			&i.ReorderThreshold,
+			&i.StockStatus,
+			&i.ParentID,
+			&i.Sku,
+			&i.Options,
+			&i.CategoryID,
 		); err != nil {
 			return nil, err
 		}
@@ -160,11 +302,144 @@ func (q *Queries) GetProductsByIDs(ctx context.Context, dollar_1 []uuid.UUID) ([
 	return items, nil
 }
 
-const listProducts = `-- name: ListProducts :many
+const increaseProductStock = `-- name: IncreaseProductStock :execrows
+UPDATE products
+SET stock_quantity = stock_quantity + $1::int,
+    version = version + 1
+WHERE id = $2
+`
+
+type IncreaseProductStockParams struct {
+	Quantity int32     `json:"quantity"`
+	ID       uuid.UUID `json:"id"`
+}
+
+func (q *Queries) IncreaseProductStock(ctx context.Context, arg IncreaseProductStockParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, increaseProductStock, arg.Quantity, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const listAllProducts = `-- name: ListAllProducts :many
+SELECT id, name, description, price, stock_quantity, reserved_stock,
+       is_active, created_at, updated_at, version, reorder_threshold, stock_status,
+       parent_id, sku, options, category_id
+FROM products
+WHERE parent_id IS NULL
+ORDER BY created_at DESC
+    LIMIT $1 OFFSET $2
+`
+
+type ListAllProductsParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListAllProducts(ctx context.Context, arg ListAllProductsParams) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, listAllProducts, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Product{}
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.StockQuantity,
+			&i.ReservedStock,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.ReorderThreshold,
+			&i.StockStatus,
+			&i.ParentID,
+			&i.Sku,
+			&i.Options,
+			&i.CategoryID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLowStockProducts = `-- name: ListLowStockProducts :many
 SELECT id, name, description, price, stock_quantity, reserved_stock,
-       is_active, created_at, updated_at
+       is_active, created_at, updated_at, version, reorder_threshold, stock_status,
+       parent_id, sku, options, category_id
 FROM products
 WHERE is_active = true
+  AND stock_quantity - reserved_stock <= reorder_threshold
+  AND NOT EXISTS (SELECT 1 FROM products v WHERE v.parent_id = products.id)
+ORDER BY stock_quantity - reserved_stock - reorder_threshold ASC, name ASC
+    LIMIT $1 OFFSET $2
+`
+
+type ListLowStockProductsParams struct {
+	Limit  int32 `json:"limit"`
+	Offset int32 `json:"offset"`
+}
+
+func (q *Queries) ListLowStockProducts(ctx context.Context, arg ListLowStockProductsParams) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, listLowStockProducts, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Product{}
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.StockQuantity,
+			&i.ReservedStock,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.ReorderThreshold,
+			&i.StockStatus,
+			&i.ParentID,
+			&i.Sku,
+			&i.Options,
+			&i.CategoryID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProducts = `-- name: ListProducts :many
+SELECT id, name, description, price, stock_quantity, reserved_stock,
+       is_active, created_at, updated_at, version, reorder_threshold, stock_status,
+       parent_id, sku, options, category_id
+FROM products
+WHERE is_active = true AND parent_id IS NULL
 ORDER BY created_at DESC
     LIMIT $1 OFFSET $2
 `
@@ -193,6 +468,13 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]P
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+			&i.ReorderThreshold,
+			&i.StockStatus,
+			&i.ParentID,
+			&i.Sku,
+			&i.Options,
+			&i.CategoryID,
 		); err != nil {
 			return nil, err
 		}
@@ -207,29 +489,243 @@ func (q *Queries) ListProducts(ctx context.Context, arg ListProductsParams) ([]P
 	return items, nil
 }
 
-const updateProduct = `-- name: UpdateProduct :exec
+const listProductsAfter = `-- name: ListProductsAfter :many
+SELECT id, name, description, price, stock_quantity, reserved_stock,
+       is_active, created_at, updated_at, version, reorder_threshold, stock_status,
+       parent_id, sku, options, category_id
+FROM products
+WHERE id > $1 AND parent_id IS NULL
+ORDER BY id ASC
+    LIMIT $2
+`
+
+type ListProductsAfterParams struct {
+	AfterID    uuid.UUID `json:"after_id"`
+	LimitCount int32     `json:"limit_count"`
+}
+
+func (q *Queries) ListProductsAfter(ctx context.Context, arg ListProductsAfterParams) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, listProductsAfter, arg.AfterID, arg.LimitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Product{}
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.StockQuantity,
+			&i.ReservedStock,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.ReorderThreshold,
+			&i.StockStatus,
+			&i.ParentID,
+			&i.Sku,
+			&i.Options,
+			&i.CategoryID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductVariants = `-- name: ListProductVariants :many
+SELECT id, name, description, price, stock_quantity, reserved_stock,
+       is_active, created_at, updated_at, version, reorder_threshold, stock_status,
+       parent_id, sku, options, category_id
+FROM products
+WHERE parent_id = ANY($1::uuid[])
+ORDER BY parent_id, sku ASC, created_at ASC
+`
+
+func (q *Queries) ListProductVariants(ctx context.Context, dollar_1 []uuid.UUID) ([]Product, error) {
+	rows, err := q.db.QueryContext(ctx, listProductVariants, pq.Array(dollar_1))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Product{}
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.Price,
+			&i.StockQuantity,
+			&i.ReservedStock,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Version,
+			&i.ReorderThreshold,
+			&i.StockStatus,
+			&i.ParentID,
+			&i.Sku,
+			&i.Options,
+			&i.CategoryID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const releaseProductStock = `-- name: ReleaseProductStock :execrows
+UPDATE products
+SET reserved_stock = reserved_stock - $1::int,
+    version = version + 1
+WHERE id = $2
+  AND reserved_stock >= $1::int
+`
+
+type ReleaseProductStockParams struct {
+	Quantity int32     `json:"quantity"`
+	ID       uuid.UUID `json:"id"`
+}
+
+func (q *Queries) ReleaseProductStock(ctx context.Context, arg ReleaseProductStockParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, releaseProductStock, arg.Quantity, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const reserveProductStock = `-- name: ReserveProductStock :execrows
+UPDATE products
+SET reserved_stock = reserved_stock + $1::int,
+    version = version + 1
+WHERE id = $2
+  AND is_active = true
+  AND stock_quantity - reserved_stock >= $1::int
+`
+
+type ReserveProductStockParams struct {
+	Quantity int32     `json:"quantity"`
+	ID       uuid.UUID `json:"id"`
+}
+
+func (q *Queries) ReserveProductStock(ctx context.Context, arg ReserveProductStockParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, reserveProductStock, arg.Quantity, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const sellProductStock = `-- name: SellProductStock :execrows
+UPDATE products
+SET stock_quantity = stock_quantity - $1::int,
+    reserved_stock = reserved_stock - $1::int,
+    version = version + 1
+WHERE id = $2
+  AND reserved_stock >= $1::int
+`
+
+type SellProductStockParams struct {
+	Quantity int32     `json:"quantity"`
+	ID       uuid.UUID `json:"id"`
+}
+
+func (q *Queries) SellProductStock(ctx context.Context, arg SellProductStockParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, sellProductStock, arg.Quantity, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setProductStockLevels = `-- name: SetProductStockLevels :exec
+UPDATE products
+SET stock_quantity = $2,
+    reserved_stock = $3,
+    version = version + 1
+WHERE id = $1
+`
+
+type SetProductStockLevelsParams struct {
+	ID            uuid.UUID `json:"id"`
+	StockQuantity int32     `json:"stock_quantity"`
+	ReservedStock int32     `json:"reserved_stock"`
+}
+
+func (q *Queries) SetProductStockLevels(ctx context.Context, arg SetProductStockLevelsParams) error {
+	_, err := q.db.ExecContext(ctx, setProductStockLevels, arg.ID, arg.StockQuantity, arg.ReservedStock)
+	return err
+}
+
+const setProductStockStatus = `-- name: SetProductStockStatus :exec
+UPDATE products
+SET stock_status = $2
+WHERE id = $1
+`
+
+type SetProductStockStatusParams struct {
+	ID          uuid.UUID `json:"id"`
+	StockStatus string    `json:"stock_status"`
+}
+
+func (q *Queries) SetProductStockStatus(ctx context.Context, arg SetProductStockStatusParams) error {
+	_, err := q.db.ExecContext(ctx, setProductStockStatus, arg.ID, arg.StockStatus)
+	return err
+}
+
+const updateProduct = `-- name: UpdateProduct :execrows
 UPDATE products
 SET name = $2,
     description = $3,
     price = $4,
     stock_quantity = $5,
     reserved_stock = $6,
-    is_active = $7
-WHERE id = $1
+    is_active = $7,
+    reorder_threshold = $8,
+    sku = $10,
+    options = $11,
+    category_id = $12,
+    version = version + 1
+WHERE id = $1 AND version = $9
 `
 
 type UpdateProductParams struct {
-	ID            uuid.UUID      `json:"id"`
-	Name          string         `json:"name"`
-	Description   sql.NullString `json:"description"`
-	Price         string         `json:"price"`
-	StockQuantity int32          `json:"stock_quantity"`
-	ReservedStock int32          `json:"reserved_stock"`
-	IsActive      bool           `json:"is_active"`
+	ID               uuid.UUID       `json:"id"`
+	Name             string          `json:"name"`
+	Description      sql.NullString  `json:"description"`
+	Price            string          `json:"price"`
+	StockQuantity    int32           `json:"stock_quantity"`
+	ReservedStock    int32           `json:"reserved_stock"`
+	IsActive         bool            `json:"is_active"`
+	ReorderThreshold int32           `json:"reorder_threshold"`
+	Version          int32           `json:"version"`
+	Sku              sql.NullString  `json:"sku"`
+	Options          json.RawMessage `json:"options"`
+	CategoryID       uuid.NullUUID   `json:"category_id"`
 }
 
-func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) error {
-	_, err := q.db.ExecContext(ctx, updateProduct,
+func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateProduct,
 		arg.ID,
 		arg.Name,
 		arg.Description,
@@ -237,6 +733,14 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) er
 		arg.StockQuantity,
 		arg.ReservedStock,
 		arg.IsActive,
+		arg.ReorderThreshold,
+		arg.Version,
+		arg.Sku,
+		arg.Options,
+		arg.CategoryID,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
@@ -11,13 +11,81 @@ import (
 )
 
 type Querier interface {
+	AllocateBackorder(ctx context.Context, arg AllocateBackorderParams) error
+	CancelOrderBackorders(ctx context.Context, orderID uuid.UUID) (int64, error)
+	ClosePriceHistory(ctx context.Context, arg ClosePriceHistoryParams) error
+	CountAllProducts(ctx context.Context) (int64, error)
+	CountLowStockProducts(ctx context.Context) (int64, error)
+	CountPriceHistory(ctx context.Context, productID uuid.UUID) (int64, error)
+	CountProducts(ctx context.Context) (int64, error)
+	CountProductVariants(ctx context.Context, parentID uuid.NullUUID) (int64, error)
+	CreateAttribute(ctx context.Context, arg CreateAttributeParams) error
+	CreateBackorder(ctx context.Context, arg CreateBackorderParams) error
+	CreateCategory(ctx context.Context, arg CreateCategoryParams) error
+	CreatePriceHistory(ctx context.Context, arg CreatePriceHistoryParams) error
 	CreateProduct(ctx context.Context, arg CreateProductParams) error
+	CreateProductAttribute(ctx context.Context, arg CreateProductAttributeParams) error
+	CreateScheduledPrice(ctx context.Context, arg CreateScheduledPriceParams) error
+	CreateStockMovement(ctx context.Context, arg CreateStockMovementParams) error
+	CreateWarehouse(ctx context.Context, arg CreateWarehouseParams) error
+	DecreaseProductStock(ctx context.Context, arg DecreaseProductStockParams) (int64, error)
+	DecreaseWarehouseStock(ctx context.Context, arg DecreaseWarehouseStockParams) (int64, error)
 	DeleteProduct(ctx context.Context, id uuid.UUID) error
+	DeleteProductAttributes(ctx context.Context, productID uuid.UUID) error
 	GetActiveProducts(ctx context.Context) ([]Product, error)
+	GetActiveWarehouses(ctx context.Context) ([]Warehouse, error)
+	GetCategoryByID(ctx context.Context, id uuid.UUID) (Category, error)
+	GetDefaultWarehouse(ctx context.Context) (Warehouse, error)
+	GetOpenPriceHistory(ctx context.Context, productID uuid.UUID) (ProductPriceHistory, error)
+	GetOutstandingOrderReservations(ctx context.Context, orderID uuid.NullUUID) ([]GetOutstandingOrderReservationsRow, error)
+	GetPendingBackordersForUpdate(ctx context.Context, productID uuid.UUID) ([]Backorder, error)
+	GetPriceAt(ctx context.Context, arg GetPriceAtParams) (ProductPriceHistory, error)
 	GetProductByID(ctx context.Context, id uuid.UUID) (Product, error)
+	GetProductForUpdate(ctx context.Context, id uuid.UUID) (Product, error)
+	GetProductLedgerTotals(ctx context.Context, productID uuid.UUID) (GetProductLedgerTotalsRow, error)
 	GetProductsByIDs(ctx context.Context, dollar_1 []uuid.UUID) ([]Product, error)
+	GetProductWarehouseStock(ctx context.Context, productID uuid.UUID) ([]GetProductWarehouseStockRow, error)
+	GetScheduledPriceForUpdate(ctx context.Context, id uuid.UUID) (ScheduledPrice, error)
+	GetStockLedgerTotals(ctx context.Context) ([]GetStockLedgerTotalsRow, error)
+	GetWarehouseByCode(ctx context.Context, code string) (Warehouse, error)
+	GetWarehouseByID(ctx context.Context, id uuid.UUID) (Warehouse, error)
+	GetWarehouseLedgerTotals(ctx context.Context, productID uuid.UUID) ([]GetWarehouseLedgerTotalsRow, error)
+	GetWarehouseStockByProducts(ctx context.Context, dollar_1 []uuid.UUID) ([]GetWarehouseStockByProductsRow, error)
+	GetWarehouseStockForUpdate(ctx context.Context, dollar_1 []uuid.UUID) ([]GetWarehouseStockForUpdateRow, error)
+	GetWarehouseStockMismatches(ctx context.Context) ([]GetWarehouseStockMismatchesRow, error)
+	IncreaseProductStock(ctx context.Context, arg IncreaseProductStockParams) (int64, error)
+	IncreaseWarehouseStock(ctx context.Context, arg IncreaseWarehouseStockParams) error
+	ListAllProducts(ctx context.Context, arg ListAllProductsParams) ([]Product, error)
+	ListAttributes(ctx context.Context) ([]Attribute, error)
+	ListCategories(ctx context.Context) ([]Category, error)
+	ListCategoryAncestorIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
+	ListDueScheduledPrices(ctx context.Context, arg ListDueScheduledPricesParams) ([]ScheduledPrice, error)
+	ListLowStockProducts(ctx context.Context, arg ListLowStockProductsParams) ([]Product, error)
+	ListPendingScheduledPrices(ctx context.Context, productID uuid.UUID) ([]ScheduledPrice, error)
+	ListPriceHistory(ctx context.Context, arg ListPriceHistoryParams) ([]ProductPriceHistory, error)
+	ListProductAttributes(ctx context.Context, productID uuid.UUID) ([]ListProductAttributesRow, error)
 	ListProducts(ctx context.Context, arg ListProductsParams) ([]Product, error)
-	UpdateProduct(ctx context.Context, arg UpdateProductParams) error
+	ListProductsAfter(ctx context.Context, arg ListProductsAfterParams) ([]Product, error)
+	ListProductVariants(ctx context.Context, dollar_1 []uuid.UUID) ([]Product, error)
+	ListScheduledPrices(ctx context.Context, productID uuid.UUID) ([]ScheduledPrice, error)
+	ListStockMovements(ctx context.Context, arg ListStockMovementsParams) ([]StockMovement, error)
+	ListWarehouses(ctx context.Context) ([]Warehouse, error)
+	ReleaseProductStock(ctx context.Context, arg ReleaseProductStockParams) (int64, error)
+	ReleaseWarehouseStock(ctx context.Context, arg ReleaseWarehouseStockParams) (int64, error)
+	ReserveProductStock(ctx context.Context, arg ReserveProductStockParams) (int64, error)
+	ReserveWarehouseStock(ctx context.Context, arg ReserveWarehouseStockParams) (int64, error)
+	SearchProductFacets(ctx context.Context, arg SearchProductFacetsParams) ([]SearchProductFacetsRow, error)
+	SearchProducts(ctx context.Context, arg SearchProductsParams) ([]Product, error)
+	SellProductStock(ctx context.Context, arg SellProductStockParams) (int64, error)
+	SellWarehouseStock(ctx context.Context, arg SellWarehouseStockParams) (int64, error)
+	SetProductPrice(ctx context.Context, arg SetProductPriceParams) error
+	SetProductStockLevels(ctx context.Context, arg SetProductStockLevelsParams) error
+	SetProductStockStatus(ctx context.Context, arg SetProductStockStatusParams) error
+	SetWarehouseStockLevels(ctx context.Context, arg SetWarehouseStockLevelsParams) error
+	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (int64, error)
+	UpdateProduct(ctx context.Context, arg UpdateProductParams) (int64, error)
+	UpdateScheduledPriceStatus(ctx context.Context, arg UpdateScheduledPriceStatusParams) error
+	UpdateWarehouse(ctx context.Context, arg UpdateWarehouseParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
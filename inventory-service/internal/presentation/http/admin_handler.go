@@ -0,0 +1,45 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/usecase"
+)
+
+type AdminHandler struct {
+	reconcileStockUseCase *usecase.ReconcileStockUseCase
+}
+
+func NewAdminHandler(reconcileStockUseCase *usecase.ReconcileStockUseCase) *AdminHandler {
+	return &AdminHandler{
+		reconcileStockUseCase: reconcileStockUseCase,
+	}
+}
+
+// ReconcileStock handles a stock reconciliation run
+// @Summary Reconcile stock
+// @Description Recomputes stock and reserved quantities from the stock ledger and reports mismatches per product
+// @Tags admin
+// @Produce json
+// @Param repair query bool false "Overwrite mismatched stock levels with the ledger totals"
+// @Success 200 {object} dto.ReconciliationReport
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/admin/reconciliation [post]
+func (h *AdminHandler) ReconcileStock(c *gin.Context) {
+	var query dto.ReconcileStockQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.reconcileStockUseCase.Execute(c.Request.Context(), query.Repair)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
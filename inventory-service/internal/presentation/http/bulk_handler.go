@@ -0,0 +1,122 @@
+package http
+
+import (
+	"errors"
+	"log"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/usecase"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+)
+
+// maxImportFileSize bounds the request body of a bulk import
+const maxImportFileSize = 64 << 20
+
+// contentTypes maps the bulk file formats to their media types
+var contentTypes = map[string]string{
+	usecase.FileFormatCSV:   "text/csv",
+	usecase.FileFormatJSONL: "application/x-ndjson",
+}
+
+type BulkHandler struct {
+	importProductsUseCase *usecase.ImportProductsUseCase
+	exportProductsUseCase *usecase.ExportProductsUseCase
+}
+
+func NewBulkHandler(
+	importProductsUseCase *usecase.ImportProductsUseCase,
+	exportProductsUseCase *usecase.ExportProductsUseCase,
+) *BulkHandler {
+	return &BulkHandler{
+		importProductsUseCase: importProductsUseCase,
+		exportProductsUseCase: exportProductsUseCase,
+	}
+}
+
+// ImportProducts handles a bulk product import
+// @Summary Import products
+// @Description Creates and updates products and their stock levels from a CSV or JSON Lines body. Rows are validated one by one and upserted in batches; rows that fail are listed in the report and do not stop the import.
+// @Tags products
+// @Accept text/csv
+// @Accept application/x-ndjson
+// @Produce json
+// @Param format query string false "File format, defaults to the one matching the Content-Type" Enums(csv, jsonl)
+// @Param dry_run query bool false "Validate and apply every row, then discard the changes"
+// @Success 200 {object} dto.ProductImportReport
+// @Failure 400 {object} map[string]string "Unknown format or invalid CSV header"
+// @Failure 413 {object} map[string]string "File too large"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products/import [post]
+func (h *BulkHandler) ImportProducts(c *gin.Context) {
+	var query dto.ImportProductsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	format := query.Format
+	if format == "" {
+		format = formatFromContentType(c.ContentType())
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxImportFileSize)
+	report, err := h.importProductsUseCase.Execute(c.Request.Context(), format, body, entity.ActorAPI, query.DryRun)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		case errors.Is(err, usecase.ErrUnsupportedFormat),
+			errors.Is(err, usecase.ErrInvalidCSVHeader):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ExportProducts handles a catalogue export
+// @Summary Export products
+// @Description Streams every product with its stock per warehouse, in the format the import accepts
+// @Tags products
+// @Produce text/csv
+// @Produce application/x-ndjson
+// @Param format query string false "File format" Enums(csv, jsonl) default(csv)
+// @Success 200 {file} file
+// @Failure 400 {object} map[string]string "Unknown format"
+// @Router /api/v1/products/export [get]
+func (h *BulkHandler) ExportProducts(c *gin.Context) {
+	var query dto.ExportProductsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Header("Content-Type", contentTypes[query.Format])
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": "products." + query.Format,
+	}))
+	c.Status(http.StatusOK)
+
+	// The status is already sent, an error can only cut the file short
+	if err := h.exportProductsUseCase.Execute(c.Request.Context(), query.Format, c.Writer); err != nil {
+		log.Printf("ERROR: Product export failed: %v", err)
+	}
+}
+
+// formatFromContentType picks the bulk file format of a request body
+func formatFromContentType(contentType string) string {
+	switch contentType {
+	case "text/csv":
+		return usecase.FileFormatCSV
+	case "application/x-ndjson", "application/jsonl", "application/x-jsonlines":
+		return usecase.FileFormatJSONL
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,260 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/usecase"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+)
+
+// CatalogHandler serves categories, attributes and product search
+type CatalogHandler struct {
+	createCategoryUseCase       *usecase.CreateCategoryUseCase
+	listCategoriesUseCase       *usecase.ListCategoriesUseCase
+	updateCategoryUseCase       *usecase.UpdateCategoryUseCase
+	createAttributeUseCase      *usecase.CreateAttributeUseCase
+	listAttributesUseCase       *usecase.ListAttributesUseCase
+	setProductAttributesUseCase *usecase.SetProductAttributesUseCase
+	searchProductsUseCase       *usecase.SearchProductsUseCase
+}
+
+func NewCatalogHandler(
+	createCategoryUseCase *usecase.CreateCategoryUseCase,
+	listCategoriesUseCase *usecase.ListCategoriesUseCase,
+	updateCategoryUseCase *usecase.UpdateCategoryUseCase,
+	createAttributeUseCase *usecase.CreateAttributeUseCase,
+	listAttributesUseCase *usecase.ListAttributesUseCase,
+	setProductAttributesUseCase *usecase.SetProductAttributesUseCase,
+	searchProductsUseCase *usecase.SearchProductsUseCase,
+) *CatalogHandler {
+	return &CatalogHandler{
+		createCategoryUseCase:       createCategoryUseCase,
+		listCategoriesUseCase:       listCategoriesUseCase,
+		updateCategoryUseCase:       updateCategoryUseCase,
+		createAttributeUseCase:      createAttributeUseCase,
+		listAttributesUseCase:       listAttributesUseCase,
+		setProductAttributesUseCase: setProductAttributesUseCase,
+		searchProductsUseCase:       searchProductsUseCase,
+	}
+}
+
+// CreateCategory handles category creation
+// @Summary Create a category
+// @Description Adds a category, optionally nested under a parent category
+// @Tags catalog
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateCategoryRequest true "Category details"
+// @Success 201 {object} dto.CategoryResponse
+// @Failure 400 {object} map[string]string "Invalid request or validation error"
+// @Failure 404 {object} map[string]string "Parent category not found"
+// @Failure 409 {object} map[string]string "Category slug already in use"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/categories [post]
+func (h *CatalogHandler) CreateCategory(c *gin.Context) {
+	var req dto.CreateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := h.createCategoryUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		respondWithCatalogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, category)
+}
+
+// ListCategories handles category listing
+// @Summary List categories
+// @Description Returns the category tree, siblings ordered by name
+// @Tags catalog
+// @Produce json
+// @Success 200 {object} dto.CategoryTreeResponse
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/categories [get]
+func (h *CatalogHandler) ListCategories(c *gin.Context) {
+	categories, err := h.listCategoriesUseCase.Execute(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
+}
+
+// UpdateCategory handles partial category updates
+// @Summary Update a category
+// @Description Renames a category or moves it, along with its products and subcategories
+// @Tags catalog
+// @Accept json
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param request body dto.UpdateCategoryRequest true "Fields to update"
+// @Success 200 {object} dto.CategoryResponse
+// @Failure 400 {object} map[string]string "Invalid request or validation error"
+// @Failure 404 {object} map[string]string "Category or parent category not found"
+// @Failure 409 {object} map[string]string "Slug already in use, or the move would nest the category under itself"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/categories/{id} [patch]
+func (h *CatalogHandler) UpdateCategory(c *gin.Context) {
+	categoryID := c.Param("id")
+	if _, err := uuid.Parse(categoryID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid category ID format"})
+		return
+	}
+
+	var req dto.UpdateCategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := h.updateCategoryUseCase.Execute(c.Request.Context(), categoryID, req)
+	if err != nil {
+		respondWithCatalogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, category)
+}
+
+// CreateAttribute handles attribute creation
+// @Summary Create an attribute
+// @Description Defines a typed attribute products can have values for
+// @Tags catalog
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateAttributeRequest true "Attribute details"
+// @Success 201 {object} dto.AttributeResponse
+// @Failure 400 {object} map[string]string "Invalid request or validation error"
+// @Failure 409 {object} map[string]string "Attribute code already in use"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/attributes [post]
+func (h *CatalogHandler) CreateAttribute(c *gin.Context) {
+	var req dto.CreateAttributeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	attribute, err := h.createAttributeUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		respondWithCatalogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, attribute)
+}
+
+// ListAttributes handles attribute listing
+// @Summary List attributes
+// @Description Returns all attribute definitions ordered by code
+// @Tags catalog
+// @Produce json
+// @Success 200 {object} dto.AttributeListResponse
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/attributes [get]
+func (h *CatalogHandler) ListAttributes(c *gin.Context) {
+	attributes, err := h.listAttributesUseCase.Execute(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, attributes)
+}
+
+// SetProductAttributes handles replacing a product's attribute values
+// @Summary Set product attributes
+// @Description Replaces all attribute values of a product, values must match their attribute's type
+// @Tags catalog
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param request body dto.SetProductAttributesRequest true "Attribute values by code"
+// @Success 200 {object} dto.ProductAttributesResponse
+// @Failure 400 {object} map[string]string "Invalid request, unknown attribute or mistyped value"
+// @Failure 404 {object} map[string]string "Product not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products/{id}/attributes [put]
+func (h *CatalogHandler) SetProductAttributes(c *gin.Context) {
+	productID, ok := productIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SetProductAttributesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	attributes, err := h.setProductAttributesUseCase.Execute(c.Request.Context(), productID, req)
+	if err != nil {
+		respondWithCatalogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, attributes)
+}
+
+// SearchProducts handles faceted product search
+// @Summary Search products
+// @Description Full-text search over active products with filters, sorting and facet counts over all matches
+// @Tags catalog
+// @Produce json
+// @Param q query string false "Text matched against names and descriptions"
+// @Param category_id query string false "Category, including its subcategories"
+// @Param min_price query number false "Lowest price"
+// @Param max_price query number false "Highest price"
+// @Param in_stock query bool false "Only products that can be ordered now"
+// @Param attr[code] query string false "Attribute value, repeatable for several attributes"
+// @Param sort query string false "relevance, price_asc, price_desc, newest or name" default(relevance)
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param offset query int false "Number of products to skip" default(0)
+// @Success 200 {object} dto.ProductSearchResponse
+// @Failure 400 {object} map[string]string "Invalid filters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products/search [get]
+func (h *CatalogHandler) SearchProducts(c *gin.Context) {
+	var query dto.SearchProductsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	query.Attributes = c.QueryMap("attr")
+
+	result, err := h.searchProductsUseCase.Execute(c.Request.Context(), query)
+	if err != nil {
+		respondWithCatalogError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// respondWithCatalogError maps domain errors to HTTP status codes
+func respondWithCatalogError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrCategoryNotFound),
+		errors.Is(err, entity.ErrProductNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrCategorySlugTaken),
+		errors.Is(err, entity.ErrCategoryCycle),
+		errors.Is(err, entity.ErrAttributeCodeTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrAttributeNotFound),
+		errors.Is(err, entity.ErrInvalidAttributeValue),
+		errors.Is(err, entity.ErrInvalidPriceRange):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
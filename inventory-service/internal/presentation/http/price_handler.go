@@ -0,0 +1,209 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/usecase"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+)
+
+// PriceHandler serves product price history and scheduled price changes
+type PriceHandler struct {
+	getProductPriceUseCase      *usecase.GetProductPriceUseCase
+	listPriceHistoryUseCase     *usecase.ListPriceHistoryUseCase
+	schedulePriceUseCase        *usecase.SchedulePriceUseCase
+	listScheduledPricesUseCase  *usecase.ListScheduledPricesUseCase
+	cancelScheduledPriceUseCase *usecase.CancelScheduledPriceUseCase
+}
+
+func NewPriceHandler(
+	getProductPriceUseCase *usecase.GetProductPriceUseCase,
+	listPriceHistoryUseCase *usecase.ListPriceHistoryUseCase,
+	schedulePriceUseCase *usecase.SchedulePriceUseCase,
+	listScheduledPricesUseCase *usecase.ListScheduledPricesUseCase,
+	cancelScheduledPriceUseCase *usecase.CancelScheduledPriceUseCase,
+) *PriceHandler {
+	return &PriceHandler{
+		getProductPriceUseCase:      getProductPriceUseCase,
+		listPriceHistoryUseCase:     listPriceHistoryUseCase,
+		schedulePriceUseCase:        schedulePriceUseCase,
+		listScheduledPricesUseCase:  listScheduledPricesUseCase,
+		cancelScheduledPriceUseCase: cancelScheduledPriceUseCase,
+	}
+}
+
+// GetPrice handles the price of a product at a moment
+// @Summary Get the effective price
+// @Description Returns the price the product had at the given moment, e.g. to validate the price of a past order
+// @Tags prices
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param at query string false "Moment to get the price at (RFC 3339), defaults to now"
+// @Success 200 {object} dto.PriceResponse
+// @Failure 400 {object} map[string]string "Invalid product ID or query parameters"
+// @Failure 404 {object} map[string]string "Product not found or no price at that moment"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products/{id}/price [get]
+func (h *PriceHandler) GetPrice(c *gin.Context) {
+	productID, ok := productIDParam(c)
+	if !ok {
+		return
+	}
+
+	var query dto.GetPriceQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	price, err := h.getProductPriceUseCase.Execute(c.Request.Context(), productID, query)
+	if err != nil {
+		respondWithPriceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, price)
+}
+
+// ListPriceHistory handles the price history of a product
+// @Summary List price history
+// @Description Returns the prices a product has had and when each was in effect, newest first
+// @Tags prices
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param limit query int false "Page size (1-500)" default(50)
+// @Param offset query int false "Number of prices to skip" default(0)
+// @Success 200 {object} dto.PriceHistoryResponse
+// @Failure 400 {object} map[string]string "Invalid product ID or query parameters"
+// @Failure 404 {object} map[string]string "Product not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products/{id}/price-history [get]
+func (h *PriceHandler) ListPriceHistory(c *gin.Context) {
+	productID, ok := productIDParam(c)
+	if !ok {
+		return
+	}
+
+	var query dto.ListPriceHistoryQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	history, err := h.listPriceHistoryUseCase.Execute(c.Request.Context(), productID, query)
+	if err != nil {
+		respondWithPriceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
+// SchedulePrice handles scheduling a price change
+// @Summary Schedule a price change
+// @Description Schedules a new price from starts_at. With ends_at the replaced price is restored at the end, unless the price was changed in between.
+// @Tags prices
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param request body dto.SchedulePriceRequest true "Price change"
+// @Success 201 {object} dto.ScheduledPriceResponse
+// @Failure 400 {object} map[string]string "Invalid request or validation error"
+// @Failure 404 {object} map[string]string "Product not found"
+// @Failure 409 {object} map[string]string "Overlaps another scheduled price change"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products/{id}/scheduled-prices [post]
+func (h *PriceHandler) SchedulePrice(c *gin.Context) {
+	productID, ok := productIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SchedulePriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	schedule, err := h.schedulePriceUseCase.Execute(c.Request.Context(), productID, req)
+	if err != nil {
+		respondWithPriceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, schedule)
+}
+
+// ListScheduledPrices handles listing the scheduled price changes of a product
+// @Summary List scheduled price changes
+// @Description Returns the product's scheduled price changes in start order, including past and cancelled ones
+// @Tags prices
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} dto.ScheduledPriceListResponse
+// @Failure 400 {object} map[string]string "Invalid product ID"
+// @Failure 404 {object} map[string]string "Product not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products/{id}/scheduled-prices [get]
+func (h *PriceHandler) ListScheduledPrices(c *gin.Context) {
+	productID, ok := productIDParam(c)
+	if !ok {
+		return
+	}
+
+	schedules, err := h.listScheduledPricesUseCase.Execute(c.Request.Context(), productID)
+	if err != nil {
+		respondWithPriceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedules)
+}
+
+// CancelScheduledPrice handles cancelling a scheduled price change
+// @Summary Cancel a scheduled price change
+// @Description Cancels a price change that has not started yet
+// @Tags prices
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param schedule_id path string true "Scheduled price ID"
+// @Success 200 {object} dto.ScheduledPriceResponse
+// @Failure 400 {object} map[string]string "Invalid product ID"
+// @Failure 404 {object} map[string]string "Scheduled price not found"
+// @Failure 409 {object} map[string]string "Price change has already started"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products/{id}/scheduled-prices/{schedule_id} [delete]
+func (h *PriceHandler) CancelScheduledPrice(c *gin.Context) {
+	productID, ok := productIDParam(c)
+	if !ok {
+		return
+	}
+
+	schedule, err := h.cancelScheduledPriceUseCase.Execute(c.Request.Context(), productID, c.Param("schedule_id"))
+	if err != nil {
+		respondWithPriceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// respondWithPriceError maps domain errors to HTTP status codes
+func respondWithPriceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrProductNotFound),
+		errors.Is(err, entity.ErrPriceNotFound),
+		errors.Is(err, entity.ErrScheduledPriceNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrScheduledPriceOverlap),
+		errors.Is(err, entity.ErrScheduledPriceNotPending):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrInvalidPriceSchedule):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
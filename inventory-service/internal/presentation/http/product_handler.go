@@ -0,0 +1,452 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/usecase"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+)
+
+type ProductHandler struct {
+	createProductUseCase    *usecase.CreateProductUseCase
+	createVariantUseCase    *usecase.CreateVariantUseCase
+	getProductUseCase       *usecase.GetProductUseCase
+	listProductsUseCase     *usecase.ListProductsUseCase
+	updateProductUseCase    *usecase.UpdateProductUseCase
+	deleteProductUseCase    *usecase.DeleteProductUseCase
+	setProductActiveUseCase *usecase.SetProductActiveUseCase
+	adjustStockUseCase      *usecase.AdjustStockUseCase
+	listMovementsUseCase    *usecase.ListStockMovementsUseCase
+	getStockLevelsUseCase   *usecase.GetStockLevelsUseCase
+	lowStockReportUseCase   *usecase.GetLowStockReportUseCase
+}
+
+func NewProductHandler(
+	createProductUseCase *usecase.CreateProductUseCase,
+	getProductUseCase *usecase.GetProductUseCase,
+	listProductsUseCase *usecase.ListProductsUseCase,
+	updateProductUseCase *usecase.UpdateProductUseCase,
+	deleteProductUseCase *usecase.DeleteProductUseCase,
+	setProductActiveUseCase *usecase.SetProductActiveUseCase,
+	adjustStockUseCase *usecase.AdjustStockUseCase,
+	listMovementsUseCase *usecase.ListStockMovementsUseCase,
+	getStockLevelsUseCase *usecase.GetStockLevelsUseCase,
+	lowStockReportUseCase *usecase.GetLowStockReportUseCase,
+	createVariantUseCase *usecase.CreateVariantUseCase,
+) *ProductHandler {
+	return &ProductHandler{
+		createProductUseCase:    createProductUseCase,
+		getProductUseCase:       getProductUseCase,
+		listProductsUseCase:     listProductsUseCase,
+		updateProductUseCase:    updateProductUseCase,
+		deleteProductUseCase:    deleteProductUseCase,
+		setProductActiveUseCase: setProductActiveUseCase,
+		adjustStockUseCase:      adjustStockUseCase,
+		listMovementsUseCase:    listMovementsUseCase,
+		getStockLevelsUseCase:   getStockLevelsUseCase,
+		lowStockReportUseCase:   lowStockReportUseCase,
+		createVariantUseCase:    createVariantUseCase,
+	}
+}
+
+// CreateProduct handles product creation
+// @Summary Create a product
+// @Description Adds a new product to the catalog
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateProductRequest true "Product details"
+// @Success 201 {object} dto.ProductResponse
+// @Failure 400 {object} map[string]string "Invalid request or validation error"
+// @Failure 409 {object} map[string]string "SKU already in use or no active warehouse for the initial stock"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products [post]
+func (h *ProductHandler) CreateProduct(c *gin.Context) {
+	var req dto.CreateProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	product, err := h.createProductUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		respondWithProductError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, product)
+}
+
+// CreateVariant handles adding a variant to a product
+// @Summary Create a product variant
+// @Description Adds a variant with its own SKU, options, price and stock to a product that holds no stock
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Parent product ID"
+// @Param request body dto.CreateVariantRequest true "Variant details"
+// @Success 201 {object} dto.ProductResponse
+// @Failure 400 {object} map[string]string "Invalid request or validation error"
+// @Failure 404 {object} map[string]string "Parent product not found"
+// @Failure 409 {object} map[string]string "SKU or options already in use, or the parent holds stock or is a variant"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products/{id}/variants [post]
+func (h *ProductHandler) CreateVariant(c *gin.Context) {
+	productID, ok := productIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req dto.CreateVariantRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	variant, err := h.createVariantUseCase.Execute(c.Request.Context(), productID, req)
+	if err != nil {
+		respondWithProductError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, variant)
+}
+
+// ListProducts handles paginated product listing
+// @Summary List products
+// @Description Returns a page of products, newest first, each with its variants
+// @Tags products
+// @Produce json
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param offset query int false "Number of products to skip" default(0)
+// @Param include_inactive query bool false "Include deactivated products"
+// @Success 200 {object} dto.ProductListResponse
+// @Failure 400 {object} map[string]string "Invalid pagination parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products [get]
+func (h *ProductHandler) ListProducts(c *gin.Context) {
+	var query dto.ListProductsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	products, err := h.listProductsUseCase.Execute(c.Request.Context(), query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, products)
+}
+
+// GetLowStockReport handles the low stock report
+// @Summary Low stock report
+// @Description Returns a page of active products whose available stock is at or below their reorder threshold, furthest below it first
+// @Tags products
+// @Produce json
+// @Param limit query int false "Page size (1-500)" default(50)
+// @Param offset query int false "Number of products to skip" default(0)
+// @Success 200 {object} dto.LowStockReportResponse
+// @Failure 400 {object} map[string]string "Invalid pagination parameters"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products/low-stock [get]
+func (h *ProductHandler) GetLowStockReport(c *gin.Context) {
+	var query dto.LowStockReportQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.lowStockReportUseCase.Execute(c.Request.Context(), query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetProduct handles fetching a single product
+// @Summary Get a product
+// @Description Returns a product with its stock levels
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} dto.ProductResponse
+// @Failure 400 {object} map[string]string "Invalid product ID"
+// @Failure 404 {object} map[string]string "Product not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products/{id} [get]
+func (h *ProductHandler) GetProduct(c *gin.Context) {
+	productID, ok := productIDParam(c)
+	if !ok {
+		return
+	}
+
+	product, err := h.getProductUseCase.Execute(c.Request.Context(), productID)
+	if err != nil {
+		respondWithProductError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, product)
+}
+
+// UpdateProduct handles partial product updates
+// @Summary Update a product
+// @Description Updates the name, description, price, SKU or, for a variant, the options of a product
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param request body dto.UpdateProductRequest true "Fields to update"
+// @Success 200 {object} dto.ProductResponse
+// @Failure 400 {object} map[string]string "Invalid request or validation error"
+// @Failure 404 {object} map[string]string "Product not found"
+// @Failure 409 {object} map[string]string "Product was modified by another request, or SKU or options already in use"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products/{id} [patch]
+func (h *ProductHandler) UpdateProduct(c *gin.Context) {
+	productID, ok := productIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateProductRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	product, err := h.updateProductUseCase.Execute(c.Request.Context(), productID, req)
+	if err != nil {
+		respondWithProductError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, product)
+}
+
+// DeleteProduct handles product removal
+// @Summary Delete a product
+// @Description Removes a product from the catalog; it is kept for existing orders
+// @Tags products
+// @Param id path string true "Product ID"
+// @Success 204
+// @Failure 400 {object} map[string]string "Invalid product ID"
+// @Failure 404 {object} map[string]string "Product not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products/{id} [delete]
+func (h *ProductHandler) DeleteProduct(c *gin.Context) {
+	productID, ok := productIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.deleteProductUseCase.Execute(c.Request.Context(), productID); err != nil {
+		respondWithProductError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ActivateProduct handles product activation
+// @Summary Activate a product
+// @Description Makes a product available for new orders
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} dto.ProductResponse
+// @Failure 400 {object} map[string]string "Invalid product ID"
+// @Failure 404 {object} map[string]string "Product not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products/{id}/activate [post]
+func (h *ProductHandler) ActivateProduct(c *gin.Context) {
+	h.setProductActive(c, true)
+}
+
+// DeactivateProduct handles product deactivation
+// @Summary Deactivate a product
+// @Description Stops new orders for a product, existing reservations are kept
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} dto.ProductResponse
+// @Failure 400 {object} map[string]string "Invalid product ID"
+// @Failure 404 {object} map[string]string "Product not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products/{id}/deactivate [post]
+func (h *ProductHandler) DeactivateProduct(c *gin.Context) {
+	h.setProductActive(c, false)
+}
+
+func (h *ProductHandler) setProductActive(c *gin.Context, active bool) {
+	productID, ok := productIDParam(c)
+	if !ok {
+		return
+	}
+
+	product, err := h.setProductActiveUseCase.Execute(c.Request.Context(), productID, active)
+	if err != nil {
+		respondWithProductError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, product)
+}
+
+// AdjustStock handles manual stock adjustments
+// @Summary Adjust stock
+// @Description Adds received stock or removes stock and records it in the stock ledger; added stock is allocated to backorders first
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param request body dto.AdjustStockRequest true "Stock adjustment"
+// @Success 200 {object} dto.StockAdjustmentResponse
+// @Failure 400 {object} map[string]string "Invalid request or validation error"
+// @Failure 404 {object} map[string]string "Product or warehouse not found"
+// @Failure 409 {object} map[string]string "Not enough unreserved stock to remove, or warehouse not active"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products/{id}/stock [post]
+func (h *ProductHandler) AdjustStock(c *gin.Context) {
+	productID, ok := productIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req dto.AdjustStockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.adjustStockUseCase.Execute(c.Request.Context(), productID, entity.ActorAPI, req)
+	if err != nil {
+		respondWithProductError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ListStockMovements handles querying the stock ledger of a product
+// @Summary List stock movements
+// @Description Returns the receipts, adjustments, reservations, releases and sales of a product in a time range, newest first
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param from query string false "Start of the range (RFC 3339), defaults to 30 days before to"
+// @Param to query string false "End of the range (RFC 3339, exclusive), defaults to now"
+// @Param limit query int false "Page size (1-500)" default(50)
+// @Param offset query int false "Number of movements to skip" default(0)
+// @Success 200 {object} dto.StockMovementListResponse
+// @Failure 400 {object} map[string]string "Invalid product ID or query parameters"
+// @Failure 404 {object} map[string]string "Product not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products/{id}/movements [get]
+func (h *ProductHandler) ListStockMovements(c *gin.Context) {
+	productID, ok := productIDParam(c)
+	if !ok {
+		return
+	}
+
+	var query dto.ListStockMovementsQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	movements, err := h.listMovementsUseCase.Execute(c.Request.Context(), productID, query)
+	if err != nil {
+		respondWithProductError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, movements)
+}
+
+// GetStockLevels handles the per-warehouse stock breakdown of a product
+// @Summary Get stock levels
+// @Description Returns the product's stock, reserved and available quantities in every warehouse holding it
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} dto.StockLevelsResponse
+// @Failure 400 {object} map[string]string "Invalid product ID"
+// @Failure 404 {object} map[string]string "Product not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/products/{id}/stock-levels [get]
+func (h *ProductHandler) GetStockLevels(c *gin.Context) {
+	productID, ok := productIDParam(c)
+	if !ok {
+		return
+	}
+
+	levels, err := h.getStockLevelsUseCase.Execute(c.Request.Context(), productID)
+	if err != nil {
+		respondWithProductError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, levels)
+}
+
+// Health check endpoint
+// @Summary Health check
+// @Description Check if the inventory service is running
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string
+// @Router /health [get]
+func (h *ProductHandler) Health(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "healthy",
+		"service": "inventory-service",
+	})
+}
+
+// productIDParam reads and validates the product ID path parameter
+func productIDParam(c *gin.Context) (string, bool) {
+	productID := c.Param("id")
+	if _, err := uuid.Parse(productID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid product ID format"})
+		return "", false
+	}
+	return productID, true
+}
+
+// respondWithProductError maps domain errors to HTTP status codes
+func respondWithProductError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrProductNotFound),
+		errors.Is(err, entity.ErrWarehouseNotFound),
+		errors.Is(err, entity.ErrCategoryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrConcurrentModification),
+		errors.Is(err, entity.ErrStaleVersion),
+		errors.Is(err, entity.ErrInsufficientStock),
+		errors.Is(err, entity.ErrWarehouseNotActive),
+		errors.Is(err, entity.ErrNoActiveWarehouse),
+		errors.Is(err, entity.ErrSKUTaken),
+		errors.Is(err, entity.ErrDuplicateVariantOptions),
+		errors.Is(err, entity.ErrProductHasVariants),
+		errors.Is(err, entity.ErrParentHoldsStock),
+		errors.Is(err, entity.ErrVariantOfVariant):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrInvalidQuantity),
+		errors.Is(err, entity.ErrNegativeReceipt),
+		errors.Is(err, entity.ErrInvalidTimeRange),
+		errors.Is(err, entity.ErrOptionsRequireParent),
+		errors.Is(err, entity.ErrMissingVariantOptions):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,210 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/usecase"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/repository"
+)
+
+const testProductID = "6f1c2b8e-3d4a-4f5b-9c6d-7e8f9a0b1c2d"
+
+// fakeInventoryRepository serves a single product, or the configured errors
+type fakeInventoryRepository struct {
+	repository.InventoryRepository
+	product   *entity.Product
+	getErr    error
+	updateErr error
+}
+
+func (r *fakeInventoryRepository) GetByID(ctx context.Context, id string) (*entity.Product, error) {
+	if r.getErr != nil {
+		return nil, r.getErr
+	}
+	if r.product == nil || r.product.ID != id {
+		return nil, entity.ErrProductNotFound
+	}
+	product := *r.product
+	return &product, nil
+}
+
+func (r *fakeInventoryRepository) GetVariants(ctx context.Context, parentIDs []string, includeInactive bool) (map[string][]*entity.Product, error) {
+	return map[string][]*entity.Product{}, nil
+}
+
+func (r *fakeInventoryRepository) Update(ctx context.Context, product *entity.Product) error {
+	return r.updateErr
+}
+
+type fakeAttributeRepository struct {
+	repository.AttributeRepository
+}
+
+func (r *fakeAttributeRepository) GetProductAttributes(ctx context.Context, productID string) ([]entity.ProductAttribute, error) {
+	return nil, nil
+}
+
+// newTestRouter routes the product endpoints to h without authentication
+func newTestRouter(h *ProductHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/products", h.CreateProduct)
+	router.GET("/products", h.ListProducts)
+	router.GET("/products/:id", h.GetProduct)
+	router.PATCH("/products/:id", h.UpdateProduct)
+	router.DELETE("/products/:id", h.DeleteProduct)
+	router.POST("/products/:id/stock", h.AdjustStock)
+	router.POST("/products/:id/variants", h.CreateVariant)
+	router.GET("/products/:id/stock-levels", h.GetStockLevels)
+	return router
+}
+
+func serve(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestProductHandlerRejectsInvalidRequests(t *testing.T) {
+	// Invalid requests never reach the use cases, so the handler needs none
+	router := newTestRouter(&ProductHandler{})
+	product := "/products/" + testProductID
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{name: "create without name", method: http.MethodPost, path: "/products", body: `{"price": 9.99}`},
+		{name: "create without price", method: http.MethodPost, path: "/products", body: `{"name": "Mug"}`},
+		{name: "create with negative price", method: http.MethodPost, path: "/products", body: `{"name": "Mug", "price": -1}`},
+		{name: "create with negative stock", method: http.MethodPost, path: "/products", body: `{"name": "Mug", "price": 9.99, "stock_quantity": -5}`},
+		{name: "create with negative reorder threshold", method: http.MethodPost, path: "/products", body: `{"name": "Mug", "price": 9.99, "reorder_threshold": -1}`},
+		{name: "create with invalid category ID", method: http.MethodPost, path: "/products", body: `{"name": "Mug", "price": 9.99, "category_id": "kitchen"}`},
+		{name: "create with malformed JSON", method: http.MethodPost, path: "/products", body: `{"name": "Mug",`},
+		{name: "list with limit above 100", method: http.MethodGet, path: "/products?limit=101"},
+		{name: "list with negative offset", method: http.MethodGet, path: "/products?offset=-1"},
+		{name: "get with invalid ID", method: http.MethodGet, path: "/products/not-a-uuid"},
+		{name: "update with invalid ID", method: http.MethodPatch, path: "/products/not-a-uuid", body: `{"name": "Mug"}`},
+		{name: "update with empty name", method: http.MethodPatch, path: product, body: `{"name": ""}`},
+		{name: "update with negative price", method: http.MethodPatch, path: product, body: `{"price": -1}`},
+		{name: "update with zero version", method: http.MethodPatch, path: product, body: `{"version": 0}`},
+		{name: "delete with invalid ID", method: http.MethodDelete, path: "/products/not-a-uuid"},
+		{name: "adjust stock by zero", method: http.MethodPost, path: product + "/stock", body: `{"delta": 0, "reason": "count"}`},
+		{name: "adjust stock without reason", method: http.MethodPost, path: product + "/stock", body: `{"delta": 5}`},
+		{name: "adjust stock with unknown type", method: http.MethodPost, path: product + "/stock", body: `{"delta": 5, "reason": "count", "type": "sale"}`},
+		{name: "adjust stock with invalid warehouse ID", method: http.MethodPost, path: product + "/stock", body: `{"delta": 5, "reason": "count", "warehouse_id": "main"}`},
+		{name: "variant without options", method: http.MethodPost, path: product + "/variants", body: `{"sku": "MUG-L"}`},
+		{name: "variant with negative stock", method: http.MethodPost, path: product + "/variants", body: `{"options": {"size": "L"}, "stock_quantity": -1}`},
+		{name: "stock levels with invalid ID", method: http.MethodGet, path: "/products/not-a-uuid/stock-levels"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(router, tt.method, tt.path, tt.body)
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("%s %s = %d, want 400: %s", tt.method, tt.path, rec.Code, rec.Body)
+			}
+			var body map[string]string
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body["error"] == "" {
+				t.Errorf("body = %s, want an error message", rec.Body)
+			}
+		})
+	}
+}
+
+func TestProductHandlerStatusCodes(t *testing.T) {
+	stored := &entity.Product{ID: testProductID, Name: "Mug", Price: 9.99, StockQuantity: 10, Version: 3, IsActive: true}
+
+	tests := []struct {
+		name     string
+		repo     *fakeInventoryRepository
+		method   string
+		path     string
+		body     string
+		wantCode int
+	}{
+		{name: "get", repo: &fakeInventoryRepository{product: stored}, method: http.MethodGet, path: "/products/" + testProductID, wantCode: http.StatusOK},
+		{name: "get unknown product", repo: &fakeInventoryRepository{}, method: http.MethodGet, path: "/products/" + testProductID, wantCode: http.StatusNotFound},
+		{name: "get failing repository", repo: &fakeInventoryRepository{getErr: errors.New("connection refused")}, method: http.MethodGet, path: "/products/" + testProductID, wantCode: http.StatusInternalServerError},
+		{name: "update unknown product", repo: &fakeInventoryRepository{}, method: http.MethodPatch, path: "/products/" + testProductID, body: `{"name": "Cup"}`, wantCode: http.StatusNotFound},
+		{name: "update stale version", repo: &fakeInventoryRepository{product: stored}, method: http.MethodPatch, path: "/products/" + testProductID, body: `{"name": "Cup", "version": 2}`, wantCode: http.StatusConflict},
+		{name: "update to a taken SKU", repo: &fakeInventoryRepository{product: stored, updateErr: entity.ErrSKUTaken}, method: http.MethodPatch, path: "/products/" + testProductID, body: `{"sku": "CUP-1"}`, wantCode: http.StatusConflict},
+		{name: "update options of a product", repo: &fakeInventoryRepository{product: stored}, method: http.MethodPatch, path: "/products/" + testProductID, body: `{"options": {"size": "L"}}`, wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newTestRouter(&ProductHandler{
+				getProductUseCase:    usecase.NewGetProductUseCase(tt.repo, &fakeAttributeRepository{}),
+				updateProductUseCase: usecase.NewUpdateProductUseCase(tt.repo, nil, nil),
+			})
+
+			rec := serve(router, tt.method, tt.path, tt.body)
+			if rec.Code != tt.wantCode {
+				t.Fatalf("%s %s = %d, want %d: %s", tt.method, tt.path, rec.Code, tt.wantCode, rec.Body)
+			}
+			if tt.wantCode == http.StatusOK {
+				var product struct {
+					ID string `json:"id"`
+				}
+				if err := json.Unmarshal(rec.Body.Bytes(), &product); err != nil || product.ID != testProductID {
+					t.Errorf("body = %s, want product %s", rec.Body, testProductID)
+				}
+			}
+		})
+	}
+}
+
+func TestRespondWithProductError(t *testing.T) {
+	tests := []struct {
+		err      error
+		wantCode int
+	}{
+		{err: entity.ErrProductNotFound, wantCode: http.StatusNotFound},
+		{err: entity.ErrWarehouseNotFound, wantCode: http.StatusNotFound},
+		{err: fmt.Errorf("%w: c-1", entity.ErrCategoryNotFound), wantCode: http.StatusNotFound},
+		{err: entity.ErrConcurrentModification, wantCode: http.StatusConflict},
+		{err: entity.ErrStaleVersion, wantCode: http.StatusConflict},
+		{err: fmt.Errorf("failed to remove stock: %w", entity.ErrInsufficientStock), wantCode: http.StatusConflict},
+		{err: entity.ErrWarehouseNotActive, wantCode: http.StatusConflict},
+		{err: entity.ErrNoActiveWarehouse, wantCode: http.StatusConflict},
+		{err: fmt.Errorf("failed to update product: %w", entity.ErrSKUTaken), wantCode: http.StatusConflict},
+		{err: entity.ErrDuplicateVariantOptions, wantCode: http.StatusConflict},
+		{err: entity.ErrProductHasVariants, wantCode: http.StatusConflict},
+		{err: entity.ErrParentHoldsStock, wantCode: http.StatusConflict},
+		{err: entity.ErrVariantOfVariant, wantCode: http.StatusConflict},
+		{err: entity.ErrInvalidQuantity, wantCode: http.StatusBadRequest},
+		{err: entity.ErrNegativeReceipt, wantCode: http.StatusBadRequest},
+		{err: entity.ErrInvalidTimeRange, wantCode: http.StatusBadRequest},
+		{err: entity.ErrOptionsRequireParent, wantCode: http.StatusBadRequest},
+		{err: entity.ErrMissingVariantOptions, wantCode: http.StatusBadRequest},
+		{err: errors.New("connection refused"), wantCode: http.StatusInternalServerError},
+	}
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(rec)
+
+			respondWithProductError(c, tt.err)
+			if rec.Code != tt.wantCode {
+				t.Errorf("respondWithProductError(%v) = %d, want %d", tt.err, rec.Code, tt.wantCode)
+			}
+			var body map[string]string
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body["error"] != tt.err.Error() {
+				t.Errorf("body = %s, want the error message", rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/auth"
+)
+
+// SetupRouter configures all routes. Reading the catalog is public, changing
+// it and everything about stock requires a permission.
+func SetupRouter(productHandler *ProductHandler, bulkHandler *BulkHandler, warehouseHandler *WarehouseHandler, catalogHandler *CatalogHandler, priceHandler *PriceHandler, adminHandler *AdminHandler, authMiddleware gin.HandlerFunc) *gin.Engine {
+	router := gin.Default()
+
+	canWriteProducts := auth.RequirePermission(auth.PermissionProductsWrite)
+	canWritePrices := auth.RequirePermission(auth.PermissionPricesWrite)
+	canWriteCatalog := auth.RequirePermission(auth.PermissionCatalogWrite)
+	canReadStock := auth.RequirePermission(auth.PermissionStockRead)
+	canWriteStock := auth.RequirePermission(auth.PermissionStockWrite)
+	canWriteWarehouses := auth.RequirePermission(auth.PermissionWarehousesWrite)
+
+	// Health check
+	router.GET("/health", productHandler.Health)
+
+	// API v1 routes
+	v1 := router.Group("/api/v1")
+	{
+		products := v1.Group("/products")
+		{
+			products.POST("", authMiddleware, canWriteProducts, productHandler.CreateProduct)                                        // POST /api/v1/products
+			products.GET("", productHandler.ListProducts)                                                                            // GET /api/v1/products
+			products.GET("/low-stock", authMiddleware, canReadStock, productHandler.GetLowStockReport)                               // GET /api/v1/products/low-stock
+			products.POST("/import", authMiddleware, canWriteProducts, bulkHandler.ImportProducts)                                   // POST /api/v1/products/import
+			products.GET("/export", authMiddleware, canReadStock, bulkHandler.ExportProducts)                                        // GET /api/v1/products/export
+			products.GET("/search", catalogHandler.SearchProducts)                                                                   // GET /api/v1/products/search
+			products.GET("/:id", productHandler.GetProduct)                                                                          // GET /api/v1/products/:id
+			products.PATCH("/:id", authMiddleware, canWriteProducts, productHandler.UpdateProduct)                                   // PATCH /api/v1/products/:id
+			products.DELETE("/:id", authMiddleware, canWriteProducts, productHandler.DeleteProduct)                                  // DELETE /api/v1/products/:id
+			products.POST("/:id/activate", authMiddleware, canWriteProducts, productHandler.ActivateProduct)                         // POST /api/v1/products/:id/activate
+			products.POST("/:id/deactivate", authMiddleware, canWriteProducts, productHandler.DeactivateProduct)                     // POST /api/v1/products/:id/deactivate
+			products.POST("/:id/stock", authMiddleware, canWriteStock, productHandler.AdjustStock)                                   // POST /api/v1/products/:id/stock
+			products.POST("/:id/variants", authMiddleware, canWriteProducts, productHandler.CreateVariant)                           // POST /api/v1/products/:id/variants
+			products.PUT("/:id/attributes", authMiddleware, canWriteCatalog, catalogHandler.SetProductAttributes)                    // PUT /api/v1/products/:id/attributes
+			products.GET("/:id/movements", authMiddleware, canReadStock, productHandler.ListStockMovements)                          // GET /api/v1/products/:id/movements
+			products.GET("/:id/stock-levels", authMiddleware, canReadStock, productHandler.GetStockLevels)                           // GET /api/v1/products/:id/stock-levels
+			products.GET("/:id/price", priceHandler.GetPrice)                                                                        // GET /api/v1/products/:id/price
+			products.GET("/:id/price-history", priceHandler.ListPriceHistory)                                                        // GET /api/v1/products/:id/price-history
+			products.POST("/:id/scheduled-prices", authMiddleware, canWritePrices, priceHandler.SchedulePrice)                       // POST /api/v1/products/:id/scheduled-prices
+			products.GET("/:id/scheduled-prices", authMiddleware, canWritePrices, priceHandler.ListScheduledPrices)                  // GET /api/v1/products/:id/scheduled-prices
+			products.DELETE("/:id/scheduled-prices/:schedule_id", authMiddleware, canWritePrices, priceHandler.CancelScheduledPrice) // DELETE /api/v1/products/:id/scheduled-prices/:schedule_id
+		}
+
+		warehouses := v1.Group("/warehouses")
+		{
+			warehouses.POST("", authMiddleware, canWriteWarehouses, warehouseHandler.CreateWarehouse)      // POST /api/v1/warehouses
+			warehouses.GET("", authMiddleware, canReadStock, warehouseHandler.ListWarehouses)              // GET /api/v1/warehouses
+			warehouses.PATCH("/:id", authMiddleware, canWriteWarehouses, warehouseHandler.UpdateWarehouse) // PATCH /api/v1/warehouses/:id
+		}
+
+		categories := v1.Group("/categories")
+		{
+			categories.POST("", authMiddleware, canWriteCatalog, catalogHandler.CreateCategory)      // POST /api/v1/categories
+			categories.GET("", catalogHandler.ListCategories)                                        // GET /api/v1/categories
+			categories.PATCH("/:id", authMiddleware, canWriteCatalog, catalogHandler.UpdateCategory) // PATCH /api/v1/categories/:id
+		}
+
+		attributes := v1.Group("/attributes")
+		{
+			attributes.POST("", authMiddleware, canWriteCatalog, catalogHandler.CreateAttribute) // POST /api/v1/attributes
+			attributes.GET("", catalogHandler.ListAttributes)                                    // GET /api/v1/attributes
+		}
+
+		admin := v1.Group("/admin")
+		{
+			admin.POST("/reconciliation", authMiddleware, canWriteStock, adminHandler.ReconcileStock) // POST /api/v1/admin/reconciliation
+		}
+	}
+
+	return router
+}
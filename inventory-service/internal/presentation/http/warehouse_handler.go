@@ -0,0 +1,126 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/application/usecase"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/inventory-service/internal/domain/entity"
+)
+
+type WarehouseHandler struct {
+	createWarehouseUseCase *usecase.CreateWarehouseUseCase
+	listWarehousesUseCase  *usecase.ListWarehousesUseCase
+	updateWarehouseUseCase *usecase.UpdateWarehouseUseCase
+}
+
+func NewWarehouseHandler(
+	createWarehouseUseCase *usecase.CreateWarehouseUseCase,
+	listWarehousesUseCase *usecase.ListWarehousesUseCase,
+	updateWarehouseUseCase *usecase.UpdateWarehouseUseCase,
+) *WarehouseHandler {
+	return &WarehouseHandler{
+		createWarehouseUseCase: createWarehouseUseCase,
+		listWarehousesUseCase:  listWarehousesUseCase,
+		updateWarehouseUseCase: updateWarehouseUseCase,
+	}
+}
+
+// CreateWarehouse handles warehouse creation
+// @Summary Create a warehouse
+// @Description Adds a stock location. Lower priority values are preferred when allocating stock.
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateWarehouseRequest true "Warehouse details"
+// @Success 201 {object} dto.WarehouseResponse
+// @Failure 400 {object} map[string]string "Invalid request or validation error"
+// @Failure 409 {object} map[string]string "Warehouse code already in use"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/warehouses [post]
+func (h *WarehouseHandler) CreateWarehouse(c *gin.Context) {
+	var req dto.CreateWarehouseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	warehouse, err := h.createWarehouseUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		respondWithWarehouseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, warehouse)
+}
+
+// ListWarehouses handles warehouse listing
+// @Summary List warehouses
+// @Description Returns all warehouses in allocation order
+// @Tags warehouses
+// @Produce json
+// @Success 200 {object} dto.WarehouseListResponse
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/warehouses [get]
+func (h *WarehouseHandler) ListWarehouses(c *gin.Context) {
+	warehouses, err := h.listWarehousesUseCase.Execute(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, warehouses)
+}
+
+// UpdateWarehouse handles partial warehouse updates
+// @Summary Update a warehouse
+// @Description Updates the given fields. Inactive warehouses keep their stock but are not allocated from.
+// @Tags warehouses
+// @Accept json
+// @Produce json
+// @Param id path string true "Warehouse ID"
+// @Param request body dto.UpdateWarehouseRequest true "Fields to update"
+// @Success 200 {object} dto.WarehouseResponse
+// @Failure 400 {object} map[string]string "Invalid request or validation error"
+// @Failure 404 {object} map[string]string "Warehouse not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/warehouses/{id} [patch]
+func (h *WarehouseHandler) UpdateWarehouse(c *gin.Context) {
+	warehouseID := c.Param("id")
+	if _, err := uuid.Parse(warehouseID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid warehouse ID format"})
+		return
+	}
+
+	var req dto.UpdateWarehouseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	warehouse, err := h.updateWarehouseUseCase.Execute(c.Request.Context(), warehouseID, req)
+	if err != nil {
+		respondWithWarehouseError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, warehouse)
+}
+
+// respondWithWarehouseError maps domain errors to HTTP status codes
+func respondWithWarehouseError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrWarehouseNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrWarehouseCodeTaken):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrIncompleteLocation),
+		errors.Is(err, entity.ErrInvalidLocationRange):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,20 @@
+-- Create backorders table for order lines waiting for stock to arrive
+CREATE TABLE IF NOT EXISTS backorders (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL,
+    correlation_id UUID NOT NULL,
+    product_id UUID NOT NULL REFERENCES products(id),
+    quantity INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_backorder_quantity CHECK (quantity >= 0)
+    );
+
+-- Create index for allocating arriving stock to the oldest backorders first
+CREATE INDEX IF NOT EXISTS idx_backorders_product_pending ON backorders(product_id, created_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_backorders_order_id ON backorders(order_id);
+
+-- Create trigger to automatically update updated_at
+CREATE TRIGGER update_backorders_updated_at BEFORE UPDATE ON backorders
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,26 @@
+-- Create stock_movements ledger recording every change to stock_quantity and reserved_stock
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id),
+    movement_type VARCHAR(20) NOT NULL,
+    stock_delta INTEGER NOT NULL DEFAULT 0,
+    reserved_delta INTEGER NOT NULL DEFAULT 0,
+    reason TEXT NOT NULL DEFAULT '',
+    order_id UUID,
+    correlation_id UUID,
+    actor VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_movement_type CHECK (movement_type IN ('receipt', 'adjustment', 'reservation', 'release', 'sale'))
+    );
+
+-- Create index for querying the movements of a product over time
+CREATE INDEX IF NOT EXISTS idx_stock_movements_product_created ON stock_movements(product_id, created_at);
+-- Create index for finding what is still reserved for an order
+CREATE INDEX IF NOT EXISTS idx_stock_movements_order_id ON stock_movements(order_id) WHERE order_id IS NOT NULL;
+
+-- Record opening balances so the ledger reconciles with stock levels from before it existed
+INSERT INTO stock_movements (id, product_id, movement_type, stock_delta, reserved_delta, reason, actor)
+SELECT gen_random_uuid(), p.id, 'adjustment', p.stock_quantity, p.reserved_stock, 'opening balance', 'system'
+FROM products p
+WHERE (p.stock_quantity <> 0 OR p.reserved_stock <> 0)
+  AND NOT EXISTS (SELECT 1 FROM stock_movements m WHERE m.product_id = p.id);
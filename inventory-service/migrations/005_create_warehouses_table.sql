@@ -0,0 +1,53 @@
+-- Create warehouses table
+CREATE TABLE IF NOT EXISTS warehouses (
+    id UUID PRIMARY KEY,
+    code VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    priority INTEGER NOT NULL DEFAULT 0,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_warehouse_location CHECK ((latitude IS NULL) = (longitude IS NULL))
+    );
+
+CREATE TRIGGER update_warehouses_updated_at BEFORE UPDATE ON warehouses
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create warehouse_stock table holding each product's stock per warehouse.
+-- products.stock_quantity and reserved_stock remain the totals over all warehouses.
+CREATE TABLE IF NOT EXISTS warehouse_stock (
+    warehouse_id UUID NOT NULL REFERENCES warehouses(id),
+    product_id UUID NOT NULL REFERENCES products(id),
+    stock_quantity INTEGER NOT NULL DEFAULT 0,
+    reserved_stock INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (warehouse_id, product_id),
+    CONSTRAINT check_warehouse_reserved_stock CHECK (reserved_stock >= 0 AND reserved_stock <= stock_quantity)
+    );
+
+-- Create index for finding a product's stock in every warehouse
+CREATE INDEX IF NOT EXISTS idx_warehouse_stock_product_id ON warehouse_stock(product_id);
+
+CREATE TRIGGER update_warehouse_stock_updated_at BEFORE UPDATE ON warehouse_stock
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Record which warehouse every ledger entry applies to
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS warehouse_id UUID REFERENCES warehouses(id);
+
+-- Move existing stock into a main warehouse
+INSERT INTO warehouses (id, code, name, priority)
+VALUES (gen_random_uuid(), 'main', 'Main warehouse', 0)
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO warehouse_stock (warehouse_id, product_id, stock_quantity, reserved_stock)
+SELECT w.id, p.id, p.stock_quantity, p.reserved_stock
+FROM products p
+CROSS JOIN warehouses w
+WHERE w.code = 'main'
+ON CONFLICT (warehouse_id, product_id) DO NOTHING;
+
+UPDATE stock_movements
+SET warehouse_id = (SELECT id FROM warehouses WHERE code = 'main')
+WHERE warehouse_id IS NULL;
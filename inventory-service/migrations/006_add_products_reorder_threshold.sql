@@ -0,0 +1,14 @@
+-- Add reorder threshold and the last alerted stock status to products
+ALTER TABLE products ADD COLUMN IF NOT EXISTS reorder_threshold INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN IF NOT EXISTS stock_status VARCHAR(20) NOT NULL DEFAULT 'in_stock';
+
+-- Start from the current stock levels so existing products do not raise alerts on their next change
+UPDATE products
+SET stock_status = CASE
+    WHEN stock_quantity - reserved_stock <= 0 THEN 'out_of_stock'
+    WHEN stock_quantity - reserved_stock <= reorder_threshold THEN 'low_stock'
+    ELSE 'in_stock'
+END;
+
+-- Create index for the low stock report
+CREATE INDEX IF NOT EXISTS idx_products_low_stock ON products((stock_quantity - reserved_stock - reorder_threshold)) WHERE is_active = true;
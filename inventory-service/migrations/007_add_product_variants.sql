@@ -0,0 +1,12 @@
+-- Let products be variants of a parent product. Stock is always held by the
+-- product that is sold, a standalone product or a variant, never by a parent.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES products(id);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS sku VARCHAR(64);
+ALTER TABLE products ADD COLUMN IF NOT EXISTS options JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- Create indexes for SKU lookups and for grouping variants under their parent
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_sku ON products(sku) WHERE sku IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_products_parent_id ON products(parent_id) WHERE parent_id IS NOT NULL;
+
+-- Two variants of the same product cannot have the same options
+CREATE UNIQUE INDEX IF NOT EXISTS idx_products_parent_options ON products(parent_id, options) WHERE parent_id IS NOT NULL;
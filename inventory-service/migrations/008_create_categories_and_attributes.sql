@@ -0,0 +1,47 @@
+-- Create categories table, a category may be nested under a parent category
+CREATE TABLE IF NOT EXISTS categories (
+    id UUID PRIMARY KEY,
+    parent_id UUID REFERENCES categories(id),
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_category_not_own_parent CHECK (parent_id IS NULL OR parent_id <> id)
+    );
+
+CREATE TRIGGER update_categories_updated_at BEFORE UPDATE ON categories
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories(parent_id);
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS category_id UUID REFERENCES categories(id);
+CREATE INDEX IF NOT EXISTS idx_products_category_id ON products(category_id) WHERE category_id IS NOT NULL;
+
+-- Create attributes table defining the typed attributes products can have
+CREATE TABLE IF NOT EXISTS attributes (
+    id UUID PRIMARY KEY,
+    code VARCHAR(64) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    type VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_attribute_type CHECK (type IN ('text', 'number', 'boolean'))
+    );
+
+-- Create product_attributes table. Values are stored in the canonical text
+-- form of their attribute's type, so filters and facets compare them as text.
+CREATE TABLE IF NOT EXISTS product_attributes (
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    attribute_id UUID NOT NULL REFERENCES attributes(id),
+    value VARCHAR(255) NOT NULL,
+    PRIMARY KEY (product_id, attribute_id)
+    );
+
+-- Create index for filtering and faceting products by attribute value
+CREATE INDEX IF NOT EXISTS idx_product_attributes_value ON product_attributes(attribute_id, value);
+
+-- Create full-text index over product names and descriptions. Searches must
+-- use the same expression for the index to apply.
+CREATE INDEX IF NOT EXISTS idx_products_search ON products USING GIN ((
+    setweight(to_tsvector('english', name), 'A') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'B')
+));
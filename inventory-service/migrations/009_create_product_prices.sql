@@ -0,0 +1,47 @@
+-- Create product_price_history table holding every price a product has had.
+-- The open row, without effective_to, is the product's current price.
+CREATE TABLE IF NOT EXISTS product_price_history (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    price DECIMAL(10,2) NOT NULL,
+    effective_from TIMESTAMP NOT NULL,
+    effective_to TIMESTAMP,
+    source VARCHAR(20) NOT NULL,
+    schedule_id UUID,
+    CONSTRAINT check_price_history_range CHECK (effective_to IS NULL OR effective_to >= effective_from)
+    );
+
+-- Create indexes for the price at a point in time and for the current price
+CREATE INDEX IF NOT EXISTS idx_product_price_history_product ON product_price_history(product_id, effective_from DESC);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_price_history_open ON product_price_history(product_id) WHERE effective_to IS NULL;
+
+-- Start the history of existing products at their current price
+INSERT INTO product_price_history (id, product_id, price, effective_from, source)
+SELECT gen_random_uuid(), p.id, p.price, p.created_at, 'created'
+FROM products p
+WHERE NOT EXISTS (SELECT 1 FROM product_price_history h WHERE h.product_id = p.id);
+
+-- Create scheduled_prices table for future price changes. A change with an
+-- end restores the price it replaced once it ends, e.g. a sale.
+CREATE TABLE IF NOT EXISTS scheduled_prices (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    price DECIMAL(10,2) NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP,
+    previous_price DECIMAL(10,2),
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT check_scheduled_price_range CHECK (ends_at IS NULL OR ends_at > starts_at),
+    CONSTRAINT check_scheduled_price_status CHECK (status IN ('scheduled', 'active', 'completed', 'cancelled'))
+    );
+
+CREATE TRIGGER update_scheduled_prices_updated_at BEFORE UPDATE ON scheduled_prices
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Create indexes for listing a product's schedule and finding due changes
+CREATE INDEX IF NOT EXISTS idx_scheduled_prices_product ON scheduled_prices(product_id, starts_at);
+CREATE INDEX IF NOT EXISTS idx_scheduled_prices_due ON scheduled_prices(starts_at) WHERE status = 'scheduled';
+CREATE INDEX IF NOT EXISTS idx_scheduled_prices_ending ON scheduled_prices(ends_at) WHERE status = 'active';
@@ -1,13 +1,18 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"time"
 
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/application/usecase"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/domain/repository"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/infrastructure/config"
+	infraMessaging "github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/infrastructure/messaging"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/infrastructure/persistence"
 	httpHandler "github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/presentation/http"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/auth"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/messaging"
 )
 
@@ -49,17 +54,67 @@ func main() {
 		log.Fatalf("Failed to create event publisher: %v", err)
 	}
 
-	// Initialize repository
+	// Initialize repositories
 	orderRepo := persistence.NewPostgresOrderRepository(db)
+	idempotencyRepo := persistence.NewPostgresIdempotencyRepository(db)
 
-	// Initialize use case
-	createOrderUseCase := usecase.NewCreateOrderUseCase(orderRepo, eventPublisher)
+	// Idempotency keys expire after a configurable window
+	idempotencyTTL := getDurationEnv("IDEMPOTENCY_KEY_TTL", 24*time.Hour)
+	go purgeExpiredIdempotencyKeys(idempotencyRepo, getDurationEnv("IDEMPOTENCY_CLEANUP_INTERVAL", time.Hour))
+
+	// Initialize use cases
+	createOrderUseCase := usecase.NewCreateOrderUseCase(orderRepo, idempotencyRepo, eventPublisher, idempotencyTTL)
+	getOrderUseCase := usecase.NewGetOrderUseCase(orderRepo)
+	updateOrderStatusUseCase := usecase.NewUpdateOrderStatusUseCase(orderRepo, eventPublisher)
+	getStatusHistoryUseCase := usecase.NewGetOrderStatusHistoryUseCase(orderRepo)
+	applyReservationUseCase := usecase.NewApplyInventoryReservationUseCase(orderRepo)
+	fulfillBackorderUseCase := usecase.NewFulfillBackorderUseCase(orderRepo)
+
+	// Consume inventory outcomes for the order saga
+	consumer, err := messaging.NewEventConsumer(
+		rabbitConn,
+		"ecommerce-events", // exchange name
+		"order-service",    // queue name
+		"inventory.*",      // routing key
+	)
+	if err != nil {
+		log.Fatalf("Failed to create event consumer: %v", err)
+	}
+
+	inventoryEventConsumer := infraMessaging.NewInventoryEventConsumer(
+		consumer,
+		applyReservationUseCase,
+		fulfillBackorderUseCase,
+		updateOrderStatusUseCase,
+	)
+	if err := inventoryEventConsumer.Start(); err != nil {
+		log.Fatalf("Failed to start event consumer: %v", err)
+	}
 
 	// Initialize HTTP handler
-	orderHandler := httpHandler.NewOrderHandler(createOrderUseCase)
+	orderHandler := httpHandler.NewOrderHandler(
+		createOrderUseCase,
+		getOrderUseCase,
+		updateOrderStatusUseCase,
+		getStatusHistoryUseCase,
+	)
+
+	// Verify access tokens issued by the user service
+	tokenVerifier, err := auth.NewVerifier(auth.Config{
+		JWKSURL: os.Getenv("JWKS_URL"),
+		Secret:  os.Getenv("JWT_SECRET"),
+		Issuer:  "user-service",
+	})
+	if err != nil {
+		log.Fatalf("Failed to create token verifier: %v", err)
+	}
 
 	// Setup router
-	router := httpHandler.SetupRouter(orderHandler)
+	router := httpHandler.SetupRouter(
+		orderHandler,
+		auth.Middleware(tokenVerifier),
+		getEnv("REQUIRE_VERIFIED_EMAIL", "false") == "true",
+	)
 
 	// Start server
 	port := getEnv("PORT", "8082")
@@ -77,3 +132,29 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+// getDurationEnv gets a duration environment variable or returns default value
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// purgeExpiredIdempotencyKeys periodically deletes idempotency keys past their expiry
+func purgeExpiredIdempotencyKeys(repo repository.IdempotencyRepository, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		deleted, err := repo.DeleteExpired(context.Background(), time.Now().UTC())
+		if err != nil {
+			log.Printf("Warning: failed to purge expired idempotency keys: %v", err)
+			continue
+		}
+		if deleted > 0 {
+			log.Printf("Purged %d expired idempotency keys", deleted)
+		}
+	}
+}
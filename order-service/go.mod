@@ -43,3 +43,5 @@ require (
 	golang.org/x/tools v0.34.0 // indirect
 	google.golang.org/protobuf v1.36.9 // indirect
 )
+
+replace github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared => ../shared
@@ -4,32 +4,70 @@ import "time"
 
 // CreateOrderRequest represents the request to create a new order
 type CreateOrderRequest struct {
-	UserID string             `json:"user_id" binding:"required"`
+	// UserID is the authenticated user placing the order, never read from the body
+	UserID string             `json:"-"`
 	Items  []OrderItemRequest `json:"items" binding:"required,min=1,dive"`
+	// FulfillmentPolicy decides what happens when items are out of stock, defaults to all_or_nothing
+	FulfillmentPolicy string `json:"fulfillment_policy,omitempty" binding:"omitempty,oneof=all_or_nothing allow_partial backorder"`
+	// ShippingLocation lets inventory ship from the warehouse nearest to the customer
+	ShippingLocation *LocationRequest `json:"shipping_location,omitempty"`
+}
+
+// LocationRequest represents a point in decimal degrees
+type LocationRequest struct {
+	Latitude  *float64 `json:"latitude" binding:"required,min=-90,max=90"`
+	Longitude *float64 `json:"longitude" binding:"required,min=-180,max=180"`
 }
 
 // OrderItemRequest represents a single item in the order request
 type OrderItemRequest struct {
-	ProductID string  `json:"product_id" binding:"required"`
+	// ProductID is the product holding the stock, for a product with variants
+	// the variant of the chosen SKU
+	ProductID string  `json:"product_id" binding:"required,uuid"`
 	Quantity  int     `json:"quantity" binding:"required,min=1"`
 	Price     float64 `json:"price" binding:"required,gt=0"`
 }
 
 // OrderResponse represents the order data returned to the client
 type OrderResponse struct {
-	ID            string              `json:"id"`
-	UserID        string              `json:"user_id"`
-	Status        string              `json:"status"`
-	TotalAmount   float64             `json:"total_amount"`
-	Items         []OrderItemResponse `json:"items"`
-	CorrelationID string              `json:"correlation_id"`
-	CreatedAt     time.Time           `json:"created_at"`
+	ID                string              `json:"id"`
+	UserID            string              `json:"user_id"`
+	Status            string              `json:"status"`
+	TotalAmount       float64             `json:"total_amount"`
+	Items             []OrderItemResponse `json:"items"`
+	CorrelationID     string              `json:"correlation_id"`
+	CreatedAt         time.Time           `json:"created_at"`
+	FulfillmentPolicy string              `json:"fulfillment_policy"`
 }
 
 // OrderItemResponse represents a single item in the order response
 type OrderItemResponse struct {
-	ID        string  `json:"id"`
-	ProductID string  `json:"product_id"`
-	Quantity  int     `json:"quantity"`
-	Price     float64 `json:"price"`
+	ID                  string  `json:"id"`
+	ProductID           string  `json:"product_id"`
+	Quantity            int     `json:"quantity"`
+	Price               float64 `json:"price"`
+	RequestedQuantity   int     `json:"requested_quantity"`
+	ReservedQuantity    int     `json:"reserved_quantity"`
+	BackorderedQuantity int     `json:"backordered_quantity"`
+	SKU                 string  `json:"sku,omitempty"`
+}
+
+// CancelOrderRequest represents the request to cancel an order
+type CancelOrderRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// OrderStatusChangeResponse represents a single status history entry
+type OrderStatusChangeResponse struct {
+	FromStatus string    `json:"from_status,omitempty"`
+	ToStatus   string    `json:"to_status"`
+	Actor      string    `json:"actor"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// OrderStatusHistoryResponse represents the status history of an order
+type OrderStatusHistoryResponse struct {
+	OrderID string                      `json:"order_id"`
+	History []OrderStatusChangeResponse `json:"history"`
 }
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/domain/repository"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/events"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/retry"
+)
+
+type ApplyInventoryReservationUseCase struct {
+	orderRepo repository.OrderRepository
+}
+
+// NewApplyInventoryReservationUseCase creates a new ApplyInventoryReservationUseCase
+func NewApplyInventoryReservationUseCase(orderRepo repository.OrderRepository) *ApplyInventoryReservationUseCase {
+	return &ApplyInventoryReservationUseCase{
+		orderRepo: orderRepo,
+	}
+}
+
+// Execute moves the order to processing and records the per-line outcome of
+// the reservation, shrinking lines inventory could only partially reserve
+func (uc *ApplyInventoryReservationUseCase) Execute(ctx context.Context, event events.InventoryReservedEvent) error {
+	lines := make([]entity.ReservationLine, len(event.Reservations))
+	for i, reservation := range event.Reservations {
+		lines[i] = entity.ReservationLine{
+			ProductID:   reservation.ProductID,
+			SKU:         reservation.SKU,
+			Reserved:    reservation.Quantity,
+			Backordered: reservation.BackorderedQuantity,
+		}
+	}
+
+	return retry.OnConflict(ctx, entity.ErrConcurrentModification, func() error {
+		order, err := uc.orderRepo.GetByID(ctx, event.OrderID)
+		if err != nil {
+			return err
+		}
+
+		change, err := order.MarkAsProcessing(entity.ActorInventoryService, reservationReason(event.Reservations))
+		if err != nil {
+			return err
+		}
+		change.ID = uuid.New().String()
+
+		if err := order.ApplyReservation(lines); err != nil {
+			return err
+		}
+
+		if err := uc.orderRepo.UpdateFulfillment(ctx, order, change); err != nil {
+			return fmt.Errorf("failed to apply inventory reservation: %w", err)
+		}
+		return nil
+	})
+}
+
+// reservationReason summarises the reservation for the status history
+func reservationReason(reservations []events.InventoryReservation) string {
+	var partial, backordered int
+	for _, reservation := range reservations {
+		switch reservation.Status {
+		case events.ReservationStatusPartiallyReserved, events.ReservationStatusUnavailable:
+			partial++
+		case events.ReservationStatusBackordered:
+			backordered++
+		}
+	}
+
+	switch {
+	case backordered > 0:
+		return fmt.Sprintf("stock reserved, %d item(s) backordered", backordered)
+	case partial > 0:
+		return fmt.Sprintf("stock partially reserved, %d item(s) short", partial)
+	default:
+		return "stock reserved"
+	}
+}
@@ -2,6 +2,10 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -14,55 +18,161 @@ import (
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/messaging"
 )
 
+// eventPublisher publishes events, implemented by *messaging.EventPublisher
+type eventPublisher interface {
+	Publish(routingKey string, event interface{}) error
+}
+
 type CreateOrderUseCase struct {
-	orderRepo      repository.OrderRepository
-	eventPublisher *messaging.EventPublisher
+	orderRepo       repository.OrderRepository
+	idempotencyRepo repository.IdempotencyRepository
+	eventPublisher  eventPublisher
+	idempotencyTTL  time.Duration
 }
 
 // NewCreateOrderUseCase creates a new CreateOrderUseCase
 func NewCreateOrderUseCase(
 	orderRepo repository.OrderRepository,
+	idempotencyRepo repository.IdempotencyRepository,
 	eventPublisher *messaging.EventPublisher,
+	idempotencyTTL time.Duration,
 ) *CreateOrderUseCase {
 	return &CreateOrderUseCase{
-		orderRepo:      orderRepo,
-		eventPublisher: eventPublisher,
+		orderRepo:       orderRepo,
+		idempotencyRepo: idempotencyRepo,
+		eventPublisher:  eventPublisher,
+		idempotencyTTL:  idempotencyTTL,
+	}
+}
+
+// Execute creates an order. When an idempotency key is given, retries of the
+// same user with the same key and body return the original response instead
+// of creating a new order.
+func (uc *CreateOrderUseCase) Execute(ctx context.Context, req dto.CreateOrderRequest, idempotencyKey string) (*dto.OrderResponse, error) {
+	if idempotencyKey == "" {
+		return uc.createOrder(ctx, req, nil)
+	}
+
+	requestHash, err := hashRequest(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash request: %w", err)
+	}
+
+	now := time.Now().UTC()
+	key := &entity.IdempotencyKey{
+		UserID:      req.UserID,
+		Key:         idempotencyKey,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(uc.idempotencyTTL),
+	}
+	reserved, err := uc.idempotencyRepo.Reserve(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !reserved {
+		return uc.replay(ctx, req.UserID, idempotencyKey, requestHash)
+	}
+
+	// The response is stored with the order in one transaction, so the key
+	// either gets the response of the created order or no order exists
+	response, err := uc.createOrder(ctx, req, key)
+	if err != nil {
+		// Release the key so the client can retry the failed request
+		if delErr := uc.idempotencyRepo.Delete(ctx, req.UserID, idempotencyKey); delErr != nil {
+			log.Printf("Warning: failed to release idempotency key %s: %v", idempotencyKey, delErr)
+		}
+		return nil, err
 	}
+
+	return response, nil
 }
 
-func (uc *CreateOrderUseCase) Execute(ctx context.Context, req dto.CreateOrderRequest) (*dto.OrderResponse, error) {
+// replay returns the stored response of a request of the user that already used the key
+func (uc *CreateOrderUseCase) replay(ctx context.Context, userID, idempotencyKey, requestHash string) (*dto.OrderResponse, error) {
+	existing, err := uc.idempotencyRepo.Get(ctx, userID, idempotencyKey)
+	if err != nil {
+		if errors.Is(err, entity.ErrIdempotencyKeyNotFound) {
+			// The original request failed and released the key in the meantime
+			return nil, entity.ErrIdempotencyKeyInProgress
+		}
+		return nil, err
+	}
+
+	if !existing.Matches(requestHash) {
+		return nil, entity.ErrIdempotencyKeyMismatch
+	}
+	if !existing.HasResponse() {
+		return nil, entity.ErrIdempotencyKeyInProgress
+	}
+
+	var response dto.OrderResponse
+	if err := json.Unmarshal(existing.ResponseBody, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal stored order response: %w", err)
+	}
+	return &response, nil
+}
+
+// createOrder stores and announces the order. When idempotencyKey is not nil,
+// the response is stored on it in the same transaction as the order.
+func (uc *CreateOrderUseCase) createOrder(ctx context.Context, req dto.CreateOrderRequest, idempotencyKey *entity.IdempotencyKey) (*dto.OrderResponse, error) {
 	// 1. Generate IDs
 	orderID := uuid.New().String()
 	correlationID := uuid.New().String()
 
+	policy, err := entity.ParseFulfillmentPolicy(req.FulfillmentPolicy)
+	if err != nil {
+		return nil, err
+	}
+
 	// 2. Convert request items to entity items
 	items := make([]entity.OrderItem, len(req.Items))
 	for i, item := range req.Items {
 		items[i] = entity.OrderItem{
-			ID:        uuid.New().String(),
-			OrderID:   orderID,        // ← Fixed
-			ProductID: item.ProductID, // ← Fixed
-			Quantity:  item.Quantity,
-			Price:     item.Price,
+			ID:                uuid.New().String(),
+			OrderID:           orderID,        // ← Fixed
+			ProductID:         item.ProductID, // ← Fixed
+			Quantity:          item.Quantity,
+			Price:             item.Price,
+			RequestedQuantity: item.Quantity,
 		}
 	}
 
 	// 3. Create order entity
 	order := &entity.Order{
-		ID:            orderID,
-		UserID:        req.UserID,
-		Status:        entity.OrderStatusPending,
-		Items:         items,
-		CorrelationID: correlationID,
-		CreatedAt:     time.Now().UTC(),
-		UpdatedAt:     time.Now().UTC(),
+		ID:                orderID,
+		UserID:            req.UserID,
+		Status:            entity.OrderStatusPending,
+		Items:             items,
+		CorrelationID:     correlationID,
+		CreatedAt:         time.Now().UTC(),
+		UpdatedAt:         time.Now().UTC(),
+		Version:           1,
+		FulfillmentPolicy: policy,
 	}
 
 	// 4. Calculate total
 	order.CalculateTotal()
 
-	// 5. Save to database
-	err := uc.orderRepo.Create(ctx, order)
+	// 5. Save to database together with the initial status history entry
+	initialStatus := &entity.OrderStatusChange{
+		ID:        uuid.New().String(),
+		OrderID:   orderID,
+		ToStatus:  order.Status,
+		Actor:     entity.UserActor(req.UserID),
+		Reason:    "order created",
+		CreatedAt: order.CreatedAt,
+	}
+	response := toOrderResponse(order)
+	if idempotencyKey != nil {
+		body, err := json.Marshal(response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal order response: %w", err)
+		}
+		idempotencyKey.OrderID = orderID
+		idempotencyKey.ResponseBody = body
+	}
+	err = uc.orderRepo.Create(ctx, order, initialStatus, idempotencyKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
@@ -74,10 +184,17 @@ func (uc *CreateOrderUseCase) Execute(ctx context.Context, req dto.CreateOrderRe
 			orderID,
 			correlationID,
 		),
-		OrderID:     orderID,
-		UserID:      req.UserID,
-		TotalAmount: order.TotalAmount,
-		Items:       convertToEventItems(req.Items),
+		OrderID:           orderID,
+		UserID:            req.UserID,
+		TotalAmount:       order.TotalAmount,
+		Items:             convertToEventItems(req.Items),
+		FulfillmentPolicy: string(policy),
+	}
+	if req.ShippingLocation != nil {
+		event.ShippingLocation = &events.GeoLocation{
+			Latitude:  *req.ShippingLocation.Latitude,
+			Longitude: *req.ShippingLocation.Longitude,
+		}
 	}
 
 	err = uc.eventPublisher.Publish("order.created", event)
@@ -86,26 +203,21 @@ func (uc *CreateOrderUseCase) Execute(ctx context.Context, req dto.CreateOrderRe
 		log.Printf("Warning: failed to publish OrderCreatedEvent: %v", err) // ← Fixed
 	}
 
-	// 7. Convert to response DTO
-	return &dto.OrderResponse{
-		ID:            order.ID,
-		UserID:        order.UserID,
-		Status:        string(order.Status),
-		TotalAmount:   order.TotalAmount,
-		Items:         convertToResponseItems(order.Items),
-		CorrelationID: order.CorrelationID,
-		CreatedAt:     order.CreatedAt,
-	}, nil
+	return response, nil
 }
 
 func convertToResponseItems(items []entity.OrderItem) []dto.OrderItemResponse {
 	orderItems := make([]dto.OrderItemResponse, len(items))
 	for i, item := range items {
 		orderItems[i] = dto.OrderItemResponse{
-			ID:        item.ID, // ← Fixed
-			ProductID: item.ProductID,
-			Quantity:  item.Quantity,
-			Price:     item.Price,
+			ID:                  item.ID, // ← Fixed
+			ProductID:           item.ProductID,
+			Quantity:            item.Quantity,
+			Price:               item.Price,
+			RequestedQuantity:   item.RequestedQuantity,
+			ReservedQuantity:    item.ReservedQuantity,
+			BackorderedQuantity: item.BackorderedQuantity,
+			SKU:                 item.SKU,
 		}
 	}
 	return orderItems
@@ -123,3 +235,13 @@ func convertToEventItems(items []dto.OrderItemRequest) []events.OrderItem {
 	}
 	return orderItems
 }
+
+// Helper: Hash the request body so key reuse with a different body can be detected
+func hashRequest(req dto.CreateOrderRequest) (string, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/domain/repository"
+)
+
+// memoryIdempotencyRepository keeps keys in memory, scoped to the user like the postgres repository
+type memoryIdempotencyRepository struct {
+	keys map[[2]string]*entity.IdempotencyKey
+}
+
+func newMemoryIdempotencyRepository(keys ...*entity.IdempotencyKey) *memoryIdempotencyRepository {
+	repo := &memoryIdempotencyRepository{keys: make(map[[2]string]*entity.IdempotencyKey)}
+	for _, key := range keys {
+		repo.keys[[2]string{key.UserID, key.Key}] = key
+	}
+	return repo
+}
+
+func (r *memoryIdempotencyRepository) Reserve(ctx context.Context, key *entity.IdempotencyKey) (bool, error) {
+	id := [2]string{key.UserID, key.Key}
+	if existing, ok := r.keys[id]; ok && !existing.IsExpired(key.CreatedAt) {
+		return false, nil
+	}
+	stored := *key
+	r.keys[id] = &stored
+	return true, nil
+}
+
+func (r *memoryIdempotencyRepository) Get(ctx context.Context, userID, key string) (*entity.IdempotencyKey, error) {
+	existing, ok := r.keys[[2]string{userID, key}]
+	if !ok {
+		return nil, entity.ErrIdempotencyKeyNotFound
+	}
+	return existing, nil
+}
+
+func (r *memoryIdempotencyRepository) Delete(ctx context.Context, userID, key string) error {
+	delete(r.keys, [2]string{userID, key})
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+// memoryOrderRepository stores orders in memory. Like the postgres repository it
+// saves the idempotency response together with the order, failing the whole
+// write while failures is above zero.
+type memoryOrderRepository struct {
+	repository.OrderRepository
+	idempotencyRepo *memoryIdempotencyRepository
+	orders          []*entity.Order
+	failures        int
+}
+
+func (r *memoryOrderRepository) Create(ctx context.Context, order *entity.Order, change *entity.OrderStatusChange, idempotencyKey *entity.IdempotencyKey) error {
+	if r.failures > 0 {
+		r.failures--
+		return errors.New("could not save idempotency response: connection reset")
+	}
+	if idempotencyKey != nil {
+		existing, ok := r.idempotencyRepo.keys[[2]string{idempotencyKey.UserID, idempotencyKey.Key}]
+		if !ok {
+			return entity.ErrIdempotencyKeyNotFound
+		}
+		existing.OrderID = idempotencyKey.OrderID
+		existing.ResponseBody = idempotencyKey.ResponseBody
+	}
+	r.orders = append(r.orders, order)
+	return nil
+}
+
+type recordingPublisher struct {
+	routingKeys []string
+}
+
+func (p *recordingPublisher) Publish(routingKey string, event interface{}) error {
+	p.routingKeys = append(p.routingKeys, routingKey)
+	return nil
+}
+
+func TestHashRequest(t *testing.T) {
+	req := dto.CreateOrderRequest{
+		UserID: "u-1",
+		Items:  []dto.OrderItemRequest{{ProductID: "a", Quantity: 2, Price: 10}},
+	}
+	hash, err := hashRequest(req)
+	if err != nil {
+		t.Fatalf("hashRequest() error = %v", err)
+	}
+
+	// The user is not part of the body, keys are scoped to the user instead
+	same := req
+	same.UserID = "u-2"
+	if got, _ := hashRequest(same); got != hash {
+		t.Errorf("hashRequest() changed with the user: %s != %s", got, hash)
+	}
+
+	changed := req
+	changed.Items = []dto.OrderItemRequest{{ProductID: "a", Quantity: 3, Price: 10}}
+	if got, _ := hashRequest(changed); got == hash {
+		t.Error("hashRequest() did not change with the items")
+	}
+}
+
+func TestCreateOrderReplay(t *testing.T) {
+	req := dto.CreateOrderRequest{
+		UserID: "u-1",
+		Items:  []dto.OrderItemRequest{{ProductID: "a", Quantity: 2, Price: 10}},
+	}
+	requestHash, err := hashRequest(req)
+	if err != nil {
+		t.Fatalf("hashRequest() error = %v", err)
+	}
+	stored := dto.OrderResponse{ID: "o-1", UserID: "u-1", Status: "pending", TotalAmount: 20}
+	body, err := json.Marshal(stored)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	expiresAt := time.Now().UTC().Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		key     *entity.IdempotencyKey
+		req     dto.CreateOrderRequest
+		wantErr error
+	}{
+		{
+			name: "same request returns the stored response",
+			key:  &entity.IdempotencyKey{UserID: "u-1", Key: "k", RequestHash: requestHash, OrderID: "o-1", ResponseBody: body, ExpiresAt: expiresAt},
+			req:  req,
+		},
+		{
+			name:    "different body with the same key",
+			key:     &entity.IdempotencyKey{UserID: "u-1", Key: "k", RequestHash: "other", OrderID: "o-1", ResponseBody: body, ExpiresAt: expiresAt},
+			req:     req,
+			wantErr: entity.ErrIdempotencyKeyMismatch,
+		},
+		{
+			name:    "original request still running",
+			key:     &entity.IdempotencyKey{UserID: "u-1", Key: "k", RequestHash: requestHash, ExpiresAt: expiresAt},
+			req:     req,
+			wantErr: entity.ErrIdempotencyKeyInProgress,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := &CreateOrderUseCase{
+				idempotencyRepo: newMemoryIdempotencyRepository(tt.key),
+				idempotencyTTL:  time.Hour,
+			}
+
+			response, err := uc.Execute(context.Background(), tt.req, "k")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (response == nil || response.ID != stored.ID || response.TotalAmount != stored.TotalAmount) {
+				t.Errorf("Execute() = %+v, want the stored response %+v", response, stored)
+			}
+		})
+	}
+}
+
+func TestCreateOrderReplayReleasedKey(t *testing.T) {
+	// The original request failed and released the key between Reserve and Get
+	uc := &CreateOrderUseCase{idempotencyRepo: newMemoryIdempotencyRepository()}
+
+	if _, err := uc.replay(context.Background(), "u-1", "k", "hash"); !errors.Is(err, entity.ErrIdempotencyKeyInProgress) {
+		t.Errorf("replay() error = %v, want ErrIdempotencyKeyInProgress", err)
+	}
+}
+
+func TestCreateOrderRetryAfterFailedSave(t *testing.T) {
+	req := dto.CreateOrderRequest{
+		UserID: "u-1",
+		Items:  []dto.OrderItemRequest{{ProductID: "a", Quantity: 2, Price: 10}},
+	}
+	idempotencyRepo := newMemoryIdempotencyRepository()
+	orderRepo := &memoryOrderRepository{idempotencyRepo: idempotencyRepo, failures: 1}
+	publisher := &recordingPublisher{}
+	uc := &CreateOrderUseCase{
+		orderRepo:       orderRepo,
+		idempotencyRepo: idempotencyRepo,
+		eventPublisher:  publisher,
+		idempotencyTTL:  time.Hour,
+	}
+
+	// Saving the order and its response fails, nothing is created and the key is released
+	if _, err := uc.Execute(context.Background(), req, "k"); err == nil {
+		t.Fatal("Execute() error = nil, want the save error")
+	}
+	if len(orderRepo.orders) != 0 || len(publisher.routingKeys) != 0 {
+		t.Fatalf("failed attempt stored %d orders and published %v", len(orderRepo.orders), publisher.routingKeys)
+	}
+	if _, err := idempotencyRepo.Get(context.Background(), "u-1", "k"); !errors.Is(err, entity.ErrIdempotencyKeyNotFound) {
+		t.Fatalf("key after failed attempt: error = %v, want ErrIdempotencyKeyNotFound", err)
+	}
+
+	// The retry creates the order and stores its response with it
+	created, err := uc.Execute(context.Background(), req, "k")
+	if err != nil {
+		t.Fatalf("retry: Execute() error = %v", err)
+	}
+	if len(orderRepo.orders) != 1 || orderRepo.orders[0].ID != created.ID {
+		t.Fatalf("retry stored %d orders, want the created order %s", len(orderRepo.orders), created.ID)
+	}
+	stored, err := idempotencyRepo.Get(context.Background(), "u-1", "k")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if stored.OrderID != created.ID || !stored.HasResponse() {
+		t.Fatalf("stored key = %+v, want the response of order %s", stored, created.ID)
+	}
+
+	// Another retry replays the response without creating a second order
+	replayed, err := uc.Execute(context.Background(), req, "k")
+	if err != nil {
+		t.Fatalf("replay: Execute() error = %v", err)
+	}
+	if replayed.ID != created.ID || replayed.TotalAmount != created.TotalAmount {
+		t.Errorf("replay = %+v, want %+v", replayed, created)
+	}
+	if len(orderRepo.orders) != 1 || len(publisher.routingKeys) != 1 {
+		t.Errorf("after replay: %d orders and %d events, want 1 and 1", len(orderRepo.orders), len(publisher.routingKeys))
+	}
+}
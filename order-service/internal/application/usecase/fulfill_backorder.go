@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/domain/repository"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/events"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/retry"
+)
+
+type FulfillBackorderUseCase struct {
+	orderRepo repository.OrderRepository
+}
+
+// NewFulfillBackorderUseCase creates a new FulfillBackorderUseCase
+func NewFulfillBackorderUseCase(orderRepo repository.OrderRepository) *FulfillBackorderUseCase {
+	return &FulfillBackorderUseCase{
+		orderRepo: orderRepo,
+	}
+}
+
+// Execute marks the stock inventory allocated to a backordered line as reserved
+func (uc *FulfillBackorderUseCase) Execute(ctx context.Context, event events.InventoryBackorderFulfilledEvent) error {
+	return retry.OnConflict(ctx, entity.ErrConcurrentModification, func() error {
+		order, err := uc.orderRepo.GetByID(ctx, event.OrderID)
+		if err != nil {
+			return err
+		}
+
+		if err := order.FulfillBackorder(event.ProductID, event.Quantity); err != nil {
+			return err
+		}
+
+		if err := uc.orderRepo.UpdateFulfillment(ctx, order, nil); err != nil {
+			return fmt.Errorf("failed to fulfill backorder: %w", err)
+		}
+		return nil
+	})
+}
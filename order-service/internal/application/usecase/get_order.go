@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/domain/repository"
+)
+
+type GetOrderUseCase struct {
+	orderRepo repository.OrderRepository
+}
+
+// NewGetOrderUseCase creates a new GetOrderUseCase
+func NewGetOrderUseCase(orderRepo repository.OrderRepository) *GetOrderUseCase {
+	return &GetOrderUseCase{
+		orderRepo: orderRepo,
+	}
+}
+
+func (uc *GetOrderUseCase) Execute(ctx context.Context, orderID string) (*dto.OrderResponse, error) {
+	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return toOrderResponse(order), nil
+}
+
+func toOrderResponse(order *entity.Order) *dto.OrderResponse {
+	return &dto.OrderResponse{
+		ID:                order.ID,
+		UserID:            order.UserID,
+		Status:            string(order.Status),
+		TotalAmount:       order.TotalAmount,
+		Items:             convertToResponseItems(order.Items),
+		CorrelationID:     order.CorrelationID,
+		CreatedAt:         order.CreatedAt,
+		FulfillmentPolicy: string(order.FulfillmentPolicy),
+	}
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/domain/repository"
+)
+
+type GetOrderStatusHistoryUseCase struct {
+	orderRepo repository.OrderRepository
+}
+
+// NewGetOrderStatusHistoryUseCase creates a new GetOrderStatusHistoryUseCase
+func NewGetOrderStatusHistoryUseCase(orderRepo repository.OrderRepository) *GetOrderStatusHistoryUseCase {
+	return &GetOrderStatusHistoryUseCase{
+		orderRepo: orderRepo,
+	}
+}
+
+func (uc *GetOrderStatusHistoryUseCase) Execute(ctx context.Context, orderID string) (*dto.OrderStatusHistoryResponse, error) {
+	// Make sure the order exists so unknown IDs are reported as not found
+	if _, err := uc.orderRepo.GetByID(ctx, orderID); err != nil {
+		return nil, err
+	}
+
+	history, err := uc.orderRepo.GetStatusHistory(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order status history: %w", err)
+	}
+
+	response := &dto.OrderStatusHistoryResponse{
+		OrderID: orderID,
+		History: make([]dto.OrderStatusChangeResponse, len(history)),
+	}
+	for i, change := range history {
+		response.History[i] = dto.OrderStatusChangeResponse{
+			FromStatus: string(change.FromStatus),
+			ToStatus:   string(change.ToStatus),
+			Actor:      change.Actor,
+			Reason:     change.Reason,
+			CreatedAt:  change.CreatedAt,
+		}
+	}
+	return response, nil
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/domain/repository"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/events"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/messaging"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/retry"
+)
+
+type UpdateOrderStatusUseCase struct {
+	orderRepo      repository.OrderRepository
+	eventPublisher *messaging.EventPublisher
+}
+
+// NewUpdateOrderStatusUseCase creates a new UpdateOrderStatusUseCase
+func NewUpdateOrderStatusUseCase(orderRepo repository.OrderRepository, eventPublisher *messaging.EventPublisher) *UpdateOrderStatusUseCase {
+	return &UpdateOrderStatusUseCase{
+		orderRepo:      orderRepo,
+		eventPublisher: eventPublisher,
+	}
+}
+
+// Execute moves the order to the given status, rejecting transitions the
+// order state machine does not allow, and records who made the change and why
+func (uc *UpdateOrderStatusUseCase) Execute(
+	ctx context.Context,
+	orderID string,
+	status entity.OrderStatus,
+	actor, reason string,
+) (*dto.OrderResponse, error) {
+	var order *entity.Order
+
+	// A saga handler may update the same order concurrently, re-read it and
+	// re-check the transition when the version check fails
+	err := retry.OnConflict(ctx, entity.ErrConcurrentModification, func() error {
+		var err error
+		order, err = uc.orderRepo.GetByID(ctx, orderID)
+		if err != nil {
+			return err
+		}
+
+		change, err := order.TransitionTo(status, actor, reason)
+		if err != nil {
+			return err
+		}
+		change.ID = uuid.New().String()
+
+		if err := uc.orderRepo.UpdateStatus(ctx, order, change); err != nil {
+			return fmt.Errorf("failed to update order status: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.publishStatusEvent(order, reason)
+
+	return toOrderResponse(order), nil
+}
+
+// publishStatusEvent announces final statuses so inventory can release or sell the order's stock
+func (uc *UpdateOrderStatusUseCase) publishStatusEvent(order *entity.Order, reason string) {
+	routingKey, event, ok := statusEvent(order, reason)
+	if !ok {
+		return
+	}
+
+	if err := uc.eventPublisher.Publish(routingKey, event); err != nil {
+		// Log but don't fail, the status change is already committed
+		log.Printf("Warning: failed to publish %s event for order %s: %v", routingKey, order.ID, err)
+	}
+}
+
+// statusEvent builds the event announcing the order's current status,
+// ok is false for statuses nothing downstream waits for
+func statusEvent(order *entity.Order, reason string) (routingKey string, event interface{}, ok bool) {
+	switch order.Status {
+	case entity.OrderStatusCompleted:
+		routingKey = events.OrderCompletedEventType
+		event = events.OrderCompletedEvent{
+			BaseEvent: events.NewBaseEvent(routingKey, order.ID, order.CorrelationID),
+			OrderID:   order.ID,
+			UserID:    order.UserID,
+		}
+	case entity.OrderStatusFailed:
+		routingKey = events.OrderFailedEventType
+		event = events.OrderFailedEvent{
+			BaseEvent: events.NewBaseEvent(routingKey, order.ID, order.CorrelationID),
+			OrderID:   order.ID,
+			UserID:    order.UserID,
+			Reason:    reason,
+		}
+	case entity.OrderStatusCancelled:
+		routingKey = events.OrderCancelledEventType
+		event = events.OrderCancelledEvent{
+			BaseEvent: events.NewBaseEvent(routingKey, order.ID, order.CorrelationID),
+			OrderID:   order.ID,
+			UserID:    order.UserID,
+			Reason:    reason,
+		}
+	default:
+		return "", nil, false
+	}
+	return routingKey, event, true
+}
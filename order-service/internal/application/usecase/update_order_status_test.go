@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/events"
+)
+
+func TestStatusEvent(t *testing.T) {
+	tests := []struct {
+		status         entity.OrderStatus
+		wantRoutingKey string
+		wantReason     string
+	}{
+		{status: entity.OrderStatusPending},
+		{status: entity.OrderStatusProcessing},
+		{status: entity.OrderStatusCompleted, wantRoutingKey: events.OrderCompletedEventType},
+		{status: entity.OrderStatusFailed, wantRoutingKey: events.OrderFailedEventType, wantReason: "out of stock"},
+		{status: entity.OrderStatusCancelled, wantRoutingKey: events.OrderCancelledEventType, wantReason: "out of stock"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			order := &entity.Order{ID: "o-1", UserID: "u-1", Status: tt.status, CorrelationID: "c-1"}
+
+			routingKey, event, ok := statusEvent(order, "out of stock")
+			if ok != (tt.wantRoutingKey != "") || routingKey != tt.wantRoutingKey {
+				t.Fatalf("statusEvent() = %q, %v, want %q", routingKey, ok, tt.wantRoutingKey)
+			}
+			if !ok {
+				return
+			}
+
+			var (
+				base            events.BaseEvent
+				orderID, userID string
+				reason          string
+			)
+			switch e := event.(type) {
+			case events.OrderCompletedEvent:
+				base, orderID, userID = e.BaseEvent, e.OrderID, e.UserID
+			case events.OrderFailedEvent:
+				base, orderID, userID, reason = e.BaseEvent, e.OrderID, e.UserID, e.Reason
+			case events.OrderCancelledEvent:
+				base, orderID, userID, reason = e.BaseEvent, e.OrderID, e.UserID, e.Reason
+			default:
+				t.Fatalf("statusEvent() event = %T", event)
+			}
+			if base.EventType != routingKey || base.AggregateID != "o-1" || base.CorrelationID != "c-1" {
+				t.Errorf("base event = %+v", base)
+			}
+			if orderID != "o-1" || userID != "u-1" || reason != tt.wantReason {
+				t.Errorf("event = %+v", event)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrUnknownFulfillmentPolicy = errors.New("unknown fulfillment policy")
+	ErrOrderItemNotFound        = errors.New("order has no matching item")
+	ErrOrderClosed              = errors.New("order is already closed")
+	ErrOrderHasBackorders       = errors.New("order still has backordered items")
+)
+
+// FulfillmentPolicy decides what happens when inventory cannot reserve every item
+type FulfillmentPolicy string
+
+const (
+	// FulfillmentPolicyAllOrNothing fails the order if any item is short
+	FulfillmentPolicyAllOrNothing FulfillmentPolicy = "all_or_nothing"
+	// FulfillmentPolicyAllowPartial ships what is available and drops the rest
+	FulfillmentPolicyAllowPartial FulfillmentPolicy = "allow_partial"
+	// FulfillmentPolicyBackorder ships what is available and waits for the rest
+	FulfillmentPolicyBackorder FulfillmentPolicy = "backorder"
+)
+
+// ParseFulfillmentPolicy converts a string into a FulfillmentPolicy,
+// defaulting to all-or-nothing when empty
+func ParseFulfillmentPolicy(s string) (FulfillmentPolicy, error) {
+	switch policy := FulfillmentPolicy(s); policy {
+	case "":
+		return FulfillmentPolicyAllOrNothing, nil
+	case FulfillmentPolicyAllOrNothing, FulfillmentPolicyAllowPartial, FulfillmentPolicyBackorder:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownFulfillmentPolicy, s)
+	}
+}
+
+// ReservationLine is inventory's outcome for a single order line
+type ReservationLine struct {
+	ProductID   string
+	SKU         string
+	Reserved    int
+	Backordered int
+}
+
+// ApplyReservation records what inventory reserved and backordered for each
+// line. Quantities that are neither are dropped from the order and the total
+// is recalculated to cover only what will be shipped.
+func (o *Order) ApplyReservation(lines []ReservationLine) error {
+	matched := make([]bool, len(o.Items))
+	for _, line := range lines {
+		index := -1
+		for i, item := range o.Items {
+			if !matched[i] && item.ProductID == line.ProductID {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return fmt.Errorf("%w: product %s", ErrOrderItemNotFound, line.ProductID)
+		}
+		matched[index] = true
+
+		item := &o.Items[index]
+		item.SKU = line.SKU
+		item.ReservedQuantity = line.Reserved
+		item.BackorderedQuantity = line.Backordered
+		item.Quantity = min(line.Reserved+line.Backordered, item.RequestedQuantity)
+	}
+
+	o.CalculateTotal()
+	o.UpdatedAt = time.Now().UTC()
+	return nil
+}
+
+// FulfillBackorder moves quantity of a backordered line to reserved once
+// inventory has allocated newly received stock to it
+func (o *Order) FulfillBackorder(productID string, quantity int) error {
+	if o.Status.IsTerminal() {
+		return ErrOrderClosed
+	}
+
+	for i := range o.Items {
+		item := &o.Items[i]
+		if item.ProductID != productID || !item.IsBackordered() {
+			continue
+		}
+
+		fulfilled := min(quantity, item.BackorderedQuantity)
+		item.BackorderedQuantity -= fulfilled
+		item.ReservedQuantity += fulfilled
+		o.UpdatedAt = time.Now().UTC()
+		return nil
+	}
+	return fmt.Errorf("%w: no backorder for product %s", ErrOrderItemNotFound, productID)
+}
+
+// HasBackorders reports whether any line is still waiting for stock
+func (o *Order) HasBackorders() bool {
+	for _, item := range o.Items {
+		if item.IsBackordered() {
+			return true
+		}
+	}
+	return false
+}
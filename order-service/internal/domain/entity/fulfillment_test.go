@@ -0,0 +1,166 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseFulfillmentPolicy(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    FulfillmentPolicy
+		wantErr error
+	}{
+		{in: "", want: FulfillmentPolicyAllOrNothing},
+		{in: "all_or_nothing", want: FulfillmentPolicyAllOrNothing},
+		{in: "allow_partial", want: FulfillmentPolicyAllowPartial},
+		{in: "backorder", want: FulfillmentPolicyBackorder},
+		{in: "Backorder", wantErr: ErrUnknownFulfillmentPolicy},
+	}
+	for _, tt := range tests {
+		got, err := ParseFulfillmentPolicy(tt.in)
+		if got != tt.want || !errors.Is(err, tt.wantErr) {
+			t.Errorf("ParseFulfillmentPolicy(%q) = %q, %v, want %q, %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func newFulfillmentOrder() *Order {
+	return &Order{
+		ID:     "o-1",
+		Status: OrderStatusPending,
+		Items: []OrderItem{
+			{ProductID: "a", Quantity: 4, RequestedQuantity: 4, Price: 10},
+			{ProductID: "b", Quantity: 2, RequestedQuantity: 2, Price: 5},
+			{ProductID: "a", Quantity: 1, RequestedQuantity: 1, Price: 10},
+		},
+	}
+}
+
+func TestApplyReservation(t *testing.T) {
+	tests := []struct {
+		name      string
+		lines     []ReservationLine
+		wantItems []OrderItem
+		wantTotal float64
+		wantErr   error
+	}{
+		{
+			name:  "fully reserved keeps every line",
+			lines: []ReservationLine{{ProductID: "a", SKU: "A-1", Reserved: 4}, {ProductID: "b", SKU: "B-1", Reserved: 2}, {ProductID: "a", SKU: "A-1", Reserved: 1}},
+			wantItems: []OrderItem{
+				{ProductID: "a", SKU: "A-1", Quantity: 4, RequestedQuantity: 4, ReservedQuantity: 4, Price: 10},
+				{ProductID: "b", SKU: "B-1", Quantity: 2, RequestedQuantity: 2, ReservedQuantity: 2, Price: 5},
+				{ProductID: "a", SKU: "A-1", Quantity: 1, RequestedQuantity: 1, ReservedQuantity: 1, Price: 10},
+			},
+			wantTotal: 60,
+		},
+		{
+			name:  "partial reservation drops and stops charging for the shortfall",
+			lines: []ReservationLine{{ProductID: "a", Reserved: 3}, {ProductID: "b", Reserved: 0}, {ProductID: "a", Reserved: 1}},
+			wantItems: []OrderItem{
+				{ProductID: "a", Quantity: 3, RequestedQuantity: 4, ReservedQuantity: 3, Price: 10},
+				{ProductID: "b", Quantity: 0, RequestedQuantity: 2, Price: 5},
+				{ProductID: "a", Quantity: 1, RequestedQuantity: 1, ReservedQuantity: 1, Price: 10},
+			},
+			wantTotal: 40,
+		},
+		{
+			name:  "backordered quantities are still charged",
+			lines: []ReservationLine{{ProductID: "a", Reserved: 1, Backordered: 3}, {ProductID: "b", Reserved: 2}, {ProductID: "a", Reserved: 1}},
+			wantItems: []OrderItem{
+				{ProductID: "a", Quantity: 4, RequestedQuantity: 4, ReservedQuantity: 1, BackorderedQuantity: 3, Price: 10},
+				{ProductID: "b", Quantity: 2, RequestedQuantity: 2, ReservedQuantity: 2, Price: 5},
+				{ProductID: "a", Quantity: 1, RequestedQuantity: 1, ReservedQuantity: 1, Price: 10},
+			},
+			wantTotal: 60,
+		},
+		{
+			name:    "unknown product",
+			lines:   []ReservationLine{{ProductID: "c", Reserved: 1}},
+			wantErr: ErrOrderItemNotFound,
+		},
+		{
+			name:    "more lines than the order has for a product",
+			lines:   []ReservationLine{{ProductID: "b", Reserved: 1}, {ProductID: "b", Reserved: 1}},
+			wantErr: ErrOrderItemNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := newFulfillmentOrder()
+
+			err := order.ApplyReservation(tt.lines)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ApplyReservation() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			for i, want := range tt.wantItems {
+				if order.Items[i] != want {
+					t.Errorf("item %d = %+v, want %+v", i, order.Items[i], want)
+				}
+			}
+			if order.TotalAmount != tt.wantTotal {
+				t.Errorf("TotalAmount = %v, want %v", order.TotalAmount, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestFulfillBackorder(t *testing.T) {
+	newBackorderedOrder := func(status OrderStatus) *Order {
+		order := newFulfillmentOrder()
+		order.Status = status
+		if err := order.ApplyReservation([]ReservationLine{
+			{ProductID: "a", Reserved: 4},
+			{ProductID: "b", Reserved: 2},
+			{ProductID: "a", Backordered: 1},
+		}); err != nil {
+			t.Fatalf("ApplyReservation() error = %v", err)
+		}
+		return order
+	}
+
+	t.Run("moves stock from backordered to reserved until nothing is waiting", func(t *testing.T) {
+		order := newBackorderedOrder(OrderStatusProcessing)
+		if !order.HasBackorders() {
+			t.Fatal("HasBackorders() = false, want true")
+		}
+		if _, err := order.TransitionTo(OrderStatusCompleted, ActorSystem, ""); !errors.Is(err, ErrOrderHasBackorders) {
+			t.Fatalf("TransitionTo(completed) error = %v, want ErrOrderHasBackorders", err)
+		}
+
+		// More stock than is waiting only fills the backorder
+		if err := order.FulfillBackorder("a", 5); err != nil {
+			t.Fatalf("FulfillBackorder() error = %v", err)
+		}
+		if item := order.Items[2]; item.ReservedQuantity != 1 || item.BackorderedQuantity != 0 {
+			t.Errorf("backordered line = %+v, want 1 reserved", item)
+		}
+		if order.Items[0].ReservedQuantity != 4 {
+			t.Errorf("reserved line changed to %+v", order.Items[0])
+		}
+		if order.HasBackorders() {
+			t.Error("HasBackorders() = true, want false")
+		}
+		if _, err := order.TransitionTo(OrderStatusCompleted, ActorSystem, ""); err != nil {
+			t.Errorf("TransitionTo(completed) error = %v", err)
+		}
+	})
+
+	t.Run("no backorder for the product", func(t *testing.T) {
+		order := newBackorderedOrder(OrderStatusProcessing)
+		if err := order.FulfillBackorder("b", 1); !errors.Is(err, ErrOrderItemNotFound) {
+			t.Errorf("FulfillBackorder() error = %v, want ErrOrderItemNotFound", err)
+		}
+	})
+
+	t.Run("closed orders", func(t *testing.T) {
+		order := newBackorderedOrder(OrderStatusCancelled)
+		if err := order.FulfillBackorder("a", 1); !errors.Is(err, ErrOrderClosed) {
+			t.Errorf("FulfillBackorder() error = %v, want ErrOrderClosed", err)
+		}
+	})
+}
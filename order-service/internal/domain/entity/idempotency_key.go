@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrIdempotencyKeyNotFound   = errors.New("idempotency key not found")
+	ErrIdempotencyKeyMismatch   = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still being processed")
+)
+
+// IdempotencyKey remembers the outcome of a client request so retries
+// with the same key return the original response instead of repeating it.
+// Keys are scoped to the user, two users sending the same key don't collide.
+type IdempotencyKey struct {
+	UserID       string    `json:"user_id"`
+	Key          string    `json:"key"`
+	RequestHash  string    `json:"request_hash"`
+	OrderID      string    `json:"order_id"`
+	ResponseBody []byte    `json:"response_body"`
+	CreatedAt    time.Time `json:"created_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+func (k *IdempotencyKey) Matches(requestHash string) bool {
+	return k.RequestHash == requestHash
+}
+
+func (k *IdempotencyKey) HasResponse() bool {
+	return len(k.ResponseBody) > 0
+}
+
+func (k *IdempotencyKey) IsExpired(now time.Time) bool {
+	return now.After(k.ExpiresAt)
+}
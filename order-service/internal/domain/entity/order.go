@@ -1,16 +1,26 @@
 package entity
 
-import "time"
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrOrderNotFound          = errors.New("order not found")
+	ErrConcurrentModification = errors.New("order was modified concurrently")
+)
 
 type Order struct {
-	ID            string      `json:"id"`
-	UserID        string      `json:"user_id"`
-	Status        OrderStatus `json:"status"`
-	TotalAmount   float64     `json:"total_amount"`
-	Items         []OrderItem `json:"items"`
-	CreatedAt     time.Time   `json:"created_at"`
-	UpdatedAt     time.Time   `json:"updated_at"`
-	CorrelationID string      `json:"correlation_id"`
+	ID                string            `json:"id"`
+	UserID            string            `json:"user_id"`
+	Status            OrderStatus       `json:"status"`
+	TotalAmount       float64           `json:"total_amount"`
+	Items             []OrderItem       `json:"items"`
+	CreatedAt         time.Time         `json:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+	CorrelationID     string            `json:"correlation_id"`
+	Version           int32             `json:"version"`
+	FulfillmentPolicy FulfillmentPolicy `json:"fulfillment_policy"`
 }
 
 type OrderStatus string
@@ -31,26 +41,48 @@ func (o *Order) CalculateTotal() {
 	o.TotalAmount = total
 }
 
-func (o *Order) MarkAsProcessing() {
-	o.Status = OrderStatusProcessing
-	o.UpdatedAt = time.Now().UTC()
+// TransitionTo moves the order to the given status if the transition table
+// allows it and returns the change to be recorded in the status history.
+// An order waiting for backordered stock can't be completed.
+func (o *Order) TransitionTo(status OrderStatus, actor, reason string) (*OrderStatusChange, error) {
+	if !o.Status.CanTransitionTo(status) {
+		return nil, &InvalidTransitionError{From: o.Status, To: status}
+	}
+	if status == OrderStatusCompleted && o.HasBackorders() {
+		return nil, ErrOrderHasBackorders
+	}
+
+	now := time.Now().UTC()
+	change := &OrderStatusChange{
+		OrderID:    o.ID,
+		FromStatus: o.Status,
+		ToStatus:   status,
+		Actor:      actor,
+		Reason:     reason,
+		CreatedAt:  now,
+	}
+
+	o.Status = status
+	o.UpdatedAt = now
+	return change, nil
+}
+
+func (o *Order) MarkAsProcessing(actor, reason string) (*OrderStatusChange, error) {
+	return o.TransitionTo(OrderStatusProcessing, actor, reason)
 }
 
-func (o *Order) MarkAsCompleted() {
-	o.Status = OrderStatusCompleted
-	o.UpdatedAt = time.Now().UTC()
+func (o *Order) MarkAsCompleted(actor, reason string) (*OrderStatusChange, error) {
+	return o.TransitionTo(OrderStatusCompleted, actor, reason)
 }
 
-func (o *Order) MarkAsFailed() {
-	o.Status = OrderStatusFailed
-	o.UpdatedAt = time.Now().UTC()
+func (o *Order) MarkAsFailed(actor, reason string) (*OrderStatusChange, error) {
+	return o.TransitionTo(OrderStatusFailed, actor, reason)
 }
 
-func (o *Order) MarkAsCancelled() {
-	o.Status = OrderStatusCancelled
-	o.UpdatedAt = time.Now().UTC()
+func (o *Order) MarkAsCancelled(actor, reason string) (*OrderStatusChange, error) {
+	return o.TransitionTo(OrderStatusCancelled, actor, reason)
 }
 
 func (o *Order) CanBeCancelled() bool {
-	return o.Status == OrderStatusPending || o.Status == OrderStatusProcessing
+	return o.Status.CanTransitionTo(OrderStatusCancelled)
 }
@@ -1,13 +1,23 @@
 package entity
 
 type OrderItem struct {
-	ID        string  `json:"id"`
-	OrderID   string  `json:"order_id"`
-	ProductID string  `json:"product_id"`
-	Quantity  int     `json:"quantity"`
-	Price     float64 `json:"price"`
+	ID                  string  `json:"id"`
+	OrderID             string  `json:"order_id"`
+	ProductID           string  `json:"product_id"`
+	Quantity            int     `json:"quantity"` // Quantity the customer is charged for
+	Price               float64 `json:"price"`
+	RequestedQuantity   int     `json:"requested_quantity"`
+	ReservedQuantity    int     `json:"reserved_quantity"`
+	BackorderedQuantity int     `json:"backordered_quantity"`
+	// SKU is the stock keeping unit inventory reserved, empty until the reservation
+	SKU string `json:"sku,omitempty"`
 }
 
 func (o *OrderItem) GetSubtotal() float64 {
 	return float64(o.Quantity) * o.Price
 }
+
+// IsBackordered reports whether part of the line is still waiting for stock
+func (o *OrderItem) IsBackordered() bool {
+	return o.BackorderedQuantity > 0
+}
@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	ErrUnknownOrderStatus      = errors.New("unknown order status")
+)
+
+// InvalidTransitionError reports a status change the transition table does not allow
+type InvalidTransitionError struct {
+	From OrderStatus
+	To   OrderStatus
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("cannot transition order from %s to %s", e.From, e.To)
+}
+
+func (e *InvalidTransitionError) Is(target error) bool {
+	return target == ErrInvalidStatusTransition
+}
+
+// orderTransitions lists the statuses each status may move to.
+// Completed, failed and cancelled are terminal.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPending:    {OrderStatusProcessing, OrderStatusFailed, OrderStatusCancelled},
+	OrderStatusProcessing: {OrderStatusCompleted, OrderStatusFailed, OrderStatusCancelled},
+	OrderStatusCompleted:  {},
+	OrderStatusFailed:     {},
+	OrderStatusCancelled:  {},
+}
+
+// ParseOrderStatus converts a string into a known order status
+func ParseOrderStatus(s string) (OrderStatus, error) {
+	status := OrderStatus(s)
+	if _, ok := orderTransitions[status]; !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownOrderStatus, s)
+	}
+	return status, nil
+}
+
+func (s OrderStatus) CanTransitionTo(to OrderStatus) bool {
+	for _, allowed := range orderTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (s OrderStatus) IsTerminal() bool {
+	return len(orderTransitions[s]) == 0
+}
+
+// Actors recorded in the status history for changes not made by a user
+const (
+	ActorSystem           = "system"
+	ActorInventoryService = "inventory-service"
+)
+
+// UserActor identifies a user as the actor of a status change
+func UserActor(userID string) string {
+	return "user:" + userID
+}
+
+// OrderStatusChange is a single entry of an order's status history
+type OrderStatusChange struct {
+	ID         string      `json:"id"`
+	OrderID    string      `json:"order_id"`
+	FromStatus OrderStatus `json:"from_status"`
+	ToStatus   OrderStatus `json:"to_status"`
+	Actor      string      `json:"actor"`
+	Reason     string      `json:"reason"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
@@ -0,0 +1,84 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOrderStatusTransitions(t *testing.T) {
+	statuses := []OrderStatus{
+		OrderStatusPending,
+		OrderStatusProcessing,
+		OrderStatusCompleted,
+		OrderStatusFailed,
+		OrderStatusCancelled,
+	}
+	allowed := map[OrderStatus]map[OrderStatus]bool{
+		OrderStatusPending:    {OrderStatusProcessing: true, OrderStatusFailed: true, OrderStatusCancelled: true},
+		OrderStatusProcessing: {OrderStatusCompleted: true, OrderStatusFailed: true, OrderStatusCancelled: true},
+	}
+
+	for _, from := range statuses {
+		for _, to := range statuses {
+			if got, want := from.CanTransitionTo(to), allowed[from][to]; got != want {
+				t.Errorf("%s.CanTransitionTo(%s) = %v, want %v", from, to, got, want)
+			}
+		}
+		if got, want := from.IsTerminal(), len(allowed[from]) == 0; got != want {
+			t.Errorf("%s.IsTerminal() = %v, want %v", from, got, want)
+		}
+	}
+}
+
+func TestParseOrderStatus(t *testing.T) {
+	if status, err := ParseOrderStatus("processing"); err != nil || status != OrderStatusProcessing {
+		t.Errorf("ParseOrderStatus(processing) = %q, %v", status, err)
+	}
+	if _, err := ParseOrderStatus("shipped"); !errors.Is(err, ErrUnknownOrderStatus) {
+		t.Errorf("ParseOrderStatus(shipped) error = %v, want ErrUnknownOrderStatus", err)
+	}
+}
+
+func TestOrderTransitionTo(t *testing.T) {
+	t.Run("records the change", func(t *testing.T) {
+		order := &Order{ID: "o-1", Status: OrderStatusPending}
+
+		change, err := order.TransitionTo(OrderStatusCancelled, UserActor("u-1"), "changed my mind")
+		if err != nil {
+			t.Fatalf("TransitionTo() error = %v", err)
+		}
+		if order.Status != OrderStatusCancelled || order.UpdatedAt.IsZero() {
+			t.Errorf("order = %s updated at %v, want cancelled with a timestamp", order.Status, order.UpdatedAt)
+		}
+		want := OrderStatusChange{
+			OrderID:    "o-1",
+			FromStatus: OrderStatusPending,
+			ToStatus:   OrderStatusCancelled,
+			Actor:      "user:u-1",
+			Reason:     "changed my mind",
+			CreatedAt:  order.UpdatedAt,
+		}
+		if *change != want {
+			t.Errorf("change = %+v, want %+v", *change, want)
+		}
+	})
+
+	t.Run("rejects transitions out of terminal statuses", func(t *testing.T) {
+		order := &Order{ID: "o-1", Status: OrderStatusCancelled}
+
+		_, err := order.TransitionTo(OrderStatusProcessing, ActorSystem, "")
+		if !errors.Is(err, ErrInvalidStatusTransition) {
+			t.Fatalf("TransitionTo() error = %v, want ErrInvalidStatusTransition", err)
+		}
+		var transitionErr *InvalidTransitionError
+		if !errors.As(err, &transitionErr) || transitionErr.From != OrderStatusCancelled || transitionErr.To != OrderStatusProcessing {
+			t.Errorf("TransitionTo() error = %#v, want cancelled to processing", err)
+		}
+		if err.Error() != "cannot transition order from cancelled to processing" {
+			t.Errorf("Error() = %q", err.Error())
+		}
+		if order.Status != OrderStatusCancelled {
+			t.Errorf("status = %s, want it unchanged", order.Status)
+		}
+	})
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/domain/entity"
+)
+
+type IdempotencyRepository interface {
+	// Reserve claims the user's key for a new request. It returns false when
+	// the key is already held by an unexpired request of the user.
+	Reserve(ctx context.Context, key *entity.IdempotencyKey) (bool, error)
+	Get(ctx context.Context, userID, key string) (*entity.IdempotencyKey, error)
+	Delete(ctx context.Context, userID, key string) error
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
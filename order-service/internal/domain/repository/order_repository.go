@@ -7,9 +7,18 @@ import (
 )
 
 type OrderRepository interface {
-	Create(ctx context.Context, order *entity.Order) error
+	// Create stores the order with its items and the initial status history entry.
+	// When idempotencyKey is not nil, its order ID and response are stored in the
+	// same transaction, so retries always find the response of a created order.
+	Create(ctx context.Context, order *entity.Order, change *entity.OrderStatusChange, idempotencyKey *entity.IdempotencyKey) error
 	GetByID(ctx context.Context, id string) (*entity.Order, error)
 	GetByUserID(ctx context.Context, userID string, limit, offset int) ([]*entity.Order, error)
-	UpdateStatus(ctx context.Context, orderID string, status entity.OrderStatus) error
+	// UpdateStatus persists the order's new status together with its history entry.
+	// It returns entity.ErrConcurrentModification if the order's version changed since it was read.
+	UpdateStatus(ctx context.Context, order *entity.Order, change *entity.OrderStatusChange) error
+	// UpdateFulfillment persists the order's status, total and per-line reservation outcome.
+	// change may be nil when the status did not change.
+	UpdateFulfillment(ctx context.Context, order *entity.Order, change *entity.OrderStatusChange) error
 	GetByCorrelationID(ctx context.Context, correlationID string) (*entity.Order, error)
+	GetStatusHistory(ctx context.Context, orderID string) ([]*entity.OrderStatusChange, error)
 }
@@ -0,0 +1,124 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/application/usecase"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/events"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/messaging"
+)
+
+type InventoryEventConsumer struct {
+	consumer                 *messaging.EventConsumer
+	applyReservationUseCase  *usecase.ApplyInventoryReservationUseCase
+	fulfillBackorderUseCase  *usecase.FulfillBackorderUseCase
+	updateOrderStatusUseCase *usecase.UpdateOrderStatusUseCase
+}
+
+func NewInventoryEventConsumer(
+	consumer *messaging.EventConsumer,
+	applyReservationUseCase *usecase.ApplyInventoryReservationUseCase,
+	fulfillBackorderUseCase *usecase.FulfillBackorderUseCase,
+	updateOrderStatusUseCase *usecase.UpdateOrderStatusUseCase,
+) *InventoryEventConsumer {
+	return &InventoryEventConsumer{
+		consumer:                 consumer,
+		applyReservationUseCase:  applyReservationUseCase,
+		fulfillBackorderUseCase:  fulfillBackorderUseCase,
+		updateOrderStatusUseCase: updateOrderStatusUseCase,
+	}
+}
+
+// Start begins consuming inventory events
+func (c *InventoryEventConsumer) Start() error {
+	log.Println("Starting Inventory Event Consumer...")
+
+	return c.consumer.Consume(c.handleEvent)
+}
+
+// handleEvent dispatches inventory events by type
+func (c *InventoryEventConsumer) handleEvent(eventType string, body []byte) error {
+	var err error
+	switch eventType {
+	case events.InventoryReservedEventType:
+		err = c.handleInventoryReserved(body)
+	case events.InventoryReservationFailedEventType:
+		err = c.handleReservationFailed(body)
+	case events.InventoryBackorderFulfilledEventType:
+		err = c.handleBackorderFulfilled(body)
+	default:
+		return nil
+	}
+
+	// The order has moved on (e.g. it was cancelled), redelivering won't change that
+	if errors.Is(err, entity.ErrInvalidStatusTransition) ||
+		errors.Is(err, entity.ErrOrderClosed) ||
+		errors.Is(err, entity.ErrOrderNotFound) {
+		log.Printf("Warning: ignoring %s event: %v", eventType, err)
+		return nil
+	}
+	return err
+}
+
+// handleInventoryReserved processes inventory.reserved events
+func (c *InventoryEventConsumer) handleInventoryReserved(body []byte) error {
+	var event events.InventoryReservedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		log.Printf("ERROR: Failed to unmarshal inventory.reserved event: %v", err)
+		return err
+	}
+
+	if err := c.applyReservationUseCase.Execute(context.Background(), event); err != nil {
+		log.Printf("ERROR: Failed to apply reservation to order %s: %v", event.OrderID, err)
+		return err
+	}
+
+	log.Printf("Applied inventory reservation to order %s", event.OrderID)
+	return nil
+}
+
+// handleReservationFailed processes inventory.reservation_failed events
+func (c *InventoryEventConsumer) handleReservationFailed(body []byte) error {
+	var event events.InventoryReservationFailedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		log.Printf("ERROR: Failed to unmarshal inventory.reservation_failed event: %v", err)
+		return err
+	}
+
+	_, err := c.updateOrderStatusUseCase.Execute(
+		context.Background(),
+		event.OrderID,
+		entity.OrderStatusFailed,
+		entity.ActorInventoryService,
+		event.Reason,
+	)
+	if err != nil {
+		log.Printf("ERROR: Failed to mark order %s as failed: %v", event.OrderID, err)
+		return err
+	}
+
+	log.Printf("Marked order %s as failed: %s", event.OrderID, event.Reason)
+	return nil
+}
+
+// handleBackorderFulfilled processes inventory.backorder_fulfilled events
+func (c *InventoryEventConsumer) handleBackorderFulfilled(body []byte) error {
+	var event events.InventoryBackorderFulfilledEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		log.Printf("ERROR: Failed to unmarshal inventory.backorder_fulfilled event: %v", err)
+		return err
+	}
+
+	if err := c.fulfillBackorderUseCase.Execute(context.Background(), event); err != nil {
+		log.Printf("ERROR: Failed to fulfill backorder for order %s: %v", event.OrderID, err)
+		return err
+	}
+
+	log.Printf("Fulfilled %d backordered unit(s) of product %s for order %s",
+		event.Quantity, event.ProductID, event.OrderID)
+	return nil
+}
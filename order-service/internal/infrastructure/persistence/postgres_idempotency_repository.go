@@ -0,0 +1,100 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/domain/repository"
+	sqlc "github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/infrastructure/persistence/sqlc"
+)
+
+type PostgresIdempotencyRepository struct {
+	queries *sqlc.Queries
+}
+
+func NewPostgresIdempotencyRepository(db *sql.DB) repository.IdempotencyRepository {
+	return &PostgresIdempotencyRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+func (p *PostgresIdempotencyRepository) Reserve(ctx context.Context, key *entity.IdempotencyKey) (bool, error) {
+	userUUID, err := uuid.Parse(key.UserID)
+	if err != nil {
+		return false, errors.New("invalid user ID format")
+	}
+
+	rows, err := p.queries.ReserveIdempotencyKey(ctx, sqlc.ReserveIdempotencyKeyParams{
+		UserID:         userUUID,
+		IdempotencyKey: key.Key,
+		RequestHash:    key.RequestHash,
+		CreatedAt:      key.CreatedAt,
+		ExpiresAt:      key.ExpiresAt,
+	})
+	if err != nil {
+		return false, fmt.Errorf("could not reserve idempotency key: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (p *PostgresIdempotencyRepository) Get(ctx context.Context, userID, key string) (*entity.IdempotencyKey, error) {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	row, err := p.queries.GetIdempotencyKey(ctx, sqlc.GetIdempotencyKeyParams{
+		UserID:         userUUID,
+		IdempotencyKey: key,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrIdempotencyKeyNotFound
+		}
+		return nil, fmt.Errorf("could not get idempotency key: %w", err)
+	}
+
+	idempotencyKey := &entity.IdempotencyKey{
+		UserID:      row.UserID.String(),
+		Key:         row.IdempotencyKey,
+		RequestHash: row.RequestHash,
+		CreatedAt:   row.CreatedAt,
+		ExpiresAt:   row.ExpiresAt,
+	}
+	if row.OrderID.Valid {
+		idempotencyKey.OrderID = row.OrderID.UUID.String()
+	}
+	if row.ResponseBody.Valid {
+		idempotencyKey.ResponseBody = []byte(row.ResponseBody.String)
+	}
+	return idempotencyKey, nil
+}
+
+func (p *PostgresIdempotencyRepository) Delete(ctx context.Context, userID, key string) error {
+	userUUID, err := uuid.Parse(userID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	err = p.queries.DeleteIdempotencyKey(ctx, sqlc.DeleteIdempotencyKeyParams{
+		UserID:         userUUID,
+		IdempotencyKey: key,
+	})
+	if err != nil {
+		return fmt.Errorf("could not delete idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (p *PostgresIdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	deleted, err := p.queries.DeleteExpiredIdempotencyKeys(ctx, before)
+	if err != nil {
+		return 0, fmt.Errorf("could not delete expired idempotency keys: %w", err)
+	}
+	return deleted, nil
+}
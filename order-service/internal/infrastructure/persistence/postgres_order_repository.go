@@ -25,7 +25,7 @@ func NewPostgresOrderRepository(db *sql.DB) repository.OrderRepository {
 	}
 }
 
-func (p *PostgresOrderRepository) Create(ctx context.Context, order *entity.Order) error {
+func (p *PostgresOrderRepository) Create(ctx context.Context, order *entity.Order, change *entity.OrderStatusChange, idempotencyKey *entity.IdempotencyKey) error {
 	orderUUID, err := uuid.Parse(order.ID)
 	if err != nil {
 		return errors.New("invalid order ID format")
@@ -46,13 +46,14 @@ func (p *PostgresOrderRepository) Create(ctx context.Context, order *entity.Orde
 	qtx := p.queries.WithTx(tx)
 
 	err = qtx.CreateOrder(ctx, sqlc.CreateOrderParams{
-		ID:            orderUUID,
-		UserID:        uuid.MustParse(order.UserID),
-		Status:        string(order.Status),
-		TotalAmount:   fmt.Sprintf("%.2f", order.TotalAmount),
-		CorrelationID: correlationUUID,
-		CreatedAt:     order.CreatedAt,
-		UpdatedAt:     order.UpdatedAt,
+		ID:                orderUUID,
+		UserID:            uuid.MustParse(order.UserID),
+		Status:            string(order.Status),
+		TotalAmount:       fmt.Sprintf("%.2f", order.TotalAmount),
+		CorrelationID:     correlationUUID,
+		CreatedAt:         order.CreatedAt,
+		UpdatedAt:         order.UpdatedAt,
+		FulfillmentPolicy: string(order.FulfillmentPolicy),
 	})
 
 	if err != nil {
@@ -71,17 +72,39 @@ func (p *PostgresOrderRepository) Create(ctx context.Context, order *entity.Orde
 		}
 
 		err = qtx.CreateOrderItem(ctx, sqlc.CreateOrderItemParams{
-			ID:        itemUUID,
-			OrderID:   orderUUID,
-			ProductID: productUUID,
-			Quantity:  int32(item.Quantity),
-			Price:     fmt.Sprintf("%.2f", item.Price),
+			ID:                itemUUID,
+			OrderID:           orderUUID,
+			ProductID:         productUUID,
+			Quantity:          int32(item.Quantity),
+			Price:             fmt.Sprintf("%.2f", item.Price),
+			RequestedQuantity: int32(item.RequestedQuantity),
 		})
 
 		if err != nil {
 			return fmt.Errorf("could not create item: %w", err)
 		}
 	}
+
+	if err := createStatusHistory(ctx, qtx, change); err != nil {
+		return err
+	}
+
+	if idempotencyKey != nil {
+		userUUID, err := uuid.Parse(idempotencyKey.UserID)
+		if err != nil {
+			return errors.New("invalid user ID format")
+		}
+
+		err = qtx.SaveIdempotencyResponse(ctx, sqlc.SaveIdempotencyResponseParams{
+			UserID:         userUUID,
+			IdempotencyKey: idempotencyKey.Key,
+			OrderID:        uuid.NullUUID{UUID: orderUUID, Valid: true},
+			ResponseBody:   sql.NullString{String: string(idempotencyKey.ResponseBody), Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("could not save idempotency response: %w", err)
+		}
+	}
 	return tx.Commit()
 }
 
@@ -93,7 +116,7 @@ func (p *PostgresOrderRepository) GetByID(ctx context.Context, id string) (*enti
 	orderRow, err := p.queries.GetOrderByID(ctx, orderUUID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("order not found")
+			return nil, entity.ErrOrderNotFound
 		}
 		return nil, fmt.Errorf("could not get order: %w", err)
 	}
@@ -140,20 +163,99 @@ func (p *PostgresOrderRepository) GetByUserID(ctx context.Context, userID string
 	return orders, nil
 }
 
-func (p *PostgresOrderRepository) UpdateStatus(ctx context.Context, orderID string, status entity.OrderStatus) error {
-	orderUUID, err := uuid.Parse(orderID)
+func (p *PostgresOrderRepository) UpdateStatus(ctx context.Context, order *entity.Order, change *entity.OrderStatusChange) error {
+	orderUUID, err := uuid.Parse(order.ID)
 	if err != nil {
 		return errors.New("invalid order ID format")
 	}
-	orderParams := sqlc.UpdateOrderStatusParams{
-		ID:     orderUUID,
-		Status: string(status),
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not start transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	err = p.queries.UpdateOrderStatus(ctx, orderParams)
+	qtx := p.queries.WithTx(tx)
+
+	rows, err := qtx.UpdateOrderStatus(ctx, sqlc.UpdateOrderStatusParams{
+		ID:      orderUUID,
+		Status:  string(order.Status),
+		Version: order.Version,
+	})
 	if err != nil {
 		return fmt.Errorf("could not update order status: %w", err)
 	}
+	// No row matched the version we read, someone else updated the order first
+	if rows == 0 {
+		return entity.ErrConcurrentModification
+	}
+
+	if err := createStatusHistory(ctx, qtx, change); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	order.Version++
+	return nil
+}
+
+func (p *PostgresOrderRepository) UpdateFulfillment(ctx context.Context, order *entity.Order, change *entity.OrderStatusChange) error {
+	orderUUID, err := uuid.Parse(order.ID)
+	if err != nil {
+		return errors.New("invalid order ID format")
+	}
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("could not start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := p.queries.WithTx(tx)
+
+	rows, err := qtx.UpdateOrderFulfillment(ctx, sqlc.UpdateOrderFulfillmentParams{
+		ID:          orderUUID,
+		Status:      string(order.Status),
+		TotalAmount: fmt.Sprintf("%.2f", order.TotalAmount),
+		Version:     order.Version,
+	})
+	if err != nil {
+		return fmt.Errorf("could not update order fulfillment: %w", err)
+	}
+	if rows == 0 {
+		return entity.ErrConcurrentModification
+	}
+
+	for _, item := range order.Items {
+		itemUUID, err := uuid.Parse(item.ID)
+		if err != nil {
+			return errors.New("invalid item ID format")
+		}
+
+		err = qtx.UpdateOrderItemFulfillment(ctx, sqlc.UpdateOrderItemFulfillmentParams{
+			ID:                  itemUUID,
+			Quantity:            int32(item.Quantity),
+			ReservedQuantity:    int32(item.ReservedQuantity),
+			BackorderedQuantity: int32(item.BackorderedQuantity),
+			Sku:                 item.SKU,
+		})
+		if err != nil {
+			return fmt.Errorf("could not update item: %w", err)
+		}
+	}
+
+	if change != nil {
+		if err := createStatusHistory(ctx, qtx, change); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	order.Version++
 	return nil
 }
 
@@ -166,7 +268,7 @@ func (p *PostgresOrderRepository) GetByCorrelationID(ctx context.Context, correl
 	orderRow, err := p.queries.GetOrderByCorrelationID(ctx, correlationUUID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("order not found")
+			return nil, entity.ErrOrderNotFound
 		}
 		return nil, fmt.Errorf("could not get order: %w", err)
 	}
@@ -181,16 +283,71 @@ func (p *PostgresOrderRepository) GetByCorrelationID(ctx context.Context, correl
 	return order, nil
 }
 
+func (p *PostgresOrderRepository) GetStatusHistory(ctx context.Context, orderID string) ([]*entity.OrderStatusChange, error) {
+	orderUUID, err := uuid.Parse(orderID)
+	if err != nil {
+		return nil, errors.New("invalid order ID format")
+	}
+
+	rows, err := p.queries.GetOrderStatusHistory(ctx, orderUUID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get order status history: %w", err)
+	}
+
+	history := make([]*entity.OrderStatusChange, len(rows))
+	for i, row := range rows {
+		history[i] = &entity.OrderStatusChange{
+			ID:         row.ID.String(),
+			OrderID:    row.OrderID.String(),
+			FromStatus: entity.OrderStatus(row.FromStatus.String),
+			ToStatus:   entity.OrderStatus(row.ToStatus),
+			Actor:      row.Actor,
+			Reason:     row.Reason,
+			CreatedAt:  row.CreatedAt,
+		}
+	}
+	return history, nil
+}
+
+// createStatusHistory records a status change within the caller's transaction
+func createStatusHistory(ctx context.Context, qtx *sqlc.Queries, change *entity.OrderStatusChange) error {
+	changeUUID, err := uuid.Parse(change.ID)
+	if err != nil {
+		return errors.New("invalid status change ID format")
+	}
+
+	orderUUID, err := uuid.Parse(change.OrderID)
+	if err != nil {
+		return errors.New("invalid order ID format")
+	}
+
+	err = qtx.CreateOrderStatusHistory(ctx, sqlc.CreateOrderStatusHistoryParams{
+		ID:         changeUUID,
+		OrderID:    orderUUID,
+		FromStatus: sql.NullString{String: string(change.FromStatus), Valid: change.FromStatus != ""},
+		ToStatus:   string(change.ToStatus),
+		Actor:      change.Actor,
+		Reason:     change.Reason,
+		CreatedAt:  change.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("could not record status change: %w", err)
+	}
+	return nil
+}
+
 func toOrderEntity(row sqlc.Order) *entity.Order {
 	return &entity.Order{
-		ID:            row.ID.String(),
-		UserID:        row.UserID.String(),
-		Status:        entity.OrderStatus(row.Status),
-		TotalAmount:   parseDecimal(row.TotalAmount), // string → float64
-		CorrelationID: row.CorrelationID.String(),
-		CreatedAt:     row.CreatedAt,
-		UpdatedAt:     row.UpdatedAt,
-		Items:         []entity.OrderItem{}, // Will be filled separately
+		ID:                row.ID.String(),
+		UserID:            row.UserID.String(),
+		Status:            entity.OrderStatus(row.Status),
+		TotalAmount:       parseDecimal(row.TotalAmount), // string → float64
+		CorrelationID:     row.CorrelationID.String(),
+		CreatedAt:         row.CreatedAt,
+		UpdatedAt:         row.UpdatedAt,
+		Version:           row.Version,
+		Items:             []entity.OrderItem{}, // Will be filled separately
+		FulfillmentPolicy: entity.FulfillmentPolicy(row.FulfillmentPolicy),
 	}
 }
 
@@ -198,11 +355,15 @@ func toOrderItemEntities(rows []sqlc.OrderItem) []entity.OrderItem {
 	items := make([]entity.OrderItem, len(rows))
 	for i, row := range rows {
 		items[i] = entity.OrderItem{
-			ID:        row.ID.String(),
-			OrderID:   row.OrderID.String(),
-			ProductID: row.ProductID.String(),
-			Quantity:  int(row.Quantity),
-			Price:     parseDecimal(row.Price), // string → float64
+			ID:                  row.ID.String(),
+			OrderID:             row.OrderID.String(),
+			ProductID:           row.ProductID.String(),
+			Quantity:            int(row.Quantity),
+			Price:               parseDecimal(row.Price), // string → float64
+			RequestedQuantity:   int(row.RequestedQuantity),
+			ReservedQuantity:    int(row.ReservedQuantity),
+			BackorderedQuantity: int(row.BackorderedQuantity),
+			SKU:                 row.Sku,
 		}
 	}
 	return items
@@ -0,0 +1,11 @@
+-- name: CreateOrderStatusHistory :exec
+INSERT INTO order_status_history (
+    id, order_id, from_status, to_status, actor, reason, created_at
+) VALUES (
+             $1, $2, $3, $4, $5, $6, $7
+         );
+
+-- name: GetOrderStatusHistory :many
+SELECT * FROM order_status_history
+WHERE order_id = $1
+ORDER BY created_at ASC;
@@ -1,15 +1,15 @@
 -- name: CreateOrder :exec
 INSERT INTO orders (
-    id, user_id, status, total_amount, correlation_id, created_at, updated_at
+    id, user_id, status, total_amount, correlation_id, created_at, updated_at, fulfillment_policy
 ) VALUES (
-             $1, $2, $3, $4, $5, $6, $7
+             $1, $2, $3, $4, $5, $6, $7, $8
          );
 
 -- name: CreateOrderItem :exec
 INSERT INTO order_items (
-    id, order_id, product_id, quantity, price
+    id, order_id, product_id, quantity, price, requested_quantity
 ) VALUES (
-             $1, $2, $3, $4, $5
+             $1, $2, $3, $4, $5, $6
          );
 
 -- name: GetOrderByID :one
@@ -21,13 +21,23 @@ WHERE user_id = $1
 ORDER BY created_at DESC
     LIMIT $2 OFFSET $3;
 
--- name: UpdateOrderStatus :exec
+-- name: UpdateOrderStatus :execrows
 UPDATE orders
-SET status = $2, updated_at = NOW()
-WHERE id = $1;
+SET status = $2, version = version + 1, updated_at = NOW()
+WHERE id = $1 AND version = $3;
 
 -- name: GetOrderByCorrelationID :one
 SELECT * FROM orders WHERE correlation_id = $1;
 
 -- name: GetOrderItemsByOrderID :many
-SELECT * FROM order_items WHERE order_id = $1;
\ No newline at end of file
+SELECT * FROM order_items WHERE order_id = $1;
+
+-- name: UpdateOrderFulfillment :execrows
+UPDATE orders
+SET status = $2, total_amount = $3, version = version + 1, updated_at = NOW()
+WHERE id = $1 AND version = $4;
+
+-- name: UpdateOrderItemFulfillment :exec
+UPDATE order_items
+SET quantity = $2, reserved_quantity = $3, backordered_quantity = $4, sku = $5
+WHERE id = $1;
@@ -5,25 +5,52 @@
 package persistence
 
 import (
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type IdempotencyKey struct {
+	IdempotencyKey string         `json:"idempotency_key"`
+	RequestHash    string         `json:"request_hash"`
+	OrderID        uuid.NullUUID  `json:"order_id"`
+	ResponseBody   sql.NullString `json:"response_body"`
+	CreatedAt      time.Time      `json:"created_at"`
+	ExpiresAt      time.Time      `json:"expires_at"`
+	UserID         uuid.UUID      `json:"user_id"`
+}
+
 type Order struct {
-	ID            uuid.UUID `json:"id"`
-	UserID        uuid.UUID `json:"user_id"`
-	Status        string    `json:"status"`
-	TotalAmount   string    `json:"total_amount"`
-	CorrelationID uuid.UUID `json:"correlation_id"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID                uuid.UUID `json:"id"`
+	UserID            uuid.UUID `json:"user_id"`
+	Status            string    `json:"status"`
+	TotalAmount       string    `json:"total_amount"`
+	CorrelationID     uuid.UUID `json:"correlation_id"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+	Version           int32     `json:"version"`
+	FulfillmentPolicy string    `json:"fulfillment_policy"`
 }
 
 type OrderItem struct {
-	ID        uuid.UUID `json:"id"`
-	OrderID   uuid.UUID `json:"order_id"`
-	ProductID uuid.UUID `json:"product_id"`
-	Quantity  int32     `json:"quantity"`
-	Price     string    `json:"price"`
+	ID                  uuid.UUID `json:"id"`
+	OrderID             uuid.UUID `json:"order_id"`
+	ProductID           uuid.UUID `json:"product_id"`
+	Quantity            int32     `json:"quantity"`
+	Price               string    `json:"price"`
+	RequestedQuantity   int32     `json:"requested_quantity"`
+	ReservedQuantity    int32     `json:"reserved_quantity"`
+	BackorderedQuantity int32     `json:"backordered_quantity"`
+	Sku                 string    `json:"sku"`
+}
+
+type OrderStatusHistory struct {
+	ID         uuid.UUID      `json:"id"`
+	OrderID    uuid.UUID      `json:"order_id"`
+	FromStatus sql.NullString `json:"from_status"`
+	ToStatus   string         `json:"to_status"`
+	Actor      string         `json:"actor"`
+	Reason     string         `json:"reason"`
+	CreatedAt  time.Time      `json:"created_at"`
 }
@@ -14,20 +14,21 @@ import (
 
 const createOrder = `-- name: CreateOrder :exec
 INSERT INTO orders (
-    id, user_id, status, total_amount, correlation_id, created_at, updated_at
+    id, user_id, status, total_amount, correlation_id, created_at, updated_at, fulfillment_policy
 ) VALUES (
-             $1, $2, $3, $4, $5, $6, $7
+             $1, $2, $3, $4, $5, $6, $7, $8
          )
 `
 
 type CreateOrderParams struct {
-	ID            uuid.UUID `json:"id"`
-	UserID        uuid.UUID `json:"user_id"`
-	Status        string    `json:"status"`
-	TotalAmount   string    `json:"total_amount"`
-	CorrelationID uuid.UUID `json:"correlation_id"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID                uuid.UUID `json:"id"`
+	UserID            uuid.UUID `json:"user_id"`
+	Status            string    `json:"status"`
+	TotalAmount       string    `json:"total_amount"`
+	CorrelationID     uuid.UUID `json:"correlation_id"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+	FulfillmentPolicy string    `json:"fulfillment_policy"`
 }
 
 func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) error {
@@ -39,24 +40,26 @@ func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) error
 		arg.CorrelationID,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.FulfillmentPolicy,
 	)
 	return err
 }
 
 const createOrderItem = `-- name: CreateOrderItem :exec
 INSERT INTO order_items (
-    id, order_id, product_id, quantity, price
+    id, order_id, product_id, quantity, price, requested_quantity
 ) VALUES (
-             $1, $2, $3, $4, $5
+             $1, $2, $3, $4, $5, $6
          )
 `
 
 type CreateOrderItemParams struct {
-	ID        uuid.UUID `json:"id"`
-	OrderID   uuid.UUID `json:"order_id"`
-	ProductID uuid.UUID `json:"product_id"`
-	Quantity  int32     `json:"quantity"`
-	Price     string    `json:"price"`
+	ID                uuid.UUID `json:"id"`
+	OrderID           uuid.UUID `json:"order_id"`
+	ProductID         uuid.UUID `json:"product_id"`
+	Quantity          int32     `json:"quantity"`
+	Price             string    `json:"price"`
+	RequestedQuantity int32     `json:"requested_quantity"`
 }
 
 func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) error {
@@ -66,12 +69,13 @@ func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams
 		arg.ProductID,
 		arg.Quantity,
 		arg.Price,
+		arg.RequestedQuantity,
 	)
 	return err
 }
 
 const getOrderByCorrelationID = `-- name: GetOrderByCorrelationID :one
-SELECT id, user_id, status, total_amount, correlation_id, created_at, updated_at FROM orders WHERE correlation_id = $1
+SELECT id, user_id, status, total_amount, correlation_id, created_at, updated_at, version, fulfillment_policy FROM orders WHERE correlation_id = $1
 `
 
 func (q *Queries) GetOrderByCorrelationID(ctx context.Context, correlationID uuid.UUID) (Order, error) {
@@ -85,12 +89,14 @@ func (q *Queries) GetOrderByCorrelationID(ctx context.Context, correlationID uui
 		&i.CorrelationID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.FulfillmentPolicy,
 	)
 	return i, err
 }
 
 const getOrderByID = `-- name: GetOrderByID :one
-SELECT id, user_id, status, total_amount, correlation_id, created_at, updated_at FROM orders WHERE id = $1
+SELECT id, user_id, status, total_amount, correlation_id, created_at, updated_at, version, fulfillment_policy FROM orders WHERE id = $1
 `
 
 func (q *Queries) GetOrderByID(ctx context.Context, id uuid.UUID) (Order, error) {
@@ -104,12 +110,14 @@ func (q *Queries) GetOrderByID(ctx context.Context, id uuid.UUID) (Order, error)
 		&i.CorrelationID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.FulfillmentPolicy,
 	)
 	return i, err
 }
 
 const getOrderItemsByOrderID = `-- name: GetOrderItemsByOrderID :many
-SELECT id, order_id, product_id, quantity, price FROM order_items WHERE order_id = $1
+SELECT id, order_id, product_id, quantity, price, requested_quantity, reserved_quantity, backordered_quantity, sku FROM order_items WHERE order_id = $1
 `
 
 func (q *Queries) GetOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID) ([]OrderItem, error) {
@@ -127,6 +135,10 @@ func (q *Queries) GetOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID)
 			&i.ProductID,
 			&i.Quantity,
 			&i.Price,
+			&i.RequestedQuantity,
+			&i.ReservedQuantity,
+			&i.BackorderedQuantity,
+			&i.Sku,
 		); err != nil {
 			return nil, err
 		}
@@ -142,7 +154,7 @@ func (q *Queries) GetOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID)
 }
 
 const getOrdersByUserID = `-- name: GetOrdersByUserID :many
-SELECT id, user_id, status, total_amount, correlation_id, created_at, updated_at FROM orders
+SELECT id, user_id, status, total_amount, correlation_id, created_at, updated_at, version, fulfillment_policy FROM orders
 WHERE user_id = $1
 ORDER BY created_at DESC
     LIMIT $2 OFFSET $3
@@ -171,6 +183,8 @@ func (q *Queries) GetOrdersByUserID(ctx context.Context, arg GetOrdersByUserIDPa
 			&i.CorrelationID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+			&i.FulfillmentPolicy,
 		); err != nil {
 			return nil, err
 		}
@@ -185,18 +199,73 @@ func (q *Queries) GetOrdersByUserID(ctx context.Context, arg GetOrdersByUserIDPa
 	return items, nil
 }
 
-const updateOrderStatus = `-- name: UpdateOrderStatus :exec
+const updateOrderStatus = `-- name: UpdateOrderStatus :execrows
 UPDATE orders
-SET status = $2, updated_at = NOW()
-WHERE id = $1
+SET status = $2, version = version + 1, updated_at = NOW()
+WHERE id = $1 AND version = $3
 `
 
 type UpdateOrderStatusParams struct {
-	ID     uuid.UUID `json:"id"`
-	Status string    `json:"status"`
+	ID      uuid.UUID `json:"id"`
+	Status  string    `json:"status"`
+	Version int32     `json:"version"`
+}
+
+func (q *Queries) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateOrderStatus, arg.ID, arg.Status, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateOrderFulfillment = `-- name: UpdateOrderFulfillment :execrows
+UPDATE orders
+SET status = $2, total_amount = $3, version = version + 1, updated_at = NOW()
+WHERE id = $1 AND version = $4
+`
+
+type UpdateOrderFulfillmentParams struct {
+	ID          uuid.UUID `json:"id"`
+	Status      string    `json:"status"`
+	TotalAmount string    `json:"total_amount"`
+	Version     int32     `json:"version"`
+}
+
+func (q *Queries) UpdateOrderFulfillment(ctx context.Context, arg UpdateOrderFulfillmentParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateOrderFulfillment,
+		arg.ID,
+		arg.Status,
+		arg.TotalAmount,
+		arg.Version,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-func (q *Queries) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) error {
-	_, err := q.db.ExecContext(ctx, updateOrderStatus, arg.ID, arg.Status)
+const updateOrderItemFulfillment = `-- name: UpdateOrderItemFulfillment :exec
+UPDATE order_items
+SET quantity = $2, reserved_quantity = $3, backordered_quantity = $4, sku = $5
+WHERE id = $1
+`
+
+type UpdateOrderItemFulfillmentParams struct {
+	ID                  uuid.UUID `json:"id"`
+	Quantity            int32     `json:"quantity"`
+	ReservedQuantity    int32     `json:"reserved_quantity"`
+	BackorderedQuantity int32     `json:"backordered_quantity"`
+	Sku                 string    `json:"sku"`
+}
+
+func (q *Queries) UpdateOrderItemFulfillment(ctx context.Context, arg UpdateOrderItemFulfillmentParams) error {
+	_, err := q.db.ExecContext(ctx, updateOrderItemFulfillment,
+		arg.ID,
+		arg.Quantity,
+		arg.ReservedQuantity,
+		arg.BackorderedQuantity,
+		arg.Sku,
+	)
 	return err
 }
@@ -6,6 +6,7 @@ package persistence
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -13,11 +14,20 @@ import (
 type Querier interface {
 	CreateOrder(ctx context.Context, arg CreateOrderParams) error
 	CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) error
+	CreateOrderStatusHistory(ctx context.Context, arg CreateOrderStatusHistoryParams) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, expiresAt time.Time) (int64, error)
+	DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
 	GetOrderByCorrelationID(ctx context.Context, correlationID uuid.UUID) (Order, error)
 	GetOrderByID(ctx context.Context, id uuid.UUID) (Order, error)
 	GetOrderItemsByOrderID(ctx context.Context, orderID uuid.UUID) ([]OrderItem, error)
+	GetOrderStatusHistory(ctx context.Context, orderID uuid.UUID) ([]OrderStatusHistory, error)
 	GetOrdersByUserID(ctx context.Context, arg GetOrdersByUserIDParams) ([]Order, error)
-	UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) error
+	ReserveIdempotencyKey(ctx context.Context, arg ReserveIdempotencyKeyParams) (int64, error)
+	SaveIdempotencyResponse(ctx context.Context, arg SaveIdempotencyResponseParams) error
+	UpdateOrderFulfillment(ctx context.Context, arg UpdateOrderFulfillmentParams) (int64, error)
+	UpdateOrderItemFulfillment(ctx context.Context, arg UpdateOrderItemFulfillmentParams) error
+	UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -1,32 +1,57 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/application/dto"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/application/usecase"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/order-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/auth"
+)
+
+const (
+	idempotencyKeyHeader    = "Idempotency-Key"
+	maxIdempotencyKeyLength = 255
 )
 
 type OrderHandler struct {
-	createOrderUseCase *usecase.CreateOrderUseCase
+	createOrderUseCase       *usecase.CreateOrderUseCase
+	getOrderUseCase          *usecase.GetOrderUseCase
+	updateOrderStatusUseCase *usecase.UpdateOrderStatusUseCase
+	getStatusHistoryUseCase  *usecase.GetOrderStatusHistoryUseCase
 }
 
-func NewOrderHandler(createOrderUseCase *usecase.CreateOrderUseCase) *OrderHandler {
+func NewOrderHandler(
+	createOrderUseCase *usecase.CreateOrderUseCase,
+	getOrderUseCase *usecase.GetOrderUseCase,
+	updateOrderStatusUseCase *usecase.UpdateOrderStatusUseCase,
+	getStatusHistoryUseCase *usecase.GetOrderStatusHistoryUseCase,
+) *OrderHandler {
 	return &OrderHandler{
-		createOrderUseCase: createOrderUseCase,
+		createOrderUseCase:       createOrderUseCase,
+		getOrderUseCase:          getOrderUseCase,
+		updateOrderStatusUseCase: updateOrderStatusUseCase,
+		getStatusHistoryUseCase:  getStatusHistoryUseCase,
 	}
 }
 
 // CreateOrder handles order creation
 // @Summary Create a new order
-// @Description Creates a new order with items and initiates the order saga
+// @Description Creates a new order with items for the authenticated user and initiates the order saga
 // @Tags orders
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Key that makes retries of the same request safe"
 // @Param request body dto.CreateOrderRequest true "Order creation details"
 // @Success 201 {object} dto.OrderResponse
 // @Failure 400 {object} map[string]string "Invalid request or validation error"
+// @Failure 401 {object} map[string]string "Missing or invalid access token"
+// @Failure 403 {object} map[string]string "Missing permission or unverified email"
+// @Failure 409 {object} map[string]string "Request with the same idempotency key is in progress"
+// @Failure 422 {object} map[string]string "Idempotency key reused with a different request"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /api/v1/orders [post]
 func (h *OrderHandler) CreateOrder(c *gin.Context) {
@@ -37,15 +62,186 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 		return
 	}
 
-	order, err := h.createOrderUseCase.Execute(c.Request.Context(), req)
+	// Orders are always placed for the authenticated user
+	principal, ok := auth.PrincipalFromGin(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": auth.ErrMissingToken.Error()})
+		return
+	}
+	req.UserID = principal.UserID
+
+	idempotencyKey := c.GetHeader(idempotencyKeyHeader)
+	if len(idempotencyKey) > maxIdempotencyKeyLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "idempotency key must not exceed 255 characters"})
+		return
+	}
+
+	order, err := h.createOrderUseCase.Execute(c.Request.Context(), req, idempotencyKey)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		switch {
+		case errors.Is(err, entity.ErrIdempotencyKeyMismatch):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		case errors.Is(err, entity.ErrIdempotencyKeyInProgress):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
 		return
 	}
 
 	c.JSON(http.StatusCreated, order)
 }
 
+// GetOrder handles fetching a single order
+// @Summary Get an order
+// @Description Returns an order with its items. Other users' orders require orders:read:any.
+// @Tags orders
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} map[string]string "Invalid order ID"
+// @Failure 401 {object} map[string]string "Missing or invalid access token"
+// @Failure 403 {object} map[string]string "Missing permission"
+// @Failure 404 {object} map[string]string "Order not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/orders/{id} [get]
+func (h *OrderHandler) GetOrder(c *gin.Context) {
+	orderID, ok := orderIDParam(c)
+	if !ok {
+		return
+	}
+
+	order, ok := h.accessibleOrder(c, orderID, auth.PermissionOrdersReadAny)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// CancelOrder handles order cancellation
+// @Summary Cancel an order
+// @Description Cancels an order that has not reached a terminal status yet. Other users' orders require orders:cancel:any.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param request body dto.CancelOrderRequest false "Cancellation reason"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Missing or invalid access token"
+// @Failure 403 {object} map[string]string "Missing permission"
+// @Failure 404 {object} map[string]string "Order not found"
+// @Failure 409 {object} map[string]string "Order can no longer be cancelled"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/orders/{id}/cancel [post]
+func (h *OrderHandler) CancelOrder(c *gin.Context) {
+	orderID, ok := orderIDParam(c)
+	if !ok {
+		return
+	}
+
+	if _, ok := h.accessibleOrder(c, orderID, auth.PermissionOrdersCancelAny); !ok {
+		return
+	}
+	principal, _ := auth.PrincipalFromGin(c)
+
+	var req dto.CancelOrderRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	order, err := h.updateOrderStatusUseCase.Execute(
+		c.Request.Context(),
+		orderID,
+		entity.OrderStatusCancelled,
+		entity.UserActor(principal.UserID),
+		req.Reason,
+	)
+	if err != nil {
+		respondWithOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// CompleteOrder handles marking an order as fulfilled
+// @Summary Complete an order
+// @Description Marks a processing order as fulfilled, turning its reserved stock into sales. Orders still waiting for backordered stock can't be completed.
+// @Tags orders
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} map[string]string "Invalid order ID"
+// @Failure 401 {object} map[string]string "Missing or invalid access token"
+// @Failure 403 {object} map[string]string "Missing permission"
+// @Failure 404 {object} map[string]string "Order not found"
+// @Failure 409 {object} map[string]string "Order can't be completed"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/orders/{id}/complete [post]
+func (h *OrderHandler) CompleteOrder(c *gin.Context) {
+	orderID, ok := orderIDParam(c)
+	if !ok {
+		return
+	}
+
+	principal, ok := auth.PrincipalFromGin(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": auth.ErrMissingToken.Error()})
+		return
+	}
+
+	order, err := h.updateOrderStatusUseCase.Execute(
+		c.Request.Context(),
+		orderID,
+		entity.OrderStatusCompleted,
+		entity.UserActor(principal.UserID),
+		"order fulfilled",
+	)
+	if err != nil {
+		respondWithOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// GetOrderStatusHistory handles fetching the status history of an order
+// @Summary Get order status history
+// @Description Returns every status transition of an order with actor, reason and timestamp. Other users' orders require orders:read:any.
+// @Tags orders
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderStatusHistoryResponse
+// @Failure 400 {object} map[string]string "Invalid order ID"
+// @Failure 401 {object} map[string]string "Missing or invalid access token"
+// @Failure 403 {object} map[string]string "Missing permission"
+// @Failure 404 {object} map[string]string "Order not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/v1/orders/{id}/history [get]
+func (h *OrderHandler) GetOrderStatusHistory(c *gin.Context) {
+	orderID, ok := orderIDParam(c)
+	if !ok {
+		return
+	}
+
+	if _, ok := h.accessibleOrder(c, orderID, auth.PermissionOrdersReadAny); !ok {
+		return
+	}
+
+	history, err := h.getStatusHistoryUseCase.Execute(c.Request.Context(), orderID)
+	if err != nil {
+		respondWithOrderError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, history)
+}
+
 // Health check endpoint
 // @Summary Health check
 // @Description Check if the order service is running
@@ -59,3 +255,50 @@ func (h *OrderHandler) Health(c *gin.Context) {
 		"service": "order-service",
 	})
 }
+
+// orderIDParam reads and validates the order ID path parameter
+func orderIDParam(c *gin.Context) (string, bool) {
+	orderID := c.Param("id")
+	if _, err := uuid.Parse(orderID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order ID format"})
+		return "", false
+	}
+	return orderID, true
+}
+
+// accessibleOrder fetches the order if the principal may act on it: every
+// order with the anyPermission, otherwise only its own. Orders of other users
+// are reported as not found, so their IDs are not revealed.
+func (h *OrderHandler) accessibleOrder(c *gin.Context, orderID, anyPermission string) (*dto.OrderResponse, bool) {
+	principal, ok := auth.PrincipalFromGin(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": auth.ErrMissingToken.Error()})
+		return nil, false
+	}
+
+	order, err := h.getOrderUseCase.Execute(c.Request.Context(), orderID)
+	if err != nil {
+		respondWithOrderError(c, err)
+		return nil, false
+	}
+
+	if order.UserID != principal.UserID && !principal.HasPermission(anyPermission) {
+		respondWithOrderError(c, entity.ErrOrderNotFound)
+		return nil, false
+	}
+
+	return order, true
+}
+
+// respondWithOrderError maps domain errors to HTTP status codes
+func respondWithOrderError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrOrderNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrInvalidStatusTransition),
+		errors.Is(err, entity.ErrOrderHasBackorders):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -2,9 +2,12 @@ package http
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/auth"
 )
 
-func SetupRouter(orderHandler *OrderHandler) *gin.Engine {
+// SetupRouter configures all routes, order routes require a valid access token.
+// With requireVerifiedEmail only users with a verified email can place orders.
+func SetupRouter(orderHandler *OrderHandler, authMiddleware gin.HandlerFunc, requireVerifiedEmail bool) *gin.Engine {
 	router := gin.Default()
 
 	// Health check
@@ -13,9 +16,25 @@ func SetupRouter(orderHandler *OrderHandler) *gin.Engine {
 	// API v1 routes
 	v1 := router.Group("/api/v1")
 	{
-		orders := v1.Group("/orders")
+		orders := v1.Group("/orders", authMiddleware)
 		{
-			orders.POST("", orderHandler.CreateOrder) // POST /api/v1/orders
+			// Holders of only the :own permissions are limited to their own
+			// orders by the handler
+			canRead := auth.RequirePermission(auth.PermissionOrdersReadOwn, auth.PermissionOrdersReadAny)
+			canCancel := auth.RequirePermission(auth.PermissionOrdersCancelOwn, auth.PermissionOrdersCancelAny)
+			canComplete := auth.RequirePermission(auth.PermissionOrdersComplete)
+
+			createOrder := []gin.HandlerFunc{auth.RequirePermission(auth.PermissionOrdersCreate)}
+			if requireVerifiedEmail {
+				createOrder = append(createOrder, auth.RequireVerifiedEmail())
+			}
+			createOrder = append(createOrder, orderHandler.CreateOrder)
+
+			orders.POST("", createOrder...)                                         // POST /api/v1/orders
+			orders.GET("/:id", canRead, orderHandler.GetOrder)                      // GET /api/v1/orders/:id
+			orders.POST("/:id/cancel", canCancel, orderHandler.CancelOrder)         // POST /api/v1/orders/:id/cancel
+			orders.POST("/:id/complete", canComplete, orderHandler.CompleteOrder)   // POST /api/v1/orders/:id/complete
+			orders.GET("/:id/history", canRead, orderHandler.GetOrderStatusHistory) // GET /api/v1/orders/:id/history
 		}
 	}
 
@@ -0,0 +1,2 @@
+-- Add version column for optimistic concurrency control
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,10 @@
+-- Add fulfillment policy to orders
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS fulfillment_policy VARCHAR(20) NOT NULL DEFAULT 'all_or_nothing';
+
+-- Track requested, reserved and backordered quantities per order line
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS requested_quantity INTEGER;
+UPDATE order_items SET requested_quantity = quantity WHERE requested_quantity IS NULL;
+ALTER TABLE order_items ALTER COLUMN requested_quantity SET NOT NULL;
+
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS reserved_quantity INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS backordered_quantity INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+-- Record the SKU inventory reserved for each order line
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS sku VARCHAR(64) NOT NULL DEFAULT '';
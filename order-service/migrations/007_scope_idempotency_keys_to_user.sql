@@ -0,0 +1,15 @@
+-- Scope idempotency keys to the user, so one user's key never replays or
+-- blocks another user's request
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS user_id UUID;
+
+-- Keys of created orders belong to the order's user, the others can't be
+-- attributed and are dropped
+UPDATE idempotency_keys k
+SET user_id = o.user_id
+FROM orders o
+WHERE k.order_id = o.id AND k.user_id IS NULL;
+DELETE FROM idempotency_keys WHERE user_id IS NULL;
+
+ALTER TABLE idempotency_keys ALTER COLUMN user_id SET NOT NULL;
+ALTER TABLE idempotency_keys DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (user_id, idempotency_key);
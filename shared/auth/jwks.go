@@ -0,0 +1,266 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// jwksCacheTTL is how long fetched keys are used before fetching them again
+	jwksCacheTTL = 5 * time.Minute
+	// jwksMinRefreshInterval limits fetches caused by tokens with unknown key IDs
+	jwksMinRefreshInterval = 30 * time.Second
+)
+
+// publicKey is a verification key of the key set
+type publicKey struct {
+	algorithm string
+	key       crypto.PublicKey
+}
+
+type jwk struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n"`
+	E         string `json:"e"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+}
+
+// PublicKey is a key RS256 or EdDSA tokens are verified with, ID matches
+// the kid header of the tokens
+type PublicKey struct {
+	ID        string
+	Algorithm string
+	Key       crypto.PublicKey
+}
+
+// PublicKeyVerifier verifies tokens with a fixed set of public keys, for the
+// service signing the tokens, which has the keys without fetching them
+type PublicKeyVerifier struct {
+	keys   map[string]publicKey
+	issuer string
+}
+
+// NewPublicKeyVerifier creates a verifier for tokens signed with the keys
+func NewPublicKeyVerifier(keys []PublicKey, issuer string) *PublicKeyVerifier {
+	verifier := &PublicKeyVerifier{
+		keys:   make(map[string]publicKey, len(keys)),
+		issuer: issuer,
+	}
+	for _, key := range keys {
+		verifier.keys[key.ID] = publicKey{algorithm: key.Algorithm, key: key.Key}
+	}
+	return verifier
+}
+
+// Verify checks the token's signature and claims
+func (v *PublicKeyVerifier) Verify(token string) (*Principal, error) {
+	parsed, err := parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := v.keys[parsed.header.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key ID %q", ErrInvalidToken, parsed.header.KeyID)
+	}
+
+	return parsed.verifyWithKey(key, v.issuer)
+}
+
+// JWKSVerifier verifies RS256 and EdDSA tokens with the public keys the user
+// service publishes. Keys are fetched on first use and cached, a token with
+// an unknown key ID makes it fetch the keys again to pick up rotated keys.
+// Only one fetch runs at a time, and it runs without holding the lock, so
+// tokens with cached keys are verified while the key set is fetched.
+type JWKSVerifier struct {
+	url    string
+	issuer string
+	client *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]publicKey
+	fetchedAt time.Time
+	// refreshing is closed when the running fetch finished, nil when none runs
+	refreshing chan struct{}
+}
+
+// NewJWKSVerifier creates a verifier for tokens signed with the keys at url
+func NewJWKSVerifier(url, issuer string) *JWKSVerifier {
+	return &JWKSVerifier{
+		url:    url,
+		issuer: issuer,
+		client: &http.Client{Timeout: 5 * time.Second},
+		keys:   map[string]publicKey{},
+	}
+}
+
+// Verify checks the token's signature and claims
+func (v *JWKSVerifier) Verify(token string) (*Principal, error) {
+	parsed, err := parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.header.KeyID == "" {
+		return nil, fmt.Errorf("%w: missing key ID", ErrInvalidToken)
+	}
+
+	key, ok := v.key(parsed.header.KeyID)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key ID %q", ErrInvalidToken, parsed.header.KeyID)
+	}
+
+	return parsed.verifyWithKey(key, v.issuer)
+}
+
+// key returns the key with the ID, fetching the key set when the cache is
+// stale or does not know the ID
+func (v *JWKSVerifier) key(keyID string) (publicKey, bool) {
+	v.mu.Lock()
+	key, ok := v.keys[keyID]
+	since := time.Since(v.fetchedAt)
+	if (ok && since < jwksCacheTTL) || (!ok && since < jwksMinRefreshInterval) {
+		v.mu.Unlock()
+		return key, ok
+	}
+
+	refreshing := v.refreshing
+	switch {
+	case refreshing != nil && ok:
+		// Another request is fetching the key set, the cached key is still good meanwhile
+		v.mu.Unlock()
+		return key, ok
+	case refreshing != nil:
+		// Wait for the running fetch instead of starting another one
+		v.mu.Unlock()
+		<-refreshing
+	default:
+		refreshing = make(chan struct{})
+		v.refreshing = refreshing
+		v.mu.Unlock()
+		v.refresh(refreshing)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	key, ok = v.keys[keyID]
+	return key, ok
+}
+
+// refresh fetches the key set and stores it, then wakes up the requests
+// waiting for it by closing done
+func (v *JWKSVerifier) refresh(done chan struct{}) {
+	keys, err := v.fetch()
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	defer close(done)
+
+	v.refreshing = nil
+	// Also after a failed fetch, so an unreachable user service is not asked
+	// again for every request
+	v.fetchedAt = time.Now()
+	if err != nil {
+		// Keep verifying with the cached keys until the key set is reachable again
+		log.Printf("Warning: failed to fetch JWKS from %s: %v", v.url, err)
+		return
+	}
+	v.keys = keys
+}
+
+func (v *JWKSVerifier) fetch() (map[string]publicKey, error) {
+	resp, err := v.client.Get(v.url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("failed to decode key set: %w", err)
+	}
+
+	keys := make(map[string]publicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		key, err := k.publicKey()
+		if err != nil {
+			log.Printf("Warning: skipping JWKS key %s: %v", k.KeyID, err)
+			continue
+		}
+		keys[k.KeyID] = key
+	}
+	return keys, nil
+}
+
+func (k jwk) publicKey() (publicKey, error) {
+	switch {
+	case k.KeyType == "RSA" && k.Algorithm == AlgorithmRS256:
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return publicKey{}, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return publicKey{}, fmt.Errorf("invalid exponent: %w", err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return publicKey{}, fmt.Errorf("exponent out of range")
+		}
+		return publicKey{
+			algorithm: AlgorithmRS256,
+			key:       &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())},
+		}, nil
+	case k.KeyType == "OKP" && k.Curve == "Ed25519" && k.Algorithm == AlgorithmEdDSA:
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return publicKey{}, fmt.Errorf("invalid Ed25519 key")
+		}
+		return publicKey{algorithm: AlgorithmEdDSA, key: ed25519.PublicKey(x)}, nil
+	default:
+		return publicKey{}, fmt.Errorf("unsupported key type %s with algorithm %s", k.KeyType, k.Algorithm)
+	}
+}
+
+// verifyWithKey checks the signature with the key matching the token's key ID
+func (t *parsedToken) verifyWithKey(key publicKey, issuer string) (*Principal, error) {
+	// The key decides the algorithm, so a token can't pick a weaker one
+	if t.header.Algorithm != key.algorithm {
+		return nil, fmt.Errorf("%w: unexpected algorithm %q", ErrInvalidToken, t.header.Algorithm)
+	}
+	if !verifySignature(key, t.signingInput, t.signature) {
+		return nil, ErrInvalidToken
+	}
+
+	return t.principal(issuer, time.Now())
+}
+
+func verifySignature(key publicKey, signingInput, signature []byte) bool {
+	switch k := key.key.(type) {
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(signingInput)
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, signingInput, signature)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,338 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// signTestToken builds a token with the given header, signed by sign
+func signTestToken(t *testing.T, header tokenHeader, claims tokenClaims, sign func([]byte) []byte) string {
+	t.Helper()
+
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("json.Marshal() error = %v", err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signingInput := encode(header) + "." + encode(claims)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(signingInput)))
+}
+
+func testClaims() tokenClaims {
+	return tokenClaims{
+		Subject:     "u-1",
+		Role:        "customer",
+		Permissions: []string{PermissionOrdersReadOwn},
+		Issuer:      "user-service",
+		ExpiresAt:   time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+type testKeys struct {
+	ed25519    ed25519.PrivateKey
+	signEdDSA  func([]byte) []byte
+	signRS256  func([]byte) []byte
+	publicKeys []PublicKey
+}
+
+// newTestKeys generates an Ed25519 key "ed" and an RSA key "rsa"
+func newTestKeys(t *testing.T) *testKeys {
+	t.Helper()
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() error = %v", err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+
+	return &testKeys{
+		ed25519: edKey,
+		signEdDSA: func(input []byte) []byte {
+			return ed25519.Sign(edKey, input)
+		},
+		signRS256: func(input []byte) []byte {
+			digest := sha256.Sum256(input)
+			signature, err := rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:])
+			if err != nil {
+				t.Fatalf("rsa.SignPKCS1v15() error = %v", err)
+			}
+			return signature
+		},
+		publicKeys: []PublicKey{
+			{ID: "ed", Algorithm: AlgorithmEdDSA, Key: edKey.Public()},
+			{ID: "rsa", Algorithm: AlgorithmRS256, Key: rsaKey.Public()},
+		},
+	}
+}
+
+func TestPublicKeyVerifierPinsAlgorithmToKey(t *testing.T) {
+	keys := newTestKeys(t)
+	verifier := NewPublicKeyVerifier(keys.publicKeys, "user-service")
+
+	// An HS256 token keyed with the public key, the classic algorithm confusion
+	signWithPublicKey := func(input []byte) []byte {
+		mac := hmac.New(sha256.New, keys.ed25519.Public().(ed25519.PublicKey))
+		mac.Write(input)
+		return mac.Sum(nil)
+	}
+	unsigned := func([]byte) []byte { return nil }
+
+	tests := []struct {
+		name    string
+		header  tokenHeader
+		sign    func([]byte) []byte
+		wantErr error
+	}{
+		{name: "EdDSA", header: tokenHeader{Algorithm: AlgorithmEdDSA, KeyID: "ed"}, sign: keys.signEdDSA},
+		{name: "RS256", header: tokenHeader{Algorithm: AlgorithmRS256, KeyID: "rsa"}, sign: keys.signRS256},
+		{name: "unknown key ID", header: tokenHeader{Algorithm: AlgorithmEdDSA, KeyID: "old"}, sign: keys.signEdDSA, wantErr: ErrInvalidToken},
+		{name: "missing key ID", header: tokenHeader{Algorithm: AlgorithmEdDSA}, sign: keys.signEdDSA, wantErr: ErrInvalidToken},
+		{name: "algorithm of another key", header: tokenHeader{Algorithm: AlgorithmRS256, KeyID: "ed"}, sign: keys.signRS256, wantErr: ErrInvalidToken},
+		{name: "HS256 with the public key", header: tokenHeader{Algorithm: AlgorithmHS256, KeyID: "ed"}, sign: signWithPublicKey, wantErr: ErrInvalidToken},
+		{name: "none", header: tokenHeader{Algorithm: "none", KeyID: "ed"}, sign: unsigned, wantErr: ErrInvalidToken},
+		{name: "signed by another key", header: tokenHeader{Algorithm: AlgorithmEdDSA, KeyID: "ed"}, sign: func(input []byte) []byte {
+			_, other, _ := ed25519.GenerateKey(rand.Reader)
+			return ed25519.Sign(other, input)
+		}, wantErr: ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := signTestToken(t, tt.header, testClaims(), tt.sign)
+
+			principal, err := verifier.Verify(token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (principal.UserID != "u-1" || !principal.HasPermission(PermissionOrdersReadOwn)) {
+				t.Errorf("principal = %+v", principal)
+			}
+		})
+	}
+}
+
+func TestHMACVerifierOnlyAcceptsHS256(t *testing.T) {
+	keys := newTestKeys(t)
+	verifier := NewHMACVerifier("secret", "user-service")
+	signHS256 := func(input []byte) []byte {
+		mac := hmac.New(sha256.New, []byte("secret"))
+		mac.Write(input)
+		return mac.Sum(nil)
+	}
+
+	if _, err := verifier.Verify(signTestToken(t, tokenHeader{Algorithm: AlgorithmHS256}, testClaims(), signHS256)); err != nil {
+		t.Errorf("Verify(HS256) error = %v", err)
+	}
+	if _, err := verifier.Verify(signTestToken(t, tokenHeader{Algorithm: AlgorithmEdDSA, KeyID: "ed"}, testClaims(), keys.signEdDSA)); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify(EdDSA) error = %v, want ErrInvalidToken", err)
+	}
+	if _, err := verifier.Verify(signTestToken(t, tokenHeader{Algorithm: "none"}, testClaims(), func([]byte) []byte { return nil })); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify(none) error = %v, want ErrInvalidToken", err)
+	}
+}
+
+func TestVerifierChecksClaims(t *testing.T) {
+	keys := newTestKeys(t)
+	verifier := NewPublicKeyVerifier(keys.publicKeys, "user-service")
+	header := tokenHeader{Algorithm: AlgorithmEdDSA, KeyID: "ed"}
+
+	tests := []struct {
+		name    string
+		claims  func(*tokenClaims)
+		wantErr error
+	}{
+		{name: "within the clock skew", claims: func(c *tokenClaims) { c.ExpiresAt = time.Now().Add(-clockSkew / 2).Unix() }},
+		{name: "expired", claims: func(c *tokenClaims) { c.ExpiresAt = time.Now().Add(-2 * clockSkew).Unix() }, wantErr: ErrExpiredToken},
+		{name: "no expiry", claims: func(c *tokenClaims) { c.ExpiresAt = 0 }, wantErr: ErrInvalidToken},
+		{name: "no subject", claims: func(c *tokenClaims) { c.Subject = "" }, wantErr: ErrInvalidToken},
+		{name: "other issuer", claims: func(c *tokenClaims) { c.Issuer = "someone-else" }, wantErr: ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := testClaims()
+			tt.claims(&claims)
+
+			if _, err := verifier.Verify(signTestToken(t, header, claims, keys.signEdDSA)); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// jwkFor encodes a public key the way the user service publishes it
+func jwkFor(key PublicKey) jwk {
+	switch k := key.Key.(type) {
+	case *rsa.PublicKey:
+		return jwk{
+			KeyType:   "RSA",
+			KeyID:     key.ID,
+			Algorithm: key.Algorithm,
+			N:         base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			E:         base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}
+	default:
+		return jwk{
+			KeyType:   "OKP",
+			KeyID:     key.ID,
+			Algorithm: key.Algorithm,
+			Curve:     "Ed25519",
+			X:         base64.RawURLEncoding.EncodeToString(k.(ed25519.PublicKey)),
+		}
+	}
+}
+
+func TestJWKSVerifier(t *testing.T) {
+	keys := newTestKeys(t)
+
+	var (
+		mu        sync.Mutex
+		published = []jwk{jwkFor(keys.publicKeys[0])}
+		fetches   int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		fetches++
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": published})
+	}))
+	defer server.Close()
+
+	verifier := NewJWKSVerifier(server.URL, "user-service")
+	edToken := signTestToken(t, tokenHeader{Algorithm: AlgorithmEdDSA, KeyID: "ed"}, testClaims(), keys.signEdDSA)
+	rsaToken := signTestToken(t, tokenHeader{Algorithm: AlgorithmRS256, KeyID: "rsa"}, testClaims(), keys.signRS256)
+
+	if _, err := verifier.Verify(edToken); err != nil {
+		t.Fatalf("Verify(ed) error = %v", err)
+	}
+	if _, err := verifier.Verify(edToken); err != nil || fetches != 1 {
+		t.Fatalf("Verify(ed) again = %v after %d fetches, want the cached key", err, fetches)
+	}
+	if _, err := verifier.Verify(signTestToken(t, tokenHeader{Algorithm: AlgorithmEdDSA}, testClaims(), keys.signEdDSA)); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Verify() without key ID error = %v, want ErrInvalidToken", err)
+	}
+
+	// A key published after the last fetch is unknown until the refresh interval passed
+	mu.Lock()
+	published = append(published, jwkFor(keys.publicKeys[1]), jwk{KeyType: "oct", KeyID: "hmac", Algorithm: AlgorithmHS256})
+	mu.Unlock()
+	if _, err := verifier.Verify(rsaToken); !errors.Is(err, ErrInvalidToken) || fetches != 1 {
+		t.Fatalf("Verify(rsa) = %v after %d fetches, want an unknown key without fetching", err, fetches)
+	}
+
+	verifier.mu.Lock()
+	verifier.fetchedAt = time.Now().Add(-jwksMinRefreshInterval)
+	verifier.mu.Unlock()
+	if _, err := verifier.Verify(rsaToken); err != nil || fetches != 2 {
+		t.Fatalf("Verify(rsa) = %v after %d fetches, want the rotated key fetched", err, fetches)
+	}
+	if _, ok := verifier.keys["hmac"]; ok {
+		t.Error("the unsupported symmetric key was not skipped")
+	}
+
+	// Cached keys keep working while the key set is unreachable
+	server.Close()
+	verifier.mu.Lock()
+	verifier.fetchedAt = time.Now().Add(-jwksCacheTTL)
+	verifier.mu.Unlock()
+	if _, err := verifier.Verify(edToken); err != nil {
+		t.Errorf("Verify(ed) with an unreachable key set error = %v", err)
+	}
+}
+
+func TestJWKSVerifierServesCachedKeysDuringFetch(t *testing.T) {
+	keys := newTestKeys(t)
+
+	var (
+		mu      sync.Mutex
+		fetches int
+		block   chan struct{}
+	)
+	started := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		fetches++
+		wait := block
+		mu.Unlock()
+		if wait != nil {
+			started <- struct{}{}
+			<-wait
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []jwk{jwkFor(keys.publicKeys[0]), jwkFor(keys.publicKeys[1])}})
+	}))
+	defer server.Close()
+
+	verifier := NewJWKSVerifier(server.URL, "user-service")
+	edToken := signTestToken(t, tokenHeader{Algorithm: AlgorithmEdDSA, KeyID: "ed"}, testClaims(), keys.signEdDSA)
+	rsaToken := signTestToken(t, tokenHeader{Algorithm: AlgorithmRS256, KeyID: "rsa"}, testClaims(), keys.signRS256)
+	if _, err := verifier.Verify(edToken); err != nil {
+		t.Fatalf("Verify(ed) error = %v", err)
+	}
+
+	// Hold the next fetch in the handler until released
+	mu.Lock()
+	block = make(chan struct{})
+	mu.Unlock()
+	verifier.mu.Lock()
+	verifier.fetchedAt = time.Now().Add(-jwksCacheTTL)
+	delete(verifier.keys, "rsa")
+	verifier.mu.Unlock()
+
+	results := make(chan error, 2)
+	go func() {
+		_, err := verifier.Verify(edToken)
+		results <- err
+	}()
+	<-started
+
+	// The cached key is used without waiting for the running fetch
+	verified := make(chan error, 1)
+	go func() {
+		_, err := verifier.Verify(edToken)
+		verified <- err
+	}()
+	select {
+	case err := <-verified:
+		if err != nil {
+			t.Fatalf("Verify(ed) during the fetch error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Verify(ed) waited for the running fetch")
+	}
+
+	// An unknown key waits for the running fetch instead of starting another one
+	go func() {
+		_, err := verifier.Verify(rsaToken)
+		results <- err
+	}()
+
+	mu.Lock()
+	close(block)
+	mu.Unlock()
+	for i := 0; i < 2; i++ {
+		if err := <-results; err != nil {
+			t.Errorf("Verify() after the fetch error = %v", err)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if fetches != 2 {
+		t.Errorf("key set fetched %d times, want 2", fetches)
+	}
+}
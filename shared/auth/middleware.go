@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware authenticates requests with a bearer token. The principal is
+// stored in the gin context and the request context, requests without a
+// valid token are rejected with 401.
+func Middleware(verifier Verifier) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			abortUnauthorized(c, ErrMissingToken)
+			return
+		}
+
+		principal, err := verifier.Verify(token)
+		if err != nil {
+			abortUnauthorized(c, err)
+			return
+		}
+
+		c.Set(principalKey, principal)
+		c.Request = c.Request.WithContext(NewContext(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// RequireRole rejects requests whose principal has none of the roles with
+// 403. It has to run after Middleware.
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := PrincipalFromGin(c)
+		if !ok {
+			abortUnauthorized(c, ErrMissingToken)
+			return
+		}
+
+		if !principal.HasRole(roles...) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequirePermission rejects requests whose principal has none of the
+// permissions with 403. It has to run after Middleware.
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := PrincipalFromGin(c)
+		if !ok {
+			abortUnauthorized(c, ErrMissingToken)
+			return
+		}
+
+		if !principal.HasPermission(permissions...) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequireVerifiedEmail rejects requests of principals whose email is not
+// verified with 403. It has to run after Middleware.
+func RequireVerifiedEmail() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := PrincipalFromGin(c)
+		if !ok {
+			abortUnauthorized(c, ErrMissingToken)
+			return
+		}
+
+		if !principal.EmailVerified {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "email address is not verified"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func bearerToken(header string) (string, bool) {
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func abortUnauthorized(c *gin.Context, err error) {
+	c.Header("WWW-Authenticate", `Bearer realm="api"`)
+
+	// Why exactly a token was rejected is not told to the client
+	message := ErrInvalidToken.Error()
+	if errors.Is(err, ErrMissingToken) || errors.Is(err, ErrExpiredToken) {
+		message = err.Error()
+	}
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": message})
+}
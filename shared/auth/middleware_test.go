@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// stubVerifier accepts the token "valid" as principal
+type stubVerifier struct {
+	principal *Principal
+}
+
+func (v stubVerifier) Verify(token string) (*Principal, error) {
+	switch token {
+	case "valid":
+		return v.principal, nil
+	case "expired":
+		return nil, ErrExpiredToken
+	default:
+		return nil, ErrInvalidToken
+	}
+}
+
+// serve runs the handlers for a GET / with the Authorization header and
+// returns the response
+func serve(authorization string, handlers ...gin.HandlerFunc) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/", append(handlers, func(c *gin.Context) {
+		principal, _ := PrincipalFromGin(c)
+		fromContext, _ := FromContext(c.Request.Context())
+		if principal != fromContext {
+			c.String(http.StatusInternalServerError, "principal missing from the request context")
+			return
+		}
+		c.String(http.StatusOK, principal.UserID)
+	})...)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware(t *testing.T) {
+	verifier := stubVerifier{principal: &Principal{UserID: "u-1", Role: "customer"}}
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantBody      string
+	}{
+		{name: "valid token", authorization: "Bearer valid", wantStatus: http.StatusOK, wantBody: "u-1"},
+		{name: "scheme is case insensitive", authorization: "bearer valid", wantStatus: http.StatusOK, wantBody: "u-1"},
+		{name: "no header", wantStatus: http.StatusUnauthorized, wantBody: `{"error":"missing bearer token"}`},
+		{name: "basic auth", authorization: "Basic dTpw", wantStatus: http.StatusUnauthorized, wantBody: `{"error":"missing bearer token"}`},
+		{name: "empty token", authorization: "Bearer  ", wantStatus: http.StatusUnauthorized, wantBody: `{"error":"missing bearer token"}`},
+		{name: "expired token", authorization: "Bearer expired", wantStatus: http.StatusUnauthorized, wantBody: `{"error":"token has expired"}`},
+		{name: "invalid token", authorization: "Bearer forged", wantStatus: http.StatusUnauthorized, wantBody: `{"error":"invalid token"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serve(tt.authorization, Middleware(verifier))
+
+			if w.Code != tt.wantStatus || w.Body.String() != tt.wantBody {
+				t.Errorf("got %d %s, want %d %s", w.Code, w.Body.String(), tt.wantStatus, tt.wantBody)
+			}
+			if tt.wantStatus == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+				t.Error("missing WWW-Authenticate header")
+			}
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	verifier := stubVerifier{principal: &Principal{UserID: "u-1", Role: "customer"}}
+
+	if w := serve("Bearer valid", Middleware(verifier), RequireRole("admin", "customer")); w.Code != http.StatusOK {
+		t.Errorf("matching role: got %d %s", w.Code, w.Body.String())
+	}
+	if w := serve("Bearer valid", Middleware(verifier), RequireRole("admin")); w.Code != http.StatusForbidden {
+		t.Errorf("other role: got %d, want 403", w.Code)
+	}
+	// Without Middleware there is no principal to check
+	if w := serve("Bearer valid", RequireRole("customer")); w.Code != http.StatusUnauthorized {
+		t.Errorf("no principal: got %d, want 401", w.Code)
+	}
+}
+
+func TestRequirePermission(t *testing.T) {
+	verifier := stubVerifier{principal: &Principal{
+		UserID:      "u-1",
+		Role:        "customer",
+		Permissions: []string{PermissionOrdersReadOwn, PermissionOrdersCreate},
+	}}
+
+	tests := []struct {
+		name        string
+		permissions []string
+		wantStatus  int
+	}{
+		{name: "granted", permissions: []string{PermissionOrdersCreate}, wantStatus: http.StatusOK},
+		{name: "any of several", permissions: []string{PermissionOrdersReadAny, PermissionOrdersReadOwn}, wantStatus: http.StatusOK},
+		{name: "own does not cover any", permissions: []string{PermissionOrdersReadAny}, wantStatus: http.StatusForbidden},
+		{name: "none given", wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := serve("Bearer valid", Middleware(verifier), RequirePermission(tt.permissions...)); w.Code != tt.wantStatus {
+				t.Errorf("got %d %s, want %d", w.Code, w.Body.String(), tt.wantStatus)
+			}
+		})
+	}
+
+	if w := serve("Bearer valid", RequirePermission(PermissionOrdersCreate)); w.Code != http.StatusUnauthorized {
+		t.Errorf("no principal: got %d, want 401", w.Code)
+	}
+}
+
+func TestRequireVerifiedEmail(t *testing.T) {
+	verified := stubVerifier{principal: &Principal{UserID: "u-1", EmailVerified: true}}
+	unverified := stubVerifier{principal: &Principal{UserID: "u-2"}}
+
+	if w := serve("Bearer valid", Middleware(verified), RequireVerifiedEmail()); w.Code != http.StatusOK {
+		t.Errorf("verified: got %d %s", w.Code, w.Body.String())
+	}
+	w := serve("Bearer valid", Middleware(unverified), RequireVerifiedEmail())
+	if w.Code != http.StatusForbidden || w.Body.String() != `{"error":"email address is not verified"}` {
+		t.Errorf("unverified: got %d %s, want 403", w.Code, w.Body.String())
+	}
+	if w := serve("Bearer valid", RequireVerifiedEmail()); w.Code != http.StatusUnauthorized {
+		t.Errorf("no principal: got %d, want 401", w.Code)
+	}
+}
@@ -0,0 +1,25 @@
+package auth
+
+// Permissions checked by the services. The user service stores which role
+// has which permission and embeds the permissions of the user's role in the
+// access token. A permission ending in :own only covers the user's own
+// resources, the same permission ending in :any covers everyone's.
+const (
+	PermissionUsersReadAny  = "users:read:any"
+	PermissionUsersWriteAny = "users:write:any"
+	PermissionRolesManage   = "roles:manage"
+
+	PermissionOrdersCreate    = "orders:create"
+	PermissionOrdersReadOwn   = "orders:read:own"
+	PermissionOrdersReadAny   = "orders:read:any"
+	PermissionOrdersCancelOwn = "orders:cancel:own"
+	PermissionOrdersCancelAny = "orders:cancel:any"
+	PermissionOrdersComplete  = "orders:complete"
+
+	PermissionProductsWrite   = "products:write"
+	PermissionPricesWrite     = "prices:write"
+	PermissionCatalogWrite    = "catalog:write"
+	PermissionStockRead       = "stock:read"
+	PermissionStockWrite      = "stock:write"
+	PermissionWarehousesWrite = "warehouses:write"
+)
@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Principal is the authenticated user a request is made by
+type Principal struct {
+	UserID        string
+	Email         string
+	EmailVerified bool
+	Role          string
+	Permissions   []string
+	ExpiresAt     time.Time
+}
+
+// HasRole checks if the principal has one of the given roles
+func (p *Principal) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if p.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermission checks if the principal has one of the given permissions
+func (p *Principal) HasPermission(permissions ...string) bool {
+	for _, granted := range p.Permissions {
+		for _, permission := range permissions {
+			if granted == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type principalContextKey struct{}
+
+// principalKey is the key the principal is stored under in the gin context
+const principalKey = "auth.principal"
+
+// NewContext returns a copy of ctx carrying the principal
+func NewContext(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// FromContext returns the principal of an authenticated request, for use
+// cases that only get the request context
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return principal, ok
+}
+
+// PrincipalFromGin returns the principal set by Middleware
+func PrincipalFromGin(c *gin.Context) (*Principal, bool) {
+	value, exists := c.Get(principalKey)
+	if !exists {
+		return nil, false
+	}
+	principal, ok := value.(*Principal)
+	return principal, ok
+}
@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMissingToken = errors.New("missing bearer token")
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token has expired")
+)
+
+// Signing algorithms of the tokens issued by the user service
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// clockSkew is how long a token is still accepted after its expiry, for
+// clocks of the issuing and verifying service that differ slightly
+const clockSkew = 30 * time.Second
+
+// Verifier checks an access token and returns the principal it was issued to
+type Verifier interface {
+	Verify(token string) (*Principal, error)
+}
+
+// Config selects how tokens are verified. Tokens are verified against the
+// key set at JWKSURL when it is set, otherwise with the shared Secret.
+type Config struct {
+	JWKSURL string
+	Secret  string
+	// Issuer is the required iss claim, not checked when empty
+	Issuer string
+}
+
+// NewVerifier creates the verifier selected by the config
+func NewVerifier(cfg Config) (Verifier, error) {
+	switch {
+	case cfg.JWKSURL != "":
+		return NewJWKSVerifier(cfg.JWKSURL, cfg.Issuer), nil
+	case cfg.Secret != "":
+		return NewHMACVerifier(cfg.Secret, cfg.Issuer), nil
+	default:
+		return nil, errors.New("either a JWKS URL or a JWT secret is required to verify tokens")
+	}
+}
+
+type tokenHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+type tokenClaims struct {
+	Subject       string   `json:"sub"`
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+	Role          string   `json:"role"`
+	Permissions   []string `json:"permissions"`
+	Issuer        string   `json:"iss"`
+	ExpiresAt     int64    `json:"exp"`
+}
+
+// parsedToken is a token split into its parts, its signature not verified yet
+type parsedToken struct {
+	header       tokenHeader
+	claims       tokenClaims
+	signingInput []byte
+	signature    []byte
+}
+
+func parseToken(token string) (*parsedToken, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	parsed := &parsedToken{
+		signingInput: []byte(parts[0] + "." + parts[1]),
+	}
+	if err := decodeSegment(parts[0], &parsed.header); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if err := decodeSegment(parts[1], &parsed.claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	parsed.signature = signature
+
+	return parsed, nil
+}
+
+// principal validates the claims of a token whose signature was verified
+func (t *parsedToken) principal(issuer string, now time.Time) (*Principal, error) {
+	if t.claims.Subject == "" || t.claims.ExpiresAt == 0 {
+		return nil, ErrInvalidToken
+	}
+	if issuer != "" && t.claims.Issuer != issuer {
+		return nil, fmt.Errorf("%w: unexpected issuer %q", ErrInvalidToken, t.claims.Issuer)
+	}
+
+	expiresAt := time.Unix(t.claims.ExpiresAt, 0).UTC()
+	if now.After(expiresAt.Add(clockSkew)) {
+		return nil, ErrExpiredToken
+	}
+
+	return &Principal{
+		UserID:        t.claims.Subject,
+		Email:         t.claims.Email,
+		EmailVerified: t.claims.EmailVerified,
+		Role:          t.claims.Role,
+		Permissions:   t.claims.Permissions,
+		ExpiresAt:     expiresAt,
+	}, nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// HMACVerifier verifies HS256 tokens signed with a shared secret
+type HMACVerifier struct {
+	secret []byte
+	issuer string
+}
+
+// NewHMACVerifier creates a verifier for tokens signed with the secret
+func NewHMACVerifier(secret, issuer string) *HMACVerifier {
+	return &HMACVerifier{
+		secret: []byte(secret),
+		issuer: issuer,
+	}
+}
+
+// Verify checks the token's signature and claims
+func (v *HMACVerifier) Verify(token string) (*Principal, error) {
+	parsed, err := parseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	// Only HS256 is accepted, the algorithm is never taken from the token
+	if parsed.header.Algorithm != AlgorithmHS256 {
+		return nil, fmt.Errorf("%w: unexpected algorithm %q", ErrInvalidToken, parsed.header.Algorithm)
+	}
+
+	mac := hmac.New(sha256.New, v.secret)
+	mac.Write(parsed.signingInput)
+	if !hmac.Equal(parsed.signature, mac.Sum(nil)) {
+		return nil, ErrInvalidToken
+	}
+
+	return parsed.principal(v.issuer, time.Now())
+}
@@ -9,12 +9,35 @@ type InventoryReservedEvent struct {
     Reservations []InventoryReservation `json:"reservations"`
 }
 
-// InventoryReservation represents a single item reservation
+// InventoryReservation represents the outcome of reserving a single item
 type InventoryReservation struct {
-    ProductID string `json:"product_id"`
-    Quantity  int    `json:"quantity"`
+    // ProductID is the reserved SKU's product, a variant when the product has variants
+    ProductID           string `json:"product_id"`
+    SKU                 string `json:"sku,omitempty"`
+    Quantity            int    `json:"quantity"` // Quantity actually reserved
+    RequestedQuantity   int    `json:"requested_quantity"`
+    BackorderedQuantity int    `json:"backordered_quantity"`
+    Status              string `json:"status"`
+    // WarehouseID is set when the whole reserved quantity ships from one warehouse
+    WarehouseID string                `json:"warehouse_id,omitempty"`
+    Allocations []InventoryAllocation `json:"allocations"`
+}
+
+// InventoryAllocation is the part of a line reserved in a single warehouse
+type InventoryAllocation struct {
+    WarehouseID   string `json:"warehouse_id"`
+    WarehouseCode string `json:"warehouse_code"`
+    Quantity      int    `json:"quantity"`
 }
 
+// Reservation line statuses
+const (
+    ReservationStatusReserved          = "reserved"
+    ReservationStatusPartiallyReserved = "partially_reserved"
+    ReservationStatusBackordered       = "backordered"
+    ReservationStatusUnavailable       = "unavailable"
+)
+
 // InventoryReservationFailedEvent is published when inventory reservation fails
 type InventoryReservationFailedEvent struct {
     BaseEvent
@@ -23,8 +46,40 @@ type InventoryReservationFailedEvent struct {
     Reason    string `json:"reason"`
 }
 
+// InventoryBackorderFulfilledEvent is published when arriving stock is reserved for a backordered line
+type InventoryBackorderFulfilledEvent struct {
+    BaseEvent
+    OrderID           string `json:"order_id"`
+    ProductID         string `json:"product_id"`
+    WarehouseID       string `json:"warehouse_id"`
+    Quantity          int    `json:"quantity"`
+    RemainingQuantity int    `json:"remaining_quantity"`
+}
+
+// InventoryStockLevelEvent is published when a product's available stock crosses its reorder threshold
+type InventoryStockLevelEvent struct {
+    BaseEvent
+    ProductID        string `json:"product_id"`
+    ProductName      string `json:"product_name"`
+    AvailableStock   int    `json:"available_stock"`
+    ReorderThreshold int    `json:"reorder_threshold"`
+    PreviousStatus   string `json:"previous_status"`
+    Status           string `json:"status"`
+}
+
+// Stock level statuses
+const (
+    StockStatusInStock    = "in_stock"
+    StockStatusLowStock   = "low_stock"
+    StockStatusOutOfStock = "out_of_stock"
+)
+
 // Event type constants
 const (
     InventoryReservedEventType           = "inventory.reserved"
     InventoryReservationFailedEventType  = "inventory.reservation_failed"
+    InventoryBackorderFulfilledEventType = "inventory.backorder_fulfilled"
+    InventoryLowStockEventType           = "inventory.low_stock"
+    InventoryOutOfStockEventType         = "inventory.out_of_stock"
+    InventoryRestockedEventType          = "inventory.restocked"
 )
\ No newline at end of file
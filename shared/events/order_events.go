@@ -5,12 +5,28 @@ package events
 // OrderCreatedEvent is published when a new order is created
 type OrderCreatedEvent struct {
     BaseEvent
-    OrderID      string  `json:"order_id"`
-    UserID       string  `json:"user_id"`
-    TotalAmount  float64 `json:"total_amount"`
-    Items        []OrderItem `json:"items"`
+    OrderID           string       `json:"order_id"`
+    UserID            string       `json:"user_id"`
+    TotalAmount       float64      `json:"total_amount"`
+    Items             []OrderItem  `json:"items"`
+    FulfillmentPolicy string       `json:"fulfillment_policy"`
+    ShippingLocation  *GeoLocation `json:"shipping_location,omitempty"`
 }
 
+// GeoLocation is a point in decimal degrees, used to ship from the nearest warehouse
+type GeoLocation struct {
+    Latitude  float64 `json:"latitude"`
+    Longitude float64 `json:"longitude"`
+}
+
+// Fulfillment policies decide what happens when not every item is in stock.
+// An empty policy is treated as all-or-nothing.
+const (
+    FulfillmentPolicyAllOrNothing = "all_or_nothing"
+    FulfillmentPolicyAllowPartial = "allow_partial"
+    FulfillmentPolicyBackorder    = "backorder"
+)
+
 // OrderItem represents an item in the order
 type OrderItem struct {
     ProductID string  `json:"product_id"`
@@ -33,9 +49,18 @@ type OrderFailedEvent struct {
     Reason  string `json:"reason"`
 }
 
+// OrderCancelledEvent is published when an order is cancelled before completion
+type OrderCancelledEvent struct {
+    BaseEvent
+    OrderID string `json:"order_id"`
+    UserID  string `json:"user_id"`
+    Reason  string `json:"reason"`
+}
+
 // Event type constants
 const (
     OrderCreatedEventType   = "order.created"
     OrderCompletedEventType = "order.completed"
     OrderFailedEventType    = "order.failed"
+    OrderCancelledEventType = "order.cancelled"
 )
@@ -0,0 +1,51 @@
+package events
+
+// Product catalog events, consumed by services that keep a local copy of products
+
+// ProductEvent carries the product's state after the change. It is published
+// as product.created, product.updated and product.deactivated.
+type ProductEvent struct {
+    BaseEvent
+    ProductID   string            `json:"product_id"`
+    ParentID    string            `json:"parent_id,omitempty"`
+    SKU         string            `json:"sku,omitempty"`
+    Name        string            `json:"name"`
+    Description string            `json:"description"`
+    Price       float64           `json:"price"`
+    IsActive    bool              `json:"is_active"`
+    CategoryID  string            `json:"category_id,omitempty"`
+    Options     map[string]string `json:"options,omitempty"`
+    // Version orders the events of a product, an older version than the one
+    // already applied can be ignored
+    Version int `json:"version"`
+}
+
+// ProductPriceChangedEvent is published when a product's price changes
+type ProductPriceChangedEvent struct {
+    BaseEvent
+    ProductID     string  `json:"product_id"`
+    SKU           string  `json:"sku,omitempty"`
+    PreviousPrice float64 `json:"previous_price"`
+    Price         float64 `json:"price"`
+    Version       int     `json:"version"`
+}
+
+// ProductStockChangedEvent is published when a product's stock or reserved stock changes
+type ProductStockChangedEvent struct {
+    BaseEvent
+    ProductID      string `json:"product_id"`
+    SKU            string `json:"sku,omitempty"`
+    StockQuantity  int    `json:"stock_quantity"`
+    ReservedStock  int    `json:"reserved_stock"`
+    AvailableStock int    `json:"available_stock"`
+    Version        int    `json:"version"`
+}
+
+// Event type constants
+const (
+    ProductCreatedEventType      = "product.created"
+    ProductUpdatedEventType      = "product.updated"
+    ProductPriceChangedEventType = "product.price_changed"
+    ProductDeactivatedEventType  = "product.deactivated"
+    ProductStockChangedEventType = "product.stock_changed"
+)
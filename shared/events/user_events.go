@@ -0,0 +1,59 @@
+package events
+
+import "time"
+
+// User account events, consumed by notification-service to email the user
+
+// UserRegisteredEvent is published when a user registers. VerificationToken
+// is the single-use token to send to Email for verifying it.
+type UserRegisteredEvent struct {
+    BaseEvent
+    UserID                     string    `json:"user_id"`
+    Email                      string    `json:"email"`
+    FirstName                  string    `json:"first_name"`
+    LastName                   string    `json:"last_name"`
+    VerificationToken          string    `json:"verification_token"`
+    VerificationTokenExpiresAt time.Time `json:"verification_token_expires_at"`
+}
+
+// UserEmailVerificationRequestedEvent is published when a user asks for a
+// new email verification token, e.g. because the first one expired
+type UserEmailVerificationRequestedEvent struct {
+    BaseEvent
+    UserID    string    `json:"user_id"`
+    Email     string    `json:"email"`
+    FirstName string    `json:"first_name"`
+    Token     string    `json:"token"`
+    ExpiresAt time.Time `json:"expires_at"`
+}
+
+// UserPasswordResetRequestedEvent is published when a user asks to reset a
+// forgotten password. Token is the single-use reset token to send to Email,
+// it is not stored anywhere in plain text.
+type UserPasswordResetRequestedEvent struct {
+    BaseEvent
+    UserID    string    `json:"user_id"`
+    Email     string    `json:"email"`
+    FirstName string    `json:"first_name"`
+    Token     string    `json:"token"`
+    ExpiresAt time.Time `json:"expires_at"`
+}
+
+// UserLockedEvent is published when logins of a user are locked after too
+// many failed attempts
+type UserLockedEvent struct {
+    BaseEvent
+    UserID         string    `json:"user_id"`
+    Email          string    `json:"email"`
+    FirstName      string    `json:"first_name"`
+    FailedAttempts int       `json:"failed_attempts"`
+    LockedUntil    time.Time `json:"locked_until"`
+}
+
+// Event type constants
+const (
+    UserRegisteredEventType                 = "user.registered"
+    UserEmailVerificationRequestedEventType = "user.email_verification_requested"
+    UserPasswordResetRequestedEventType     = "user.password_reset_requested"
+    UserLockedEventType                     = "user.locked"
+)
@@ -0,0 +1,24 @@
+package retry
+
+import (
+	"context"
+	"errors"
+)
+
+// MaxConflictAttempts bounds how often an operation is re-run after losing an optimistic concurrency race
+const MaxConflictAttempts = 3
+
+// OnConflict re-runs fn, which must re-read the state it modifies,
+// as long as it fails with conflictErr because another writer got there first
+func OnConflict(ctx context.Context, conflictErr error, fn func() error) error {
+	var err error
+	for attempt := 0; attempt < MaxConflictAttempts; attempt++ {
+		if err = fn(); !errors.Is(err, conflictErr) {
+			return err
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+	}
+	return err
+}
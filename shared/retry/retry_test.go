@@ -0,0 +1,65 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestOnConflict(t *testing.T) {
+	errConflict := errors.New("modified concurrently")
+	errOther := errors.New("boom")
+
+	tests := []struct {
+		name      string
+		results   []error
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "success runs once", results: []error{nil}, wantCalls: 1},
+		{name: "other errors are not retried", results: []error{errOther}, wantErr: errOther, wantCalls: 1},
+		{name: "a lost race is retried", results: []error{errConflict, nil}, wantCalls: 2},
+		{name: "wrapped conflicts are retried", results: []error{wrap(errConflict), nil}, wantCalls: 2},
+		{
+			name:      "gives up after the last attempt",
+			results:   []error{errConflict, errConflict, errConflict, nil},
+			wantErr:   errConflict,
+			wantCalls: MaxConflictAttempts,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := OnConflict(context.Background(), errConflict, func() error {
+				calls++
+				return tt.results[calls-1]
+			})
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("OnConflict() error = %v, want %v", err, tt.wantErr)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("OnConflict() ran fn %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestOnConflictStopsWhenContextIsDone(t *testing.T) {
+	errConflict := errors.New("modified concurrently")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	err := OnConflict(ctx, errConflict, func() error {
+		calls++
+		return errConflict
+	})
+	if !errors.Is(err, context.Canceled) || calls != 1 {
+		t.Errorf("OnConflict() = %v after %d calls, want context.Canceled after 1", err, calls)
+	}
+}
+
+func wrap(err error) error {
+	return fmt.Errorf("failed to update: %w", err)
+}
@@ -3,10 +3,18 @@ package main
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/auth"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/messaging"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/usecase"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/service"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/infrastructure/config"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/infrastructure/persistence"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/infrastructure/security"
 	httpHandler "github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/presentation/http"
 )
 
@@ -27,18 +35,147 @@ func main() {
 	}
 	defer db.Close()
 
-	// Initialize repository
+	// Initialize RabbitMQ connection
+	rabbitConn, err := messaging.NewRabbitMQConnection(
+		getEnv("RABBITMQ_HOST", "localhost"),
+		getEnv("RABBITMQ_PORT", "5672"),
+		getEnv("RABBITMQ_USER", "admin"),
+		getEnv("RABBITMQ_PASSWORD", "admin"),
+	)
+	if err != nil {
+		log.Fatalf("Failed to connect to RabbitMQ: %v", err)
+	}
+	defer rabbitConn.Close()
+
+	// Initialize publisher
+	publisher, err := messaging.NewEventPublisher(rabbitConn, "ecommerce-events")
+	if err != nil {
+		log.Fatalf("Failed to create publisher: %v", err)
+	}
+
+	// Initialize repositories
 	userRepo := persistence.NewPostgresUserRepository(db)
+	refreshTokenRepo := persistence.NewPostgresRefreshTokenRepository(db)
+	roleRepo := persistence.NewPostgresRoleRepository(db)
+	passwordResetTokenRepo := persistence.NewPostgresPasswordResetTokenRepository(db)
+	emailVerificationTokenRepo := persistence.NewPostgresEmailVerificationTokenRepository(db)
+	loginFailureRepo := persistence.NewPostgresLoginFailureRepository(db)
+	twoFactorRepo := persistence.NewPostgresTwoFactorRepository(db)
+	loginChallengeRepo := persistence.NewPostgresLoginChallengeRepository(db)
+
+	// Initialize token service
+	tokenService, err := newTokenService(getDurationEnv("JWT_EXPIRY", 15*time.Minute))
+	if err != nil {
+		log.Fatalf("Failed to initialize token service: %v", err)
+	}
 
 	// Initialize use cases
-	registerUseCase := usecase.NewRegisterUserUseCase(userRepo)
-	loginUseCase := usecase.NewLoginUserUseCase(userRepo)
+	emailVerificationTokenTTL := getDurationEnv("EMAIL_VERIFICATION_TOKEN_EXPIRY", 24*time.Hour)
+	registerUseCase := usecase.NewRegisterUserUseCase(userRepo, emailVerificationTokenRepo, publisher, emailVerificationTokenTTL)
+	refreshTokenTTL := getDurationEnv("REFRESH_TOKEN_EXPIRY", 30*24*time.Hour)
+	lockoutDuration := getDurationEnv("LOGIN_LOCKOUT_DURATION", 15*time.Minute)
+	loginUseCase := usecase.NewLoginUserUseCase(
+		userRepo,
+		refreshTokenRepo,
+		roleRepo,
+		loginFailureRepo,
+		twoFactorRepo,
+		loginChallengeRepo,
+		tokenService,
+		publisher,
+		refreshTokenTTL,
+		usecase.LoginThrottles{
+			// Delays of 1s, 2s, 4s, ... between guesses before the lockout
+			Account: entity.LoginThrottle{
+				MaxAttempts:     getIntEnv("LOGIN_MAX_FAILED_ATTEMPTS", 5),
+				LockoutDuration: lockoutDuration,
+				BaseDelay:       time.Second,
+				MaxDelay:        time.Minute,
+				ResetAfter:      time.Hour,
+			},
+			// Clients trying many accounts are only locked out
+			IP: entity.LoginThrottle{
+				MaxAttempts:     getIntEnv("LOGIN_MAX_FAILED_ATTEMPTS_PER_IP", 20),
+				LockoutDuration: lockoutDuration,
+				ResetAfter:      time.Hour,
+			},
+		},
+	)
+	refreshTokenUseCase := usecase.NewRefreshTokenUseCase(userRepo, refreshTokenRepo, roleRepo, tokenService, refreshTokenTTL)
+	logoutUseCase := usecase.NewLogoutUserUseCase(refreshTokenRepo)
+	logoutAllSessionsUseCase := usecase.NewLogoutAllSessionsUseCase(refreshTokenRepo)
+	requestPasswordResetUseCase := usecase.NewRequestPasswordResetUseCase(
+		userRepo,
+		passwordResetTokenRepo,
+		publisher,
+		getDurationEnv("PASSWORD_RESET_TOKEN_EXPIRY", time.Hour),
+	)
+	resetPasswordUseCase := usecase.NewResetPasswordUseCase(userRepo, passwordResetTokenRepo)
+	verifyEmailUseCase := usecase.NewVerifyEmailUseCase(emailVerificationTokenRepo)
+	resendEmailVerificationUseCase := usecase.NewResendEmailVerificationUseCase(
+		userRepo,
+		emailVerificationTokenRepo,
+		publisher,
+		emailVerificationTokenTTL,
+	)
+	getJWKSUseCase := usecase.NewGetJWKSUseCase(tokenService)
+	getUserUseCase := usecase.NewGetUserUseCase(userRepo)
+	updateUserUseCase := usecase.NewUpdateUserUseCase(userRepo)
+	listUsersUseCase := usecase.NewListUsersUseCase(userRepo)
+	deleteUserUseCase := usecase.NewDeleteUserUseCase(userRepo, refreshTokenRepo)
+	listRolesUseCase := usecase.NewListRolesUseCase(roleRepo)
+	createRoleUseCase := usecase.NewCreateRoleUseCase(roleRepo)
+	setRolePermissionsUseCase := usecase.NewSetRolePermissionsUseCase(roleRepo)
+	listPermissionsUseCase := usecase.NewListPermissionsUseCase(roleRepo)
+	assignUserRoleUseCase := usecase.NewAssignUserRoleUseCase(userRepo)
+	startTwoFactorEnrolmentUseCase := usecase.NewStartTwoFactorEnrolmentUseCase(
+		userRepo,
+		twoFactorRepo,
+		getEnv("TWO_FACTOR_ISSUER", "Ecommerce"),
+	)
+	confirmTwoFactorUseCase := usecase.NewConfirmTwoFactorUseCase(twoFactorRepo)
+	disableTwoFactorUseCase := usecase.NewDisableTwoFactorUseCase(twoFactorRepo)
 
-	// Initialize HTTP handler
+	// Initialize HTTP handlers
 	userHandler := httpHandler.NewUserHandler(registerUseCase, loginUseCase)
+	sessionHandler := httpHandler.NewSessionHandler(refreshTokenUseCase, logoutUseCase, logoutAllSessionsUseCase)
+	passwordHandler := httpHandler.NewPasswordHandler(requestPasswordResetUseCase, resetPasswordUseCase)
+	emailVerificationHandler := httpHandler.NewEmailVerificationHandler(verifyEmailUseCase, resendEmailVerificationUseCase)
+	keyHandler := httpHandler.NewKeyHandler(getJWKSUseCase)
+	profileHandler := httpHandler.NewProfileHandler(getUserUseCase, updateUserUseCase)
+	adminUserHandler := httpHandler.NewAdminUserHandler(listUsersUseCase, getUserUseCase, updateUserUseCase, deleteUserUseCase)
+	roleHandler := httpHandler.NewRoleHandler(
+		listRolesUseCase,
+		createRoleUseCase,
+		setRolePermissionsUseCase,
+		listPermissionsUseCase,
+		assignUserRoleUseCase,
+	)
+	twoFactorHandler := httpHandler.NewTwoFactorHandler(
+		startTwoFactorEnrolmentUseCase,
+		confirmTwoFactorUseCase,
+		disableTwoFactorUseCase,
+	)
 
 	// Setup router
-	router := httpHandler.SetupRouter(userHandler)
+	router := httpHandler.SetupRouter(
+		userHandler,
+		sessionHandler,
+		passwordHandler,
+		emailVerificationHandler,
+		keyHandler,
+		profileHandler,
+		adminUserHandler,
+		roleHandler,
+		twoFactorHandler,
+		auth.Middleware(newTokenVerifier(tokenService)),
+	)
+
+	// Failed logins are throttled per client IP, so only the gateway may set
+	// it through X-Forwarded-For. Without trusted proxies the peer address is used.
+	if err := router.SetTrustedProxies(getListEnv("TRUSTED_PROXIES")); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
 
 	// Start server
 	port := getEnv("PORT", "8081")
@@ -48,6 +185,41 @@ func main() {
 	}
 }
 
+// newTokenService signs tokens with the keys in JWT_SIGNING_KEYS_DIR when it
+// is set, falling back to HS256 with JWT_SECRET
+func newTokenService(expiry time.Duration) (service.TokenService, error) {
+	keysDir := os.Getenv("JWT_SIGNING_KEYS_DIR")
+	if keysDir == "" {
+		log.Println("JWT_SIGNING_KEYS_DIR not set, signing tokens with JWT_SECRET (HS256)")
+		return security.NewJWTTokenService(os.Getenv("JWT_SECRET"), expiry)
+	}
+
+	keys, err := security.LoadSigningKeys(keysDir)
+	if err != nil {
+		return nil, err
+	}
+	return security.NewAsymmetricJWTTokenService(keys, os.Getenv("JWT_SIGNING_KEY_ID"), expiry)
+}
+
+// newTokenVerifier verifies the service's own tokens with the keys it signs
+// them with, instead of fetching its own key set
+func newTokenVerifier(tokenService service.TokenService) auth.Verifier {
+	publicKeys := tokenService.PublicKeys()
+	if len(publicKeys) == 0 {
+		return auth.NewHMACVerifier(os.Getenv("JWT_SECRET"), security.Issuer)
+	}
+
+	keys := make([]auth.PublicKey, len(publicKeys))
+	for i, publicKey := range publicKeys {
+		keys[i] = auth.PublicKey{
+			ID:        publicKey.ID,
+			Algorithm: publicKey.Algorithm,
+			Key:       publicKey.Key,
+		}
+	}
+	return auth.NewPublicKeyVerifier(keys, security.Issuer)
+}
+
 // getEnv gets environment variable or returns default value
 func getEnv(key, defaultValue string) string {
 	value := os.Getenv(key)
@@ -56,3 +228,32 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+// getDurationEnv gets a duration environment variable or returns default value
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// getListEnv gets a comma separated environment variable, nil when it is not set
+func getListEnv(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getIntEnv gets a positive integer environment variable or returns default value
+func getIntEnv(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,45 @@
+package dto
+
+import "time"
+
+// RoleResponse represents a role with the permissions it grants
+type RoleResponse struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ListRolesResponse represents all roles
+type ListRolesResponse struct {
+	Roles []RoleResponse `json:"roles"`
+}
+
+// CreateRoleRequest represents a request to create a role
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions" binding:"omitempty,dive,required"`
+}
+
+// SetRolePermissionsRequest represents the complete new set of permissions
+// of a role, an empty list removes all of them
+type SetRolePermissionsRequest struct {
+	Permissions []string `json:"permissions" binding:"required,dive,required"`
+}
+
+// PermissionResponse represents a permission roles can grant
+type PermissionResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ListPermissionsResponse represents all permissions
+type ListPermissionsResponse struct {
+	Permissions []PermissionResponse `json:"permissions"`
+}
+
+// AssignRoleRequest represents a request to change a user's role
+type AssignRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// TwoFactorEnrolmentResponse represents the secret to enter into an
+// authenticator app, either by hand or as the QR code of the otpauth URI
+type TwoFactorEnrolmentResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// TwoFactorCodeRequest represents a code from the authenticator app, or a
+// recovery code where the request accepts one
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// RecoveryCodesResponse represents the recovery codes of a user, only shown
+// when they are generated
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorChallengeResponse represents a login that needs a two-factor
+// code, exchanged together with the challenge token for the tokens
+type TwoFactorChallengeResponse struct {
+	TwoFactorRequired bool      `json:"two_factor_required"`
+	ChallengeToken    string    `json:"challenge_token"`
+	ExpiresAt         time.Time `json:"expires_at"`
+}
+
+// TwoFactorLoginRequest represents the second step of a login with
+// two-factor authentication
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	// Code is a code from the authenticator app or a recovery code
+	Code string `json:"code" binding:"required"`
+	// IPAddress is the client address set by the handler
+	IPAddress string `json:"-"`
+}
@@ -14,33 +14,61 @@ type RegisterRequest struct {
 type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required"`
+	// IPAddress is the client address set by the handler, failed logins
+	// are counted per account and per IP
+	IPAddress string `json:"-"`
 }
 
 // LoginResponse represents login response with JWT token
 type LoginResponse struct {
-	Token     string       `json:"token"`
-	User      UserResponse `json:"user"`
-	ExpiresAt time.Time    `json:"expires_at"`
+	Token                 string       `json:"token"`
+	User                  UserResponse `json:"user"`
+	ExpiresAt             time.Time    `json:"expires_at"`
+	RefreshToken          string       `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time    `json:"refresh_token_expires_at"`
+}
+
+// RefreshTokenRequest represents a request carrying a refresh token,
+// used to refresh the access token and to log out
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
 }
 
 // UserResponse represents user data in API responses
 type UserResponse struct {
-	ID        string    `json:"id"`
-	Email     string    `json:"email"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	Role      string    `json:"role"`
-	IsActive  bool      `json:"is_active"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            string    `json:"id"`
+	Email         string    `json:"email"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	Role          string    `json:"role"`
+	IsActive      bool      `json:"is_active"`
+	EmailVerified bool      `json:"email_verified"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// VerifyEmailRequest represents verifying an email with the emailed token
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ResendVerificationRequest represents a request for a new email verification token
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
 }
 
 // UpdateUserRequest represents user update request
 type UpdateUserRequest struct {
-	FirstName *string `json:"first_name,omitempty"`
-	LastName  *string `json:"last_name,omitempty"`
+	FirstName *string `json:"first_name,omitempty" binding:"omitempty,min=1,max=100"`
+	LastName  *string `json:"last_name,omitempty" binding:"omitempty,min=1,max=100"`
 	Email     *string `json:"email,omitempty" binding:"omitempty,email"`
 }
 
+// ListUsersQuery represents pagination of the user list
+type ListUsersQuery struct {
+	Limit  int `form:"limit,default=20" binding:"min=1,max=100"`
+	Offset int `form:"offset,default=0" binding:"min=0"`
+}
+
 // ListUsersResponse represents paginated user list
 type ListUsersResponse struct {
 	Users  []UserResponse `json:"users"`
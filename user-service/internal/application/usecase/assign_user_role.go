@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+)
+
+// AssignUserRoleUseCase handles changing a user's role
+type AssignUserRoleUseCase struct {
+	userRepo repository.UserRepository
+}
+
+// NewAssignUserRoleUseCase creates a new AssignUserRoleUseCase
+func NewAssignUserRoleUseCase(userRepo repository.UserRepository) *AssignUserRoleUseCase {
+	return &AssignUserRoleUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute assigns an existing role to the user. The user gets the role's
+// permissions with the next access token, at the latest when refreshing it.
+func (uc *AssignUserRoleUseCase) Execute(ctx context.Context, userID string, req dto.AssignRoleRequest) (*dto.UserResponse, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.userRepo.UpdateRole(ctx, userID, req.Role); err != nil {
+		return nil, err
+	}
+
+	user.Role = req.Role
+	response := toUserResponse(user)
+	return &response, nil
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+)
+
+// ConfirmTwoFactorUseCase handles enabling two-factor authentication
+type ConfirmTwoFactorUseCase struct {
+	twoFactorRepo repository.TwoFactorRepository
+}
+
+// NewConfirmTwoFactorUseCase creates a new ConfirmTwoFactorUseCase
+func NewConfirmTwoFactorUseCase(twoFactorRepo repository.TwoFactorRepository) *ConfirmTwoFactorUseCase {
+	return &ConfirmTwoFactorUseCase{
+		twoFactorRepo: twoFactorRepo,
+	}
+}
+
+// Execute enables two-factor authentication once the user proved the
+// authenticator app works with a code, and returns the recovery codes
+func (uc *ConfirmTwoFactorUseCase) Execute(ctx context.Context, userID string, req dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error) {
+	now := time.Now()
+
+	twoFactor, err := uc.twoFactorRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, entity.ErrTwoFactorNotEnabled) {
+			return nil, entity.ErrTwoFactorNotEnrolled
+		}
+		return nil, err
+	}
+	if twoFactor.IsEnabled() {
+		return nil, entity.ErrTwoFactorAlreadyEnabled
+	}
+
+	// Only a code of the app confirms, recovery codes don't exist yet
+	step, ok := twoFactor.MatchStep(req.Code, now)
+	if !ok {
+		return nil, entity.ErrInvalidTwoFactorCode
+	}
+
+	recoveryCodes, codes, err := entity.NewRecoveryCodes(userID, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.twoFactorRepo.Enable(ctx, userID, step, recoveryCodes, now); err != nil {
+		return nil, err
+	}
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
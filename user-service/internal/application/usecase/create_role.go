@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+)
+
+// CreateRoleUseCase handles creating roles
+type CreateRoleUseCase struct {
+	roleRepo repository.RoleRepository
+}
+
+// NewCreateRoleUseCase creates a new CreateRoleUseCase
+func NewCreateRoleUseCase(roleRepo repository.RoleRepository) *CreateRoleUseCase {
+	return &CreateRoleUseCase{
+		roleRepo: roleRepo,
+	}
+}
+
+// Execute creates a role granting the given permissions
+func (uc *CreateRoleUseCase) Execute(ctx context.Context, req dto.CreateRoleRequest) (*dto.RoleResponse, error) {
+	role := &entity.Role{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: uniquePermissions(req.Permissions),
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := role.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.roleRepo.Create(ctx, role); err != nil {
+		return nil, err
+	}
+
+	response := toRoleResponse(role)
+	return &response, nil
+}
@@ -0,0 +1,35 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+)
+
+// DeleteUserUseCase handles deactivating users
+type DeleteUserUseCase struct {
+	userRepo         repository.UserRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+}
+
+// NewDeleteUserUseCase creates a new DeleteUserUseCase
+func NewDeleteUserUseCase(userRepo repository.UserRepository, refreshTokenRepo repository.RefreshTokenRepository) *DeleteUserUseCase {
+	return &DeleteUserUseCase{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+	}
+}
+
+// Execute soft deletes the user and ends all of its sessions
+func (uc *DeleteUserUseCase) Execute(ctx context.Context, userID string) error {
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := uc.userRepo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	return uc.refreshTokenRepo.RevokeAllForUser(ctx, userID, time.Now())
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+)
+
+// DisableTwoFactorUseCase handles turning two-factor authentication off
+type DisableTwoFactorUseCase struct {
+	twoFactorRepo repository.TwoFactorRepository
+}
+
+// NewDisableTwoFactorUseCase creates a new DisableTwoFactorUseCase
+func NewDisableTwoFactorUseCase(twoFactorRepo repository.TwoFactorRepository) *DisableTwoFactorUseCase {
+	return &DisableTwoFactorUseCase{
+		twoFactorRepo: twoFactorRepo,
+	}
+}
+
+// Execute disables two-factor authentication, a stolen access token alone
+// can't do it so it takes a code or a recovery code
+func (uc *DisableTwoFactorUseCase) Execute(ctx context.Context, userID string, req dto.TwoFactorCodeRequest) error {
+	twoFactor, err := uc.twoFactorRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !twoFactor.IsEnabled() {
+		return entity.ErrTwoFactorNotEnabled
+	}
+
+	if err := verifyTwoFactorCode(ctx, uc.twoFactorRepo, twoFactor, req.Code, time.Now()); err != nil {
+		return err
+	}
+
+	return uc.twoFactorRepo.Disable(ctx, userID)
+}
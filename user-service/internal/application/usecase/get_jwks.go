@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/service"
+)
+
+// GetJWKSUseCase publishes the public keys access tokens are verified with
+type GetJWKSUseCase struct {
+	tokenService service.TokenService
+}
+
+// NewGetJWKSUseCase creates a new GetJWKSUseCase
+func NewGetJWKSUseCase(tokenService service.TokenService) *GetJWKSUseCase {
+	return &GetJWKSUseCase{
+		tokenService: tokenService,
+	}
+}
+
+// Execute returns the public keys as a JSON Web Key Set
+func (uc *GetJWKSUseCase) Execute() *dto.JWKSResponse {
+	publicKeys := uc.tokenService.PublicKeys()
+
+	response := &dto.JWKSResponse{
+		Keys: make([]dto.JWK, 0, len(publicKeys)),
+	}
+	for _, publicKey := range publicKeys {
+		jwk := dto.JWK{
+			KeyID:     publicKey.ID,
+			Use:       "sig",
+			Algorithm: publicKey.Algorithm,
+		}
+
+		switch key := publicKey.Key.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(key)
+		default:
+			continue
+		}
+
+		response.Keys = append(response.Keys, jwk)
+	}
+
+	return response
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/auth"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/infrastructure/security"
+)
+
+func TestPublishedKeysVerifyIssuedTokens(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() error = %v", err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+	edSigningKey, _ := security.NewSigningKey("ed", edKey)
+	rsaSigningKey, _ := security.NewSigningKey("rsa", rsaKey)
+	keys := []*security.SigningKey{edSigningKey, rsaSigningKey}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens, _ := security.NewAsymmetricJWTTokenService(keys, "ed", time.Hour)
+		json.NewEncoder(w).Encode(NewGetJWKSUseCase(tokens).Execute())
+	}))
+	defer server.Close()
+	verifier := auth.NewJWKSVerifier(server.URL, security.Issuer)
+
+	user := &entity.User{ID: "u-1", Email: "ada@example.com", Role: entity.RoleCustomer}
+	for _, activeKeyID := range []string{"ed", "rsa"} {
+		tokens, err := security.NewAsymmetricJWTTokenService(keys, activeKeyID, time.Hour)
+		if err != nil {
+			t.Fatalf("NewAsymmetricJWTTokenService() error = %v", err)
+		}
+		token, err := tokens.Issue(user, []string{auth.PermissionOrdersCreate})
+		if err != nil {
+			t.Fatalf("Issue() error = %v", err)
+		}
+
+		principal, err := verifier.Verify(token.Value)
+		if err != nil {
+			t.Fatalf("Verify() of a token signed with %s error = %v", activeKeyID, err)
+		}
+		if principal.UserID != "u-1" || !principal.HasPermission(auth.PermissionOrdersCreate) {
+			t.Errorf("principal = %+v", principal)
+		}
+	}
+}
+
+func TestGetJWKSWithSharedSecret(t *testing.T) {
+	tokens, err := security.NewJWTTokenService("secret", time.Hour)
+	if err != nil {
+		t.Fatalf("NewJWTTokenService() error = %v", err)
+	}
+
+	// The secret must never be published
+	if keys := NewGetJWKSUseCase(tokens).Execute().Keys; len(keys) != 0 {
+		t.Errorf("Execute() = %+v, want no keys", keys)
+	}
+}
@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+)
+
+// GetUserUseCase handles fetching a user's profile
+type GetUserUseCase struct {
+	userRepo repository.UserRepository
+}
+
+// NewGetUserUseCase creates a new GetUserUseCase
+func NewGetUserUseCase(userRepo repository.UserRepository) *GetUserUseCase {
+	return &GetUserUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute returns the active user with the given ID
+func (uc *GetUserUseCase) Execute(ctx context.Context, userID string) (*dto.UserResponse, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toUserResponse(user)
+	return &response, nil
+}
+
+// toUserResponse converts a user to DTO (hide sensitive data)
+func toUserResponse(user *entity.User) dto.UserResponse {
+	return dto.UserResponse{
+		ID:            user.ID,
+		Email:         user.Email,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Role:          user.Role,
+		IsActive:      user.IsActive,
+		EmailVerified: user.IsEmailVerified(),
+		CreatedAt:     user.CreatedAt,
+	}
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+)
+
+// ListPermissionsUseCase handles listing the permissions roles can grant
+type ListPermissionsUseCase struct {
+	roleRepo repository.RoleRepository
+}
+
+// NewListPermissionsUseCase creates a new ListPermissionsUseCase
+func NewListPermissionsUseCase(roleRepo repository.RoleRepository) *ListPermissionsUseCase {
+	return &ListPermissionsUseCase{
+		roleRepo: roleRepo,
+	}
+}
+
+// Execute returns all permissions in name order
+func (uc *ListPermissionsUseCase) Execute(ctx context.Context) (*dto.ListPermissionsResponse, error) {
+	permissions, err := uc.roleRepo.ListPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ListPermissionsResponse{
+		Permissions: make([]dto.PermissionResponse, len(permissions)),
+	}
+	for i, permission := range permissions {
+		response.Permissions[i] = dto.PermissionResponse{
+			Name:        permission.Name,
+			Description: permission.Description,
+		}
+	}
+
+	return response, nil
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+)
+
+// ListRolesUseCase handles listing roles with their permissions
+type ListRolesUseCase struct {
+	roleRepo repository.RoleRepository
+}
+
+// NewListRolesUseCase creates a new ListRolesUseCase
+func NewListRolesUseCase(roleRepo repository.RoleRepository) *ListRolesUseCase {
+	return &ListRolesUseCase{
+		roleRepo: roleRepo,
+	}
+}
+
+// Execute returns all roles in name order
+func (uc *ListRolesUseCase) Execute(ctx context.Context) (*dto.ListRolesResponse, error) {
+	roles, err := uc.roleRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ListRolesResponse{
+		Roles: make([]dto.RoleResponse, len(roles)),
+	}
+	for i, role := range roles {
+		response.Roles[i] = toRoleResponse(role)
+	}
+
+	return response, nil
+}
+
+// toRoleResponse converts a role to DTO
+func toRoleResponse(role *entity.Role) dto.RoleResponse {
+	permissions := role.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	return dto.RoleResponse{
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: permissions,
+		CreatedAt:   role.CreatedAt,
+	}
+}
+
+// uniquePermissions drops repeated permissions, keeping the first occurrence
+func uniquePermissions(permissions []string) []string {
+	seen := make(map[string]bool, len(permissions))
+	unique := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		if !seen[permission] {
+			seen[permission] = true
+			unique = append(unique, permission)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,16 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUniquePermissions(t *testing.T) {
+	got := uniquePermissions([]string{"orders:create", "stock:read", "orders:create"})
+	if want := []string{"orders:create", "stock:read"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("uniquePermissions() = %v, want %v", got, want)
+	}
+	if got := uniquePermissions(nil); got == nil || len(got) != 0 {
+		t.Errorf("uniquePermissions(nil) = %#v, want an empty slice", got)
+	}
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+)
+
+// ListUsersUseCase handles listing users for admins
+type ListUsersUseCase struct {
+	userRepo repository.UserRepository
+}
+
+// NewListUsersUseCase creates a new ListUsersUseCase
+func NewListUsersUseCase(userRepo repository.UserRepository) *ListUsersUseCase {
+	return &ListUsersUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute returns a page of active users, newest first, with the total count
+func (uc *ListUsersUseCase) Execute(ctx context.Context, query dto.ListUsersQuery) (*dto.ListUsersResponse, error) {
+	users, err := uc.userRepo.List(ctx, query.Limit, query.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := uc.userRepo.Count(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &dto.ListUsersResponse{
+		Users:  make([]dto.UserResponse, len(users)),
+		Total:  int(total),
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	}
+	for i, user := range users {
+		response.Users[i] = toUserResponse(user)
+	}
+
+	return response, nil
+}
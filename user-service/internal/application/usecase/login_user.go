@@ -3,51 +3,319 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/events"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/messaging"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/service"
 )
 
+// LoginThrottles are the limits of failed logins per account and per client IP
+type LoginThrottles struct {
+	Account entity.LoginThrottle
+	IP      entity.LoginThrottle
+}
+
 // LoginUserUseCase handles user authentication
 type LoginUserUseCase struct {
-	userRepo repository.UserRepository
+	userRepo         repository.UserRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	roleRepo         repository.RoleRepository
+	loginFailureRepo repository.LoginFailureRepository
+	twoFactorRepo    repository.TwoFactorRepository
+	challengeRepo    repository.LoginChallengeRepository
+	tokenService     service.TokenService
+	eventPublisher   *messaging.EventPublisher
+	refreshTokenTTL  time.Duration
+	throttles        LoginThrottles
 }
 
 // NewLoginUserUseCase creates a new LoginUserUseCase
-func NewLoginUserUseCase(userRepo repository.UserRepository) *LoginUserUseCase {
+func NewLoginUserUseCase(
+	userRepo repository.UserRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	roleRepo repository.RoleRepository,
+	loginFailureRepo repository.LoginFailureRepository,
+	twoFactorRepo repository.TwoFactorRepository,
+	challengeRepo repository.LoginChallengeRepository,
+	tokenService service.TokenService,
+	eventPublisher *messaging.EventPublisher,
+	refreshTokenTTL time.Duration,
+	throttles LoginThrottles,
+) *LoginUserUseCase {
 	return &LoginUserUseCase{
-		userRepo: userRepo,
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		roleRepo:         roleRepo,
+		loginFailureRepo: loginFailureRepo,
+		twoFactorRepo:    twoFactorRepo,
+		challengeRepo:    challengeRepo,
+		tokenService:     tokenService,
+		eventPublisher:   eventPublisher,
+		refreshTokenTTL:  refreshTokenTTL,
+		throttles:        throttles,
 	}
 }
 
-// Execute authenticates a user and returns user data
-func (uc *LoginUserUseCase) Execute(ctx context.Context, req dto.LoginRequest) (*dto.UserResponse, error) {
-	// Get user by email
+// Execute authenticates a user and returns an access token with the user
+// data. Users with two-factor authentication get a challenge instead, the
+// tokens are only issued by CompleteTwoFactor.
+func (uc *LoginUserUseCase) Execute(ctx context.Context, req dto.LoginRequest) (*dto.LoginResponse, *dto.TwoFactorChallengeResponse, error) {
+	now := time.Now()
+	account := entity.NormalizeLoginEmail(req.Email)
+
+	// Refuse guesses while the account or the client has to wait
+	if err := uc.checkThrottles(ctx, account, req.IPAddress, now); err != nil {
+		return nil, nil, err
+	}
+
+	// Get user by email, unknown emails take as long as wrong passwords
 	user, err := uc.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
-		return nil, errors.New("invalid email or password")
+		entity.CompareDummyPassword(req.Password)
+		uc.recordFailure(ctx, account, req.IPAddress, nil, now)
+		return nil, nil, errors.New("invalid email or password")
+	}
+
+	// Verify password (domain logic)
+	if !user.CheckPassword(req.Password) {
+		uc.recordFailure(ctx, account, req.IPAddress, user, now)
+		return nil, nil, errors.New("invalid email or password")
 	}
 
 	// Check if user is active
+	if !user.IsActive {
+		return nil, nil, errors.New("user account is disabled")
+	}
+
+	// With two-factor authentication the password alone doesn't log in. The
+	// failures are kept, so wrong codes keep counting towards the lockout.
+	twoFactor, err := uc.twoFactorRepo.GetByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, entity.ErrTwoFactorNotEnabled) {
+		return nil, nil, err
+	}
+	if twoFactor != nil && twoFactor.IsEnabled() {
+		challenge, rawChallenge, err := entity.NewLoginChallenge(user.ID, now)
+		if err != nil {
+			return nil, nil, errors.New("failed to issue login challenge")
+		}
+		if err := uc.challengeRepo.Create(ctx, challenge); err != nil {
+			return nil, nil, err
+		}
+
+		return nil, &dto.TwoFactorChallengeResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    rawChallenge,
+			ExpiresAt:         challenge.ExpiresAt,
+		}, nil
+	}
+
+	// The right password forgets the account's failures
+	if err := uc.loginFailureRepo.Reset(ctx, entity.LoginScopeAccount, account); err != nil {
+		return nil, nil, err
+	}
+
+	response, err := uc.startSession(ctx, user, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response, nil, nil
+}
+
+// CompleteTwoFactor exchanges a login challenge and a code from the
+// authenticator app or a recovery code for the tokens. Wrong codes count as
+// failed logins of the account.
+func (uc *LoginUserUseCase) CompleteTwoFactor(ctx context.Context, req dto.TwoFactorLoginRequest) (*dto.LoginResponse, error) {
+	now := time.Now()
+
+	challenge, err := uc.challengeRepo.GetByHash(ctx, entity.HashLoginChallenge(req.ChallengeToken))
+	if err != nil {
+		return nil, err
+	}
+	if !challenge.IsUsable(now) {
+		return nil, entity.ErrInvalidLoginChallenge
+	}
+
+	// The user may have been disabled since the password was entered
+	user, err := uc.userRepo.GetByID(ctx, challenge.UserID)
+	if err != nil {
+		if errors.Is(err, entity.ErrUserNotFound) {
+			return nil, entity.ErrInvalidLoginChallenge
+		}
+		return nil, err
+	}
 	if !user.IsActive {
 		return nil, errors.New("user account is disabled")
 	}
 
-	// Verify password (domain logic)
-	if !user.CheckPassword(req.Password) {
-		return nil, errors.New("invalid email or password")
+	account := entity.NormalizeLoginEmail(user.Email)
+	if err := uc.checkThrottles(ctx, account, req.IPAddress, now); err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := uc.twoFactorRepo.GetByUserID(ctx, user.ID)
+	if err != nil {
+		if errors.Is(err, entity.ErrTwoFactorNotEnabled) {
+			return nil, entity.ErrInvalidLoginChallenge
+		}
+		return nil, err
+	}
+	if !twoFactor.IsEnabled() {
+		return nil, entity.ErrInvalidLoginChallenge
+	}
+
+	if err := verifyTwoFactorCode(ctx, uc.twoFactorRepo, twoFactor, req.Code, now); err != nil {
+		if errors.Is(err, entity.ErrInvalidTwoFactorCode) {
+			if err := uc.challengeRepo.RecordFailure(ctx, challenge.ID); err != nil {
+				fmt.Printf("ERROR: Failed to record login challenge failure: %v\n", err)
+			}
+			uc.recordFailure(ctx, account, req.IPAddress, user, now)
+		}
+		return nil, err
+	}
+
+	// A challenge logs in once
+	if err := uc.challengeRepo.MarkUsed(ctx, challenge.ID, now); err != nil {
+		return nil, err
+	}
+
+	// The right code forgets the account's failures
+	if err := uc.loginFailureRepo.Reset(ctx, entity.LoginScopeAccount, account); err != nil {
+		return nil, err
 	}
 
-	// Convert to DTO (hide sensitive data)
-	response := &dto.UserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Role:      user.Role,
-		IsActive:  user.IsActive,
-		CreatedAt: user.CreatedAt,
+	return uc.startSession(ctx, user, now)
+}
+
+// startSession issues the access token and starts a new refresh token
+// family for a successful login
+func (uc *LoginUserUseCase) startSession(ctx context.Context, user *entity.User, now time.Time) (*dto.LoginResponse, error) {
+	// Issue access token
+	permissions, err := rolePermissions(ctx, uc.roleRepo, user.Role)
+	if err != nil {
+		return nil, err
 	}
+	token, err := uc.tokenService.Issue(user, permissions)
+	if err != nil {
+		return nil, errors.New("failed to issue token")
+	}
+
+	// Start a new refresh token family for this login
+	refreshToken, rawRefreshToken, err := entity.NewRefreshToken(user.ID, uuid.New().String(), uc.refreshTokenTTL, now)
+	if err != nil {
+		return nil, errors.New("failed to issue refresh token")
+	}
+	if err := uc.refreshTokenRepo.Create(ctx, refreshToken); err != nil {
+		return nil, errors.New("failed to issue refresh token")
+	}
+
+	return toLoginResponse(user, token, refreshToken, rawRefreshToken), nil
+}
+
+// checkThrottles refuses logins while the account or the client IP has to wait
+func (uc *LoginUserUseCase) checkThrottles(ctx context.Context, account, ipAddress string, now time.Time) error {
+	if err := uc.checkThrottle(ctx, entity.LoginScopeAccount, account, uc.throttles.Account, now); err != nil {
+		return err
+	}
+	if ipAddress != "" {
+		return uc.checkThrottle(ctx, entity.LoginScopeIP, ipAddress, uc.throttles.IP, now)
+	}
+	return nil
+}
+
+// checkThrottle returns an *entity.LoginThrottledError while logins of the
+// account or IP have to wait after failing
+func (uc *LoginUserUseCase) checkThrottle(ctx context.Context, scope, subject string, throttle entity.LoginThrottle, now time.Time) error {
+	failure, err := uc.loginFailureRepo.Get(ctx, scope, subject)
+	if err != nil {
+		return err
+	}
+
+	if retryAfter := throttle.RetryAfter(failure, now); retryAfter > 0 {
+		return &entity.LoginThrottledError{RetryAfter: retryAfter}
+	}
+	return nil
+}
 
-	return response, nil
+// recordFailure counts a failed login for the account and the client IP and
+// locks them once the failures reach the limit. Locking a registered account
+// publishes user.locked. Failures to record are logged, the login fails anyway.
+func (uc *LoginUserUseCase) recordFailure(ctx context.Context, account, ipAddress string, user *entity.User, now time.Time) {
+	failure, locked := uc.recordScopeFailure(ctx, entity.LoginScopeAccount, account, uc.throttles.Account, now)
+	if locked && user != nil {
+		lockedEvent := events.UserLockedEvent{
+			BaseEvent:      events.NewBaseEvent(events.UserLockedEventType, user.ID, uuid.New().String()),
+			UserID:         user.ID,
+			Email:          user.Email,
+			FirstName:      user.FirstName,
+			FailedAttempts: failure.FailedAttempts,
+			LockedUntil:    *failure.LockedUntil,
+		}
+		if err := uc.eventPublisher.Publish(events.UserLockedEventType, lockedEvent); err != nil {
+			fmt.Printf("ERROR: Failed to publish %s event: %v\n", events.UserLockedEventType, err)
+		}
+	}
+
+	if ipAddress != "" {
+		uc.recordScopeFailure(ctx, entity.LoginScopeIP, ipAddress, uc.throttles.IP, now)
+	}
+}
+
+// recordScopeFailure counts a failed login of an account or IP and reports
+// whether it got locked by it
+func (uc *LoginUserUseCase) recordScopeFailure(ctx context.Context, scope, subject string, throttle entity.LoginThrottle, now time.Time) (*entity.LoginFailure, bool) {
+	failure, err := uc.loginFailureRepo.RecordFailure(ctx, scope, subject, now, throttle.ResetAfter)
+	if err != nil {
+		fmt.Printf("ERROR: Failed to record login failure: %v\n", err)
+		return nil, false
+	}
+	if !throttle.ShouldLock(failure) {
+		return failure, false
+	}
+
+	lockedUntil := now.Add(throttle.LockoutDuration).UTC()
+	if err := uc.loginFailureRepo.Lock(ctx, scope, subject, lockedUntil); err != nil {
+		fmt.Printf("ERROR: Failed to lock logins: %v\n", err)
+		return failure, false
+	}
+	failure.LockedUntil = &lockedUntil
+	return failure, true
+}
+
+// verifyTwoFactorCode accepts a code from the authenticator app or a recovery
+// code once, entity.ErrInvalidTwoFactorCode when it is neither
+func verifyTwoFactorCode(ctx context.Context, twoFactorRepo repository.TwoFactorRepository, twoFactor *entity.TwoFactor, code string, now time.Time) error {
+	if step, ok := twoFactor.MatchStep(code, now); ok {
+		return twoFactorRepo.UseStep(ctx, twoFactor.UserID, step)
+	}
+	return twoFactorRepo.UseRecoveryCode(ctx, twoFactor.UserID, entity.HashRecoveryCode(code), now)
+}
+
+// rolePermissions returns the permissions embedded in the user's tokens. A
+// role removed since it was assigned grants nothing.
+func rolePermissions(ctx context.Context, roleRepo repository.RoleRepository, roleName string) ([]string, error) {
+	role, err := roleRepo.GetByName(ctx, roleName)
+	if err != nil {
+		if errors.Is(err, entity.ErrRoleNotFound) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+	return role.Permissions, nil
+}
+
+// toLoginResponse converts the user and its tokens to DTO (hide sensitive data)
+func toLoginResponse(user *entity.User, token *service.Token, refreshToken *entity.RefreshToken, rawRefreshToken string) *dto.LoginResponse {
+	return &dto.LoginResponse{
+		Token:                 token.Value,
+		User:                  toUserResponse(user),
+		ExpiresAt:             token.ExpiresAt,
+		RefreshToken:          rawRefreshToken,
+		RefreshTokenExpiresAt: refreshToken.ExpiresAt,
+	}
 }
@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+)
+
+// LogoutAllSessionsUseCase ends every session of a user
+type LogoutAllSessionsUseCase struct {
+	refreshTokenRepo repository.RefreshTokenRepository
+}
+
+// NewLogoutAllSessionsUseCase creates a new LogoutAllSessionsUseCase
+func NewLogoutAllSessionsUseCase(refreshTokenRepo repository.RefreshTokenRepository) *LogoutAllSessionsUseCase {
+	return &LogoutAllSessionsUseCase{
+		refreshTokenRepo: refreshTokenRepo,
+	}
+}
+
+// Execute revokes every refresh token of the user owning the given token,
+// which has to be usable so an old token can not end current sessions
+func (uc *LogoutAllSessionsUseCase) Execute(ctx context.Context, req dto.RefreshTokenRequest) error {
+	now := time.Now()
+
+	refreshToken, err := uc.refreshTokenRepo.GetByHash(ctx, entity.HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		return err
+	}
+	if !refreshToken.IsUsable(now) {
+		return entity.ErrInvalidRefreshToken
+	}
+
+	return uc.refreshTokenRepo.RevokeAllForUser(ctx, refreshToken.UserID, now)
+}
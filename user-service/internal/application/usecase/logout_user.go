@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+)
+
+// LogoutUserUseCase ends the session a refresh token belongs to
+type LogoutUserUseCase struct {
+	refreshTokenRepo repository.RefreshTokenRepository
+}
+
+// NewLogoutUserUseCase creates a new LogoutUserUseCase
+func NewLogoutUserUseCase(refreshTokenRepo repository.RefreshTokenRepository) *LogoutUserUseCase {
+	return &LogoutUserUseCase{
+		refreshTokenRepo: refreshTokenRepo,
+	}
+}
+
+// Execute revokes every refresh token of the login the token belongs to.
+// Logging out of a session that already ended succeeds.
+func (uc *LogoutUserUseCase) Execute(ctx context.Context, req dto.RefreshTokenRequest) error {
+	refreshToken, err := uc.refreshTokenRepo.GetByHash(ctx, entity.HashRefreshToken(req.RefreshToken))
+	if err != nil {
+		return err
+	}
+
+	return uc.refreshTokenRepo.RevokeFamily(ctx, refreshToken.FamilyID, time.Now())
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/service"
+)
+
+// RefreshTokenUseCase exchanges a refresh token for a new access token
+type RefreshTokenUseCase struct {
+	userRepo         repository.UserRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	roleRepo         repository.RoleRepository
+	tokenService     service.TokenService
+	refreshTokenTTL  time.Duration
+}
+
+// NewRefreshTokenUseCase creates a new RefreshTokenUseCase
+func NewRefreshTokenUseCase(
+	userRepo repository.UserRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	roleRepo repository.RoleRepository,
+	tokenService service.TokenService,
+	refreshTokenTTL time.Duration,
+) *RefreshTokenUseCase {
+	return &RefreshTokenUseCase{
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		roleRepo:         roleRepo,
+		tokenService:     tokenService,
+		refreshTokenTTL:  refreshTokenTTL,
+	}
+}
+
+// Execute rotates the refresh token and issues a new access token. The
+// presented refresh token can not be used again.
+func (uc *RefreshTokenUseCase) Execute(ctx context.Context, req dto.RefreshTokenRequest) (*dto.LoginResponse, error) {
+	// The family and user are taken over from the rotated token
+	next, rawRefreshToken, err := entity.NewRefreshToken("", "", uc.refreshTokenTTL, time.Now())
+	if err != nil {
+		return nil, errors.New("failed to issue refresh token")
+	}
+	if err := uc.refreshTokenRepo.Rotate(ctx, entity.HashRefreshToken(req.RefreshToken), next); err != nil {
+		return nil, err
+	}
+
+	// Users disabled since logging in lose their sessions
+	user, err := uc.userRepo.GetByID(ctx, next.UserID)
+	if err != nil {
+		if err := uc.refreshTokenRepo.RevokeFamily(ctx, next.FamilyID, time.Now()); err != nil {
+			return nil, err
+		}
+		return nil, entity.ErrInvalidRefreshToken
+	}
+
+	permissions, err := rolePermissions(ctx, uc.roleRepo, user.Role)
+	if err != nil {
+		return nil, err
+	}
+	token, err := uc.tokenService.Issue(user, permissions)
+	if err != nil {
+		return nil, errors.New("failed to issue token")
+	}
+
+	return toLoginResponse(user, token, next, rawRefreshToken), nil
+}
@@ -3,9 +3,12 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/events"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/messaging"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
@@ -13,22 +16,33 @@ import (
 
 // RegisterUserUseCase handles user registration business logic
 type RegisterUserUseCase struct {
-	userRepo repository.UserRepository
+	userRepo              repository.UserRepository
+	verificationTokenRepo repository.EmailVerificationTokenRepository
+	eventPublisher        *messaging.EventPublisher
+	verificationTokenTTL  time.Duration
 }
 
 // NewRegisterUserUseCase creates a new RegisterUserUseCase
-func NewRegisterUserUseCase(userRepo repository.UserRepository) *RegisterUserUseCase {
+func NewRegisterUserUseCase(
+	userRepo repository.UserRepository,
+	verificationTokenRepo repository.EmailVerificationTokenRepository,
+	eventPublisher *messaging.EventPublisher,
+	verificationTokenTTL time.Duration,
+) *RegisterUserUseCase {
 	return &RegisterUserUseCase{
-		userRepo: userRepo,
+		userRepo:              userRepo,
+		verificationTokenRepo: verificationTokenRepo,
+		eventPublisher:        eventPublisher,
+		verificationTokenTTL:  verificationTokenTTL,
 	}
 }
 
-// Execute registers a new user
+// Execute registers a new user with an unverified email
 func (uc *RegisterUserUseCase) Execute(ctx context.Context, req dto.RegisterRequest) (*dto.UserResponse, error) {
 	// Check if user already exists
 	existingUser, _ := uc.userRepo.GetByEmail(ctx, req.Email)
 	if existingUser != nil {
-		return nil, errors.New("user with this email already exists")
+		return nil, entity.ErrEmailAlreadyExists
 	}
 
 	// Create user entity
@@ -38,7 +52,7 @@ func (uc *RegisterUserUseCase) Execute(ctx context.Context, req dto.RegisterRequ
 		Password:  req.Password,
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
-		Role:      "customer", // Default role
+		Role:      entity.RoleCustomer, // Default role
 		IsActive:  true,
 		CreatedAt: time.Now().UTC(),
 		UpdatedAt: time.Now().UTC(),
@@ -54,16 +68,35 @@ func (uc *RegisterUserUseCase) Execute(ctx context.Context, req dto.RegisterRequ
 		return nil, err
 	}
 
+	// The user is registered either way, without the email the user can
+	// request a new verification token
+	if err := uc.publishRegistered(ctx, user); err != nil {
+		fmt.Printf("ERROR: Failed to send email verification to user %s: %v\n", user.ID, err)
+	}
+
 	// Convert to DTO response (don't expose password!)
-	response := &dto.UserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Role:      user.Role,
-		IsActive:  user.IsActive,
-		CreatedAt: user.CreatedAt,
+	response := toUserResponse(user)
+	return &response, nil
+}
+
+// publishRegistered publishes user.registered with a new email verification token
+func (uc *RegisterUserUseCase) publishRegistered(ctx context.Context, user *entity.User) error {
+	verificationToken, rawVerificationToken, err := entity.NewEmailVerificationToken(user.ID, user.Email, uc.verificationTokenTTL, time.Now())
+	if err != nil {
+		return err
+	}
+	if err := uc.verificationTokenRepo.Create(ctx, verificationToken); err != nil {
+		return err
 	}
 
-	return response, nil
+	registeredEvent := events.UserRegisteredEvent{
+		BaseEvent:                  events.NewBaseEvent(events.UserRegisteredEventType, user.ID, uuid.New().String()),
+		UserID:                     user.ID,
+		Email:                      user.Email,
+		FirstName:                  user.FirstName,
+		LastName:                   user.LastName,
+		VerificationToken:          rawVerificationToken,
+		VerificationTokenExpiresAt: verificationToken.ExpiresAt,
+	}
+	return uc.eventPublisher.Publish(events.UserRegisteredEventType, registeredEvent)
 }
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/events"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/messaging"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+)
+
+// RequestPasswordResetUseCase handles users asking to reset a forgotten password
+type RequestPasswordResetUseCase struct {
+	userRepo       repository.UserRepository
+	resetTokenRepo repository.PasswordResetTokenRepository
+	eventPublisher *messaging.EventPublisher
+	resetTokenTTL  time.Duration
+}
+
+// NewRequestPasswordResetUseCase creates a new RequestPasswordResetUseCase
+func NewRequestPasswordResetUseCase(
+	userRepo repository.UserRepository,
+	resetTokenRepo repository.PasswordResetTokenRepository,
+	eventPublisher *messaging.EventPublisher,
+	resetTokenTTL time.Duration,
+) *RequestPasswordResetUseCase {
+	return &RequestPasswordResetUseCase{
+		userRepo:       userRepo,
+		resetTokenRepo: resetTokenRepo,
+		eventPublisher: eventPublisher,
+		resetTokenTTL:  resetTokenTTL,
+	}
+}
+
+// Execute creates a reset token and publishes it for notification-service
+// to email. Unknown and disabled accounts succeed without doing anything, so
+// the response does not tell which emails are registered.
+func (uc *RequestPasswordResetUseCase) Execute(ctx context.Context, req dto.ForgotPasswordRequest) error {
+	user, err := uc.userRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, entity.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !user.IsActive {
+		return nil
+	}
+
+	resetToken, rawResetToken, err := entity.NewPasswordResetToken(user.ID, uc.resetTokenTTL, time.Now())
+	if err != nil {
+		return errors.New("failed to create password reset token")
+	}
+	if err := uc.resetTokenRepo.Create(ctx, resetToken); err != nil {
+		return err
+	}
+
+	resetRequestedEvent := events.UserPasswordResetRequestedEvent{
+		BaseEvent: events.NewBaseEvent(events.UserPasswordResetRequestedEventType, user.ID, uuid.New().String()),
+		UserID:    user.ID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		Token:     rawResetToken,
+		ExpiresAt: resetToken.ExpiresAt,
+	}
+	if err := uc.eventPublisher.Publish(events.UserPasswordResetRequestedEventType, resetRequestedEvent); err != nil {
+		return fmt.Errorf("failed to publish password reset event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/events"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/messaging"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+)
+
+// ResendEmailVerificationUseCase handles users asking for a new email verification token
+type ResendEmailVerificationUseCase struct {
+	userRepo              repository.UserRepository
+	verificationTokenRepo repository.EmailVerificationTokenRepository
+	eventPublisher        *messaging.EventPublisher
+	verificationTokenTTL  time.Duration
+}
+
+// NewResendEmailVerificationUseCase creates a new ResendEmailVerificationUseCase
+func NewResendEmailVerificationUseCase(
+	userRepo repository.UserRepository,
+	verificationTokenRepo repository.EmailVerificationTokenRepository,
+	eventPublisher *messaging.EventPublisher,
+	verificationTokenTTL time.Duration,
+) *ResendEmailVerificationUseCase {
+	return &ResendEmailVerificationUseCase{
+		userRepo:              userRepo,
+		verificationTokenRepo: verificationTokenRepo,
+		eventPublisher:        eventPublisher,
+		verificationTokenTTL:  verificationTokenTTL,
+	}
+}
+
+// Execute replaces the user's verification token and publishes the new one
+// for notification-service to email. Unknown, disabled and already verified
+// accounts succeed without doing anything, so the response does not tell
+// which emails are registered.
+func (uc *ResendEmailVerificationUseCase) Execute(ctx context.Context, req dto.ResendVerificationRequest) error {
+	user, err := uc.userRepo.GetByEmail(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, entity.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !user.IsActive || user.IsEmailVerified() {
+		return nil
+	}
+
+	verificationToken, rawVerificationToken, err := entity.NewEmailVerificationToken(user.ID, user.Email, uc.verificationTokenTTL, time.Now())
+	if err != nil {
+		return errors.New("failed to create email verification token")
+	}
+	if err := uc.verificationTokenRepo.Create(ctx, verificationToken); err != nil {
+		return err
+	}
+
+	verificationRequestedEvent := events.UserEmailVerificationRequestedEvent{
+		BaseEvent: events.NewBaseEvent(events.UserEmailVerificationRequestedEventType, user.ID, uuid.New().String()),
+		UserID:    user.ID,
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		Token:     rawVerificationToken,
+		ExpiresAt: verificationToken.ExpiresAt,
+	}
+	if err := uc.eventPublisher.Publish(events.UserEmailVerificationRequestedEventType, verificationRequestedEvent); err != nil {
+		return fmt.Errorf("failed to publish email verification event: %w", err)
+	}
+
+	return nil
+}
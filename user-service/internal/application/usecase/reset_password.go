@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+)
+
+// ResetPasswordUseCase handles setting a new password with a reset token
+type ResetPasswordUseCase struct {
+	userRepo       repository.UserRepository
+	resetTokenRepo repository.PasswordResetTokenRepository
+}
+
+// NewResetPasswordUseCase creates a new ResetPasswordUseCase
+func NewResetPasswordUseCase(
+	userRepo repository.UserRepository,
+	resetTokenRepo repository.PasswordResetTokenRepository,
+) *ResetPasswordUseCase {
+	return &ResetPasswordUseCase{
+		userRepo:       userRepo,
+		resetTokenRepo: resetTokenRepo,
+	}
+}
+
+// Execute replaces the password of the token's user and logs out all of the
+// user's sessions. Access tokens stay valid until they expire.
+func (uc *ResetPasswordUseCase) Execute(ctx context.Context, req dto.ResetPasswordRequest) error {
+	now := time.Now()
+
+	tokenHash := entity.HashPasswordResetToken(req.Token)
+	resetToken, err := uc.resetTokenRepo.GetByHash(ctx, tokenHash)
+	if err != nil {
+		return err
+	}
+	if !resetToken.IsUsable(now) {
+		return entity.ErrInvalidPasswordResetToken
+	}
+
+	// Disabled users can not get back in by resetting the password
+	user, err := uc.userRepo.GetByID(ctx, resetToken.UserID)
+	if err != nil {
+		if errors.Is(err, entity.ErrUserNotFound) {
+			return entity.ErrInvalidPasswordResetToken
+		}
+		return err
+	}
+
+	// Hash password (domain logic)
+	user.Password = req.NewPassword
+	if err := user.HashPassword(); err != nil {
+		return errors.New("failed to hash password")
+	}
+
+	return uc.resetTokenRepo.ResetPassword(ctx, tokenHash, user.Password, now)
+}
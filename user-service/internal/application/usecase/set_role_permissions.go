@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+)
+
+// SetRolePermissionsUseCase handles changing what a role grants
+type SetRolePermissionsUseCase struct {
+	roleRepo repository.RoleRepository
+}
+
+// NewSetRolePermissionsUseCase creates a new SetRolePermissionsUseCase
+func NewSetRolePermissionsUseCase(roleRepo repository.RoleRepository) *SetRolePermissionsUseCase {
+	return &SetRolePermissionsUseCase{
+		roleRepo: roleRepo,
+	}
+}
+
+// Execute replaces the role's permissions. Users get the new permissions
+// with their next access token, at the latest when they refresh it.
+func (uc *SetRolePermissionsUseCase) Execute(ctx context.Context, name string, req dto.SetRolePermissionsRequest) (*dto.RoleResponse, error) {
+	role, err := uc.roleRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if role.IsProtected() {
+		return nil, entity.ErrProtectedRole
+	}
+
+	permissions := uniquePermissions(req.Permissions)
+	if err := uc.roleRepo.SetPermissions(ctx, name, permissions); err != nil {
+		return nil, err
+	}
+
+	role.Permissions = permissions
+	response := toRoleResponse(role)
+	return &response, nil
+}
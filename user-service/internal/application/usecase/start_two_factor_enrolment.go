@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+)
+
+// StartTwoFactorEnrolmentUseCase handles generating a TOTP secret for a user
+type StartTwoFactorEnrolmentUseCase struct {
+	userRepo      repository.UserRepository
+	twoFactorRepo repository.TwoFactorRepository
+	issuer        string
+}
+
+// NewStartTwoFactorEnrolmentUseCase creates a new StartTwoFactorEnrolmentUseCase.
+// The issuer names the service in authenticator apps.
+func NewStartTwoFactorEnrolmentUseCase(
+	userRepo repository.UserRepository,
+	twoFactorRepo repository.TwoFactorRepository,
+	issuer string,
+) *StartTwoFactorEnrolmentUseCase {
+	return &StartTwoFactorEnrolmentUseCase{
+		userRepo:      userRepo,
+		twoFactorRepo: twoFactorRepo,
+		issuer:        issuer,
+	}
+}
+
+// Execute generates a new secret, replacing an enrolment that was not
+// confirmed. Two-factor authentication is enabled once a code is confirmed.
+func (uc *StartTwoFactorEnrolmentUseCase) Execute(ctx context.Context, userID string) (*dto.TwoFactorEnrolmentResponse, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := entity.NewTwoFactor(user.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.twoFactorRepo.StartEnrolment(ctx, twoFactor); err != nil {
+		return nil, err
+	}
+
+	return &dto.TwoFactorEnrolmentResponse{
+		Secret:     twoFactor.Secret,
+		OTPAuthURI: twoFactor.URI(uc.issuer, user.Email),
+	}, nil
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+)
+
+// UpdateUserUseCase handles changes to a user's profile
+type UpdateUserUseCase struct {
+	userRepo repository.UserRepository
+}
+
+// NewUpdateUserUseCase creates a new UpdateUserUseCase
+func NewUpdateUserUseCase(userRepo repository.UserRepository) *UpdateUserUseCase {
+	return &UpdateUserUseCase{
+		userRepo: userRepo,
+	}
+}
+
+// Execute applies the fields set in the request to the user
+func (uc *UpdateUserUseCase) Execute(ctx context.Context, userID string, req dto.UpdateUserRequest) (*dto.UserResponse, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.FirstName != nil {
+		user.FirstName = *req.FirstName
+	}
+	if req.LastName != nil {
+		user.LastName = *req.LastName
+	}
+	if req.Email != nil && *req.Email != user.Email {
+		// Check the email is not used by another user, the unique index
+		// catches a concurrent change to the same email
+		existingUser, err := uc.userRepo.GetByEmail(ctx, *req.Email)
+		if err != nil && !errors.Is(err, entity.ErrUserNotFound) {
+			return nil, err
+		}
+		if existingUser != nil && existingUser.ID != user.ID {
+			return nil, entity.ErrEmailAlreadyExists
+		}
+		user.Email = *req.Email
+		// The new email has to be verified again
+		user.EmailVerifiedAt = nil
+	}
+
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	response := toUserResponse(user)
+	return &response, nil
+}
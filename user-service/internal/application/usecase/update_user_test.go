@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+)
+
+// memoryUserRepository keeps users in memory. It only implements what the
+// tested use cases call, anything else panics.
+type memoryUserRepository struct {
+	repository.UserRepository
+	users map[string]*entity.User
+}
+
+func newMemoryUserRepository(users ...*entity.User) *memoryUserRepository {
+	repo := &memoryUserRepository{users: make(map[string]*entity.User)}
+	for _, user := range users {
+		repo.users[user.ID] = user
+	}
+	return repo
+}
+
+func (r *memoryUserRepository) GetByID(ctx context.Context, id string) (*entity.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, entity.ErrUserNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (r *memoryUserRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			copied := *user
+			return &copied, nil
+		}
+	}
+	return nil, entity.ErrUserNotFound
+}
+
+func (r *memoryUserRepository) Update(ctx context.Context, user *entity.User) error {
+	copied := *user
+	r.users[user.ID] = &copied
+	return nil
+}
+
+func TestUpdateUser(t *testing.T) {
+	verifiedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	newUser := func() *entity.User {
+		return &entity.User{ID: "u-1", Email: "ada@example.com", FirstName: "Ada", LastName: "Lovelace", EmailVerifiedAt: &verifiedAt}
+	}
+	other := &entity.User{ID: "u-2", Email: "grace@example.com"}
+	ptr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name         string
+		req          dto.UpdateUserRequest
+		want         entity.User
+		wantVerified bool
+		wantErr      error
+	}{
+		{
+			name:         "only the given fields change",
+			req:          dto.UpdateUserRequest{FirstName: ptr("Augusta")},
+			want:         entity.User{ID: "u-1", Email: "ada@example.com", FirstName: "Augusta", LastName: "Lovelace"},
+			wantVerified: true,
+		},
+		{
+			name:         "the same email stays verified",
+			req:          dto.UpdateUserRequest{Email: ptr("ada@example.com")},
+			want:         entity.User{ID: "u-1", Email: "ada@example.com", FirstName: "Ada", LastName: "Lovelace"},
+			wantVerified: true,
+		},
+		{
+			name: "a new email has to be verified again",
+			req:  dto.UpdateUserRequest{Email: ptr("countess@example.com")},
+			want: entity.User{ID: "u-1", Email: "countess@example.com", FirstName: "Ada", LastName: "Lovelace"},
+		},
+		{
+			name:    "the email of another user",
+			req:     dto.UpdateUserRequest{LastName: ptr("Hopper"), Email: ptr("grace@example.com")},
+			wantErr: entity.ErrEmailAlreadyExists,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryUserRepository(newUser(), other)
+
+			_, err := NewUpdateUserUseCase(repo).Execute(context.Background(), "u-1", tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+
+			stored := repo.users["u-1"]
+			if tt.wantErr != nil {
+				if stored.LastName != "Lovelace" {
+					t.Errorf("stored user = %+v, want it unchanged", stored)
+				}
+				return
+			}
+			if stored.Email != tt.want.Email || stored.FirstName != tt.want.FirstName || stored.LastName != tt.want.LastName {
+				t.Errorf("stored user = %+v, want %+v", stored, tt.want)
+			}
+			if stored.IsEmailVerified() != tt.wantVerified {
+				t.Errorf("IsEmailVerified() = %v, want %v", stored.IsEmailVerified(), tt.wantVerified)
+			}
+		})
+	}
+
+	if _, err := NewUpdateUserUseCase(newMemoryUserRepository()).Execute(context.Background(), "u-1", dto.UpdateUserRequest{}); !errors.Is(err, entity.ErrUserNotFound) {
+		t.Errorf("Execute() for an unknown user error = %v, want ErrUserNotFound", err)
+	}
+}
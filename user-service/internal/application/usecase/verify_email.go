@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+)
+
+// VerifyEmailUseCase handles verifying an email with the emailed token
+type VerifyEmailUseCase struct {
+	verificationTokenRepo repository.EmailVerificationTokenRepository
+}
+
+// NewVerifyEmailUseCase creates a new VerifyEmailUseCase
+func NewVerifyEmailUseCase(verificationTokenRepo repository.EmailVerificationTokenRepository) *VerifyEmailUseCase {
+	return &VerifyEmailUseCase{
+		verificationTokenRepo: verificationTokenRepo,
+	}
+}
+
+// Execute marks the email of the token's user verified. Access tokens carry
+// the verification from the next login or refresh on.
+func (uc *VerifyEmailUseCase) Execute(ctx context.Context, req dto.VerifyEmailRequest) error {
+	tokenHash := entity.HashEmailVerificationToken(req.Token)
+	return uc.verificationTokenRepo.VerifyEmail(ctx, tokenHash, time.Now())
+}
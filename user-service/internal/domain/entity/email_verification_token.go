@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidEmailVerificationToken = errors.New("invalid or expired email verification token")
+
+// EmailVerificationToken proves that a user owns the email address it was
+// sent to. It can be used once, and only while the user still has that address.
+type EmailVerificationToken struct {
+	ID        string
+	UserID    string
+	Email     string // the address the token was sent to
+	TokenHash string // SHA-256 of the token, the token itself is never stored
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// NewEmailVerificationToken generates a random verification token for the
+// email address and returns it together with the entity holding its hash
+func NewEmailVerificationToken(userID, email string, ttl time.Duration, now time.Time) (*EmailVerificationToken, string, error) {
+	token, err := newSecretToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now = now.UTC()
+	return &EmailVerificationToken{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Email:     email,
+		TokenHash: HashEmailVerificationToken(token),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}, token, nil
+}
+
+// HashEmailVerificationToken returns the hash a verification token is stored and looked up by
+func HashEmailVerificationToken(token string) string {
+	return hashSecretToken(token)
+}
+
+// IsUsable checks if the token can still be used to verify the email
+func (t *EmailVerificationToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
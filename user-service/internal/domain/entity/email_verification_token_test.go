@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEmailVerificationTokenIsUsable(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	earlier := now.Add(-time.Minute)
+
+	tests := []struct {
+		name  string
+		token EmailVerificationToken
+		want  bool
+	}{
+		{name: "fresh", token: EmailVerificationToken{ExpiresAt: now.Add(time.Minute)}, want: true},
+		{name: "expires now", token: EmailVerificationToken{ExpiresAt: now}},
+		{name: "used", token: EmailVerificationToken{ExpiresAt: now.Add(time.Minute), UsedAt: &earlier}},
+	}
+	for _, tt := range tests {
+		if got := tt.token.IsUsable(now); got != tt.want {
+			t.Errorf("%s: IsUsable() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	token, raw, err := NewEmailVerificationToken("u-1", "ada@example.com", time.Hour, now)
+	if err != nil {
+		t.Fatalf("NewEmailVerificationToken() error = %v", err)
+	}
+	if token.TokenHash != HashEmailVerificationToken(raw) || token.Email != "ada@example.com" || !token.IsUsable(now) {
+		t.Errorf("NewEmailVerificationToken() = %+v, want a usable token for the address stored by its hash", token)
+	}
+}
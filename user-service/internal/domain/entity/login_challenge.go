@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidLoginChallenge = errors.New("invalid or expired login challenge")
+
+// LoginChallengeTTL is how long a user has to enter the two-factor code
+const LoginChallengeTTL = 5 * time.Minute
+
+// MaxLoginChallengeAttempts is the number of wrong codes a challenge takes
+const MaxLoginChallengeAttempts = 5
+
+// LoginChallenge is handed out instead of tokens when a user with two-factor
+// authentication logs in with the right password. It is exchanged once,
+// together with a code, for the tokens.
+type LoginChallenge struct {
+	ID             string
+	UserID         string
+	TokenHash      string // SHA-256 of the token, the token itself is never stored
+	ExpiresAt      time.Time
+	UsedAt         *time.Time
+	FailedAttempts int
+	CreatedAt      time.Time
+}
+
+// NewLoginChallenge generates a random challenge token and returns it
+// together with the entity holding its hash
+func NewLoginChallenge(userID string, now time.Time) (*LoginChallenge, string, error) {
+	token, err := newSecretToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now = now.UTC()
+	return &LoginChallenge{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		TokenHash: HashLoginChallenge(token),
+		ExpiresAt: now.Add(LoginChallengeTTL),
+		CreatedAt: now,
+	}, token, nil
+}
+
+// HashLoginChallenge returns the hash a challenge token is stored and looked up by
+func HashLoginChallenge(token string) string {
+	return hashSecretToken(token)
+}
+
+// IsUsable checks if the challenge can still be exchanged for tokens
+func (c *LoginChallenge) IsUsable(now time.Time) bool {
+	return c.UsedAt == nil && c.FailedAttempts < MaxLoginChallengeAttempts && now.Before(c.ExpiresAt)
+}
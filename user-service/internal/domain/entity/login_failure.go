@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrTooManyLoginAttempts = errors.New("too many failed login attempts, try again later")
+
+// Scopes failed logins are counted in
+const (
+	LoginScopeAccount = "account"
+	LoginScopeIP      = "ip"
+)
+
+// LoginFailure counts the recent failed logins of an account or a client IP
+type LoginFailure struct {
+	Scope          string
+	Subject        string // normalized email or IP address
+	FailedAttempts int
+	LastFailedAt   time.Time
+	LockedUntil    *time.Time
+}
+
+// LoginThrottle decides how long logins have to wait after failing
+type LoginThrottle struct {
+	// MaxAttempts failures in a row lock logins for LockoutDuration. Every
+	// further failure after a lockout locks again.
+	MaxAttempts     int
+	LockoutDuration time.Duration
+	// BaseDelay is the wait after the first failure, doubling with every
+	// further failure up to MaxDelay. Zero disables the delays.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// ResetAfter this long without failures counting starts over
+	ResetAfter time.Duration
+}
+
+// LoginThrottledError is returned while logins have to wait, it wraps
+// ErrTooManyLoginAttempts
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return ErrTooManyLoginAttempts.Error()
+}
+
+func (e *LoginThrottledError) Unwrap() error {
+	return ErrTooManyLoginAttempts
+}
+
+// NormalizeLoginEmail returns the subject failed logins of an account are counted by
+func NormalizeLoginEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// RetryAfter returns how long until the next login is allowed, zero if it is
+// allowed now
+func (t LoginThrottle) RetryAfter(failure *LoginFailure, now time.Time) time.Duration {
+	if failure.FailedAttempts == 0 {
+		return 0
+	}
+
+	allowedAt := failure.LastFailedAt.Add(t.Delay(failure.FailedAttempts))
+	if failure.LockedUntil != nil && failure.LockedUntil.After(allowedAt) {
+		allowedAt = *failure.LockedUntil
+	}
+	if !now.Before(allowedAt) {
+		return 0
+	}
+	return allowedAt.Sub(now)
+}
+
+// Delay returns the wait after the given number of failures in a row
+func (t LoginThrottle) Delay(failedAttempts int) time.Duration {
+	if t.BaseDelay <= 0 || failedAttempts <= 0 {
+		return 0
+	}
+
+	delay := t.BaseDelay
+	for i := 1; i < failedAttempts && delay < t.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > t.MaxDelay {
+		delay = t.MaxDelay
+	}
+	return delay
+}
+
+// ShouldLock checks if the failures reached the limit
+func (t LoginThrottle) ShouldLock(failure *LoginFailure) bool {
+	return t.MaxAttempts > 0 && failure.FailedAttempts >= t.MaxAttempts
+}
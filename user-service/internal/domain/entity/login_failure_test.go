@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var testThrottle = LoginThrottle{
+	MaxAttempts:     5,
+	LockoutDuration: 15 * time.Minute,
+	BaseDelay:       time.Second,
+	MaxDelay:        8 * time.Second,
+	ResetAfter:      time.Hour,
+}
+
+func TestLoginThrottleDelay(t *testing.T) {
+	tests := []struct {
+		failedAttempts int
+		want           time.Duration
+	}{
+		{failedAttempts: 0, want: 0},
+		{failedAttempts: 1, want: time.Second},
+		{failedAttempts: 2, want: 2 * time.Second},
+		{failedAttempts: 3, want: 4 * time.Second},
+		{failedAttempts: 4, want: 8 * time.Second},
+		{failedAttempts: 5, want: 8 * time.Second},
+		{failedAttempts: 1000, want: 8 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := testThrottle.Delay(tt.failedAttempts); got != tt.want {
+			t.Errorf("Delay(%d) = %v, want %v", tt.failedAttempts, got, tt.want)
+		}
+	}
+
+	// A maximum that is not a power of two of the base caps the doubling
+	capped := LoginThrottle{BaseDelay: 3 * time.Second, MaxDelay: 10 * time.Second}
+	if got := capped.Delay(3); got != 10*time.Second {
+		t.Errorf("Delay(3) = %v, want 10s", got)
+	}
+	if got := (LoginThrottle{}).Delay(3); got != 0 {
+		t.Errorf("Delay(3) without a base delay = %v, want 0", got)
+	}
+}
+
+func TestLoginThrottleRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	lockedUntil := now.Add(10 * time.Minute)
+	lockExpired := now.Add(-time.Second)
+
+	tests := []struct {
+		name    string
+		failure LoginFailure
+		want    time.Duration
+	}{
+		{name: "no failures", failure: LoginFailure{}, want: 0},
+		{
+			name:    "waiting out the delay",
+			failure: LoginFailure{FailedAttempts: 3, LastFailedAt: now.Add(-time.Second)},
+			want:    3 * time.Second,
+		},
+		{
+			name:    "delay passed",
+			failure: LoginFailure{FailedAttempts: 3, LastFailedAt: now.Add(-4 * time.Second)},
+			want:    0,
+		},
+		{
+			name:    "locked",
+			failure: LoginFailure{FailedAttempts: 5, LastFailedAt: now, LockedUntil: &lockedUntil},
+			want:    10 * time.Minute,
+		},
+		{
+			name:    "lock expired",
+			failure: LoginFailure{FailedAttempts: 5, LastFailedAt: now.Add(-time.Minute), LockedUntil: &lockExpired},
+			want:    0,
+		},
+	}
+	for _, tt := range tests {
+		if got := testThrottle.RetryAfter(&tt.failure, now); got != tt.want {
+			t.Errorf("%s: RetryAfter() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLoginThrottleShouldLock(t *testing.T) {
+	for attempts, want := range map[int]bool{0: false, 4: false, 5: true, 6: true} {
+		if got := testThrottle.ShouldLock(&LoginFailure{FailedAttempts: attempts}); got != want {
+			t.Errorf("ShouldLock(%d failures) = %v, want %v", attempts, got, want)
+		}
+	}
+	if (LoginThrottle{}).ShouldLock(&LoginFailure{FailedAttempts: 100}) {
+		t.Error("ShouldLock() without a limit = true, want false")
+	}
+}
+
+func TestLoginThrottledError(t *testing.T) {
+	var err error = &LoginThrottledError{RetryAfter: time.Minute}
+	if !errors.Is(err, ErrTooManyLoginAttempts) {
+		t.Errorf("%v does not wrap ErrTooManyLoginAttempts", err)
+	}
+}
+
+func TestNormalizeLoginEmail(t *testing.T) {
+	if got := NormalizeLoginEmail("  Ada@Example.COM "); got != "ada@example.com" {
+		t.Errorf("NormalizeLoginEmail() = %q, want ada@example.com", got)
+	}
+}
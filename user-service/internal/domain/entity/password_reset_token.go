@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidPasswordResetToken = errors.New("invalid or expired password reset token")
+
+// PasswordResetToken lets a user who forgot the password set a new one. It
+// is sent to the user's email address and can be used once.
+type PasswordResetToken struct {
+	ID        string
+	UserID    string
+	TokenHash string // SHA-256 of the token, the token itself is never stored
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// NewPasswordResetToken generates a random reset token and returns it
+// together with the entity holding its hash
+func NewPasswordResetToken(userID string, ttl time.Duration, now time.Time) (*PasswordResetToken, string, error) {
+	token, err := newSecretToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now = now.UTC()
+	return &PasswordResetToken{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		TokenHash: HashPasswordResetToken(token),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}, token, nil
+}
+
+// HashPasswordResetToken returns the hash a reset token is stored and looked up by
+func HashPasswordResetToken(token string) string {
+	return hashSecretToken(token)
+}
+
+// IsUsable checks if the token can still be used to reset the password
+func (t *PasswordResetToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPasswordResetTokenIsUsable(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	earlier := now.Add(-time.Minute)
+
+	tests := []struct {
+		name  string
+		token PasswordResetToken
+		want  bool
+	}{
+		{name: "fresh", token: PasswordResetToken{ExpiresAt: now.Add(time.Minute)}, want: true},
+		{name: "expires now", token: PasswordResetToken{ExpiresAt: now}},
+		{name: "used", token: PasswordResetToken{ExpiresAt: now.Add(time.Minute), UsedAt: &earlier}},
+	}
+	for _, tt := range tests {
+		if got := tt.token.IsUsable(now); got != tt.want {
+			t.Errorf("%s: IsUsable() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	token, raw, err := NewPasswordResetToken("u-1", time.Hour, now)
+	if err != nil {
+		t.Fatalf("NewPasswordResetToken() error = %v", err)
+	}
+	if token.TokenHash != HashPasswordResetToken(raw) || !token.IsUsable(now) {
+		t.Errorf("NewPasswordResetToken() = %+v, want a usable token stored by its hash", token)
+	}
+}
@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token was already used, all sessions of this login have been revoked")
+)
+
+// RefreshToken is a long-lived token exchanged for a new access token.
+// A login starts a family and every refresh replaces the token with a new
+// one of the same family, so a replaced token presented again means it was
+// stolen and the whole family is revoked.
+type RefreshToken struct {
+	ID        string
+	UserID    string
+	FamilyID  string
+	TokenHash string // SHA-256 of the token, the token itself is never stored
+	ExpiresAt time.Time
+	// UsedAt is set when the token was replaced by ReplacedBy
+	UsedAt     *time.Time
+	ReplacedBy string
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+// NewRefreshToken generates a random refresh token and returns it together
+// with the entity holding its hash. The token is only known to the caller.
+func NewRefreshToken(userID, familyID string, ttl time.Duration, now time.Time) (*RefreshToken, string, error) {
+	token, err := newSecretToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now = now.UTC()
+	return &RefreshToken{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: HashRefreshToken(token),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}, token, nil
+}
+
+// HashRefreshToken returns the hash a refresh token is stored and looked up by
+func HashRefreshToken(token string) string {
+	return hashSecretToken(token)
+}
+
+// IsUsable checks if the token can still be exchanged or used to log out
+func (t *RefreshToken) IsUsable(now time.Time) bool {
+	return t.UsedAt == nil && t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
+
+// newSecretToken generates a random URL safe token of 256 bits
+func newSecretToken() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// hashSecretToken returns the SHA-256 hex digest a secret token is stored as
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewRefreshToken(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	token, raw, err := NewRefreshToken("u-1", "f-1", time.Hour, now)
+	if err != nil {
+		t.Fatalf("NewRefreshToken() error = %v", err)
+	}
+	if token.TokenHash != HashRefreshToken(raw) || token.TokenHash == raw {
+		t.Errorf("TokenHash = %q, want the hash of the returned token", token.TokenHash)
+	}
+	if token.UserID != "u-1" || token.FamilyID != "f-1" || !token.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("token = %+v", token)
+	}
+
+	_, other, err := NewRefreshToken("u-1", "f-1", time.Hour, now)
+	if err != nil || other == raw {
+		t.Errorf("second token = %q, %v, want a different random token", other, err)
+	}
+}
+
+func TestRefreshTokenIsUsable(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	earlier := now.Add(-time.Minute)
+
+	tests := []struct {
+		name  string
+		token RefreshToken
+		want  bool
+	}{
+		{name: "fresh", token: RefreshToken{ExpiresAt: now.Add(time.Hour)}, want: true},
+		{name: "expired", token: RefreshToken{ExpiresAt: now}},
+		{name: "replaced", token: RefreshToken{ExpiresAt: now.Add(time.Hour), UsedAt: &earlier}},
+		{name: "revoked", token: RefreshToken{ExpiresAt: now.Add(time.Hour), RevokedAt: &earlier}},
+	}
+	for _, tt := range tests {
+		if got := tt.token.IsUsable(now); got != tt.want {
+			t.Errorf("%s: IsUsable() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
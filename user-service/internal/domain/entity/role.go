@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+var (
+	ErrRoleNotFound      = errors.New("role not found")
+	ErrRoleAlreadyExists = errors.New("role already exists")
+	ErrUnknownPermission = errors.New("unknown permission")
+	ErrInvalidRoleName   = errors.New("role name must be 2-50 lowercase letters, digits or underscores")
+	ErrProtectedRole     = errors.New("the admin role always has every permission")
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,49}$`)
+
+// Role is a named set of permissions assigned to users
+type Role struct {
+	Name        string
+	Description string
+	Permissions []string
+	CreatedAt   time.Time
+}
+
+// Validate checks the role can be stored
+func (r *Role) Validate() error {
+	if !roleNamePattern.MatchString(r.Name) {
+		return ErrInvalidRoleName
+	}
+	return nil
+}
+
+// IsProtected reports whether the role's permissions can not be changed, so
+// admins can not lock themselves out
+func (r *Role) IsProtected() bool {
+	return r.Name == RoleAdmin
+}
+
+// Permission is something a role allows, checked by the services
+type Permission struct {
+	Name        string
+	Description string
+}
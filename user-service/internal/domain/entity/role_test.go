@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRoleValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		wantErr error
+	}{
+		{name: "customer"},
+		{name: "inventory_manager"},
+		{name: "tier2"},
+		{name: "a", wantErr: ErrInvalidRoleName},
+		{name: "2fa", wantErr: ErrInvalidRoleName},
+		{name: "Support", wantErr: ErrInvalidRoleName},
+		{name: "support-team", wantErr: ErrInvalidRoleName},
+		{name: "a23456789012345678901234567890123456789012345678901", wantErr: ErrInvalidRoleName},
+	}
+	for _, tt := range tests {
+		role := Role{Name: tt.name}
+		if err := role.Validate(); !errors.Is(err, tt.wantErr) {
+			t.Errorf("Validate(%q) = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestRoleIsProtected(t *testing.T) {
+	if !(&Role{Name: RoleAdmin}).IsProtected() {
+		t.Error("the admin role must be protected")
+	}
+	if (&Role{Name: RoleCustomer}).IsProtected() {
+		t.Error("the customer role must not be protected")
+	}
+}
@@ -0,0 +1,174 @@
+package entity
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnrolled    = errors.New("two-factor enrolment has not been started")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+)
+
+// TOTP parameters (RFC 6238), the defaults every authenticator app supports
+const (
+	totpPeriod = 30 * time.Second
+	totpDigits = 6
+	// totpSkew is the number of steps a code may be off, for clocks out of sync
+	totpSkew = 1
+	// totpSecretSize is the size of a secret in bytes, as recommended by RFC 4226
+	totpSecretSize = 20
+)
+
+// RecoveryCodeCount is the number of recovery codes a user gets
+const RecoveryCodeCount = 10
+
+// secretEncoding encodes TOTP secrets and recovery codes
+var secretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TwoFactor is the TOTP authenticator of a user. It is enabled once the user
+// confirmed the enrolment with a code from the authenticator app.
+type TwoFactor struct {
+	UserID string
+	Secret string // base32, as entered into the authenticator app
+	// EnabledAt is nil while the enrolment is not confirmed
+	EnabledAt *time.Time
+	// LastUsedStep is the time step of the last accepted code, older and equal
+	// steps are refused so a code can't be used twice
+	LastUsedStep int64
+	CreatedAt    time.Time
+}
+
+// NewTwoFactor generates a random TOTP secret for the user
+func NewTwoFactor(userID string, now time.Time) (*TwoFactor, error) {
+	secret := make([]byte, totpSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	return &TwoFactor{
+		UserID:    userID,
+		Secret:    secretEncoding.EncodeToString(secret),
+		CreatedAt: now.UTC(),
+	}, nil
+}
+
+// IsEnabled checks if logins of the user need a code
+func (t *TwoFactor) IsEnabled() bool {
+	return t.EnabledAt != nil
+}
+
+// URI returns the otpauth URI authenticator apps read from a QR code
+func (t *TwoFactor) URI(issuer, accountName string) string {
+	label := url.PathEscape(issuer + ":" + accountName)
+	query := url.Values{}
+	query.Set("secret", t.Secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(int(totpPeriod.Seconds())))
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// MatchStep returns the time step the code is valid for. Codes of steps not
+// after LastUsedStep don't match.
+func (t *TwoFactor) MatchStep(code string, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+	secret, err := secretEncoding.DecodeString(t.Secret)
+	if err != nil {
+		return 0, false
+	}
+
+	current := now.Unix() / int64(totpPeriod.Seconds())
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= t.LastUsedStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(totpCode(secret, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// totpCode computes the code of a time step (RFC 4226 dynamic truncation)
+func totpCode(secret []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%modulo)
+}
+
+// RecoveryCode lets a user who lost the authenticator log in once
+type RecoveryCode struct {
+	ID        string
+	UserID    string
+	CodeHash  string // SHA-256 of the normalized code, the code itself is never stored
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// NewRecoveryCodes generates RecoveryCodeCount recovery codes and returns
+// them together with the entities holding their hashes. The codes are only
+// shown to the user once.
+func NewRecoveryCodes(userID string, now time.Time) ([]*RecoveryCode, []string, error) {
+	now = now.UTC()
+	recoveryCodes := make([]*RecoveryCode, RecoveryCodeCount)
+	codes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		// 80 random bits, written as xxxx-xxxx-xxxx-xxxx
+		random := make([]byte, 10)
+		if _, err := rand.Read(random); err != nil {
+			return nil, nil, err
+		}
+		encoded := strings.ToLower(secretEncoding.EncodeToString(random))
+		codes[i] = encoded[0:4] + "-" + encoded[4:8] + "-" + encoded[8:12] + "-" + encoded[12:16]
+
+		recoveryCodes[i] = &RecoveryCode{
+			ID:        uuid.New().String(),
+			UserID:    userID,
+			CodeHash:  HashRecoveryCode(codes[i]),
+			CreatedAt: now,
+		}
+	}
+	return recoveryCodes, codes, nil
+}
+
+// HashRecoveryCode returns the hash a recovery code is stored and looked up
+// by. Case, dashes and spaces don't matter.
+func HashRecoveryCode(code string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(code)))
+	return hashSecretToken(normalized)
+}
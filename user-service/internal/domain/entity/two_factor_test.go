@@ -0,0 +1,165 @@
+package entity
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 secret of the RFC 6238 test vectors
+var rfc6238Secret = secretEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPMatchesRFC6238Vectors(t *testing.T) {
+	// The RFC lists 8 digit codes, 6 digit codes are their last 6 digits
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+		{unix: 20000000000, code: "353130"},
+	}
+	for _, tt := range tests {
+		twoFactor := &TwoFactor{Secret: rfc6238Secret}
+
+		step, ok := twoFactor.MatchStep(tt.code, time.Unix(tt.unix, 0))
+		if !ok || step != tt.unix/30 {
+			t.Errorf("MatchStep(%s) at %d = %d, %v, want step %d", tt.code, tt.unix, step, ok, tt.unix/30)
+		}
+	}
+}
+
+func TestTOTPMatchStep(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	step := now.Unix() / 30
+	secret, err := secretEncoding.DecodeString(rfc6238Secret)
+	if err != nil {
+		t.Fatalf("failed to decode secret: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		code         string
+		lastUsedStep int64
+		wantStep     int64
+		wantOK       bool
+	}{
+		{name: "current step", code: totpCode(secret, step), wantStep: step, wantOK: true},
+		{name: "surrounded by spaces", code: " " + totpCode(secret, step) + " ", wantStep: step, wantOK: true},
+		{name: "previous step", code: totpCode(secret, step-1), wantStep: step - 1, wantOK: true},
+		{name: "next step", code: totpCode(secret, step+1), wantStep: step + 1, wantOK: true},
+		{name: "beyond the skew", code: totpCode(secret, step-2)},
+		{name: "already used", code: totpCode(secret, step), lastUsedStep: step},
+		{name: "older than the last used step", code: totpCode(secret, step-1), lastUsedStep: step},
+		{name: "later than the last used step", code: totpCode(secret, step+1), lastUsedStep: step, wantStep: step + 1, wantOK: true},
+		{name: "too short", code: totpCode(secret, step)[:5]},
+		{name: "empty", code: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			twoFactor := &TwoFactor{Secret: rfc6238Secret, LastUsedStep: tt.lastUsedStep}
+
+			gotStep, ok := twoFactor.MatchStep(tt.code, now)
+			if ok != tt.wantOK || gotStep != tt.wantStep {
+				t.Errorf("MatchStep(%q) = %d, %v, want %d, %v", tt.code, gotStep, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNewTwoFactor(t *testing.T) {
+	twoFactor, err := NewTwoFactor("u-1", time.Now())
+	if err != nil {
+		t.Fatalf("NewTwoFactor() error = %v", err)
+	}
+	secret, err := secretEncoding.DecodeString(twoFactor.Secret)
+	if err != nil || len(secret) != totpSecretSize {
+		t.Errorf("secret %q decodes to %d bytes, %v, want %d", twoFactor.Secret, len(secret), err, totpSecretSize)
+	}
+	if twoFactor.IsEnabled() {
+		t.Error("a new enrolment must not be enabled before it is confirmed")
+	}
+
+	uri, err := url.Parse(twoFactor.URI("Shop", "ada@example.com"))
+	if err != nil {
+		t.Fatalf("URI() is not a URL: %v", err)
+	}
+	query := uri.Query()
+	if uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Path != "/Shop:ada@example.com" ||
+		query.Get("secret") != twoFactor.Secret || query.Get("issuer") != "Shop" ||
+		query.Get("digits") != "6" || query.Get("period") != "30" {
+		t.Errorf("URI() = %s", uri)
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	recoveryCodes, codes, err := NewRecoveryCodes("u-1", time.Now())
+	if err != nil {
+		t.Fatalf("NewRecoveryCodes() error = %v", err)
+	}
+	if len(codes) != RecoveryCodeCount || len(recoveryCodes) != RecoveryCodeCount {
+		t.Fatalf("got %d codes and %d entities, want %d", len(codes), len(recoveryCodes), RecoveryCodeCount)
+	}
+
+	seen := make(map[string]bool, len(codes))
+	for i, code := range codes {
+		groups := strings.Split(code, "-")
+		if len(groups) != 4 || len(code) != 19 || code != strings.ToLower(code) {
+			t.Errorf("code %q is not written as xxxx-xxxx-xxxx-xxxx", code)
+		}
+		if seen[code] {
+			t.Errorf("code %q was generated twice", code)
+		}
+		seen[code] = true
+
+		if recoveryCodes[i].CodeHash != HashRecoveryCode(code) || recoveryCodes[i].UserID != "u-1" {
+			t.Errorf("entity %+v does not hold the hash of %q", recoveryCodes[i], code)
+		}
+	}
+}
+
+func TestHashRecoveryCodeNormalizes(t *testing.T) {
+	want := HashRecoveryCode("abcd-efgh-ijkl-mnop")
+	for _, code := range []string{"ABCD-EFGH-IJKL-MNOP", "abcdefghijklmnop", " abcd efgh ijkl mnop ", "Abcd-Efgh ijkl-mnop"} {
+		if got := HashRecoveryCode(code); got != want {
+			t.Errorf("HashRecoveryCode(%q) differs from the canonical code", code)
+		}
+	}
+	if HashRecoveryCode("abcd-efgh-ijkl-mnoq") == want {
+		t.Error("different codes hash the same")
+	}
+}
+
+func TestLoginChallengeIsUsable(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	earlier := now.Add(-time.Second)
+
+	tests := []struct {
+		name      string
+		challenge LoginChallenge
+		want      bool
+	}{
+		{name: "fresh", challenge: LoginChallenge{ExpiresAt: now.Add(time.Minute)}, want: true},
+		{name: "one attempt left", challenge: LoginChallenge{ExpiresAt: now.Add(time.Minute), FailedAttempts: MaxLoginChallengeAttempts - 1}, want: true},
+		{name: "out of attempts", challenge: LoginChallenge{ExpiresAt: now.Add(time.Minute), FailedAttempts: MaxLoginChallengeAttempts}},
+		{name: "expired", challenge: LoginChallenge{ExpiresAt: now}},
+		{name: "used", challenge: LoginChallenge{ExpiresAt: now.Add(time.Minute), UsedAt: &earlier}},
+	}
+	for _, tt := range tests {
+		if got := tt.challenge.IsUsable(now); got != tt.want {
+			t.Errorf("%s: IsUsable() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	challenge, token, err := NewLoginChallenge("u-1", now)
+	if err != nil {
+		t.Fatalf("NewLoginChallenge() error = %v", err)
+	}
+	if challenge.TokenHash != HashLoginChallenge(token) || !challenge.ExpiresAt.Equal(now.Add(LoginChallengeTTL)) {
+		t.Errorf("NewLoginChallenge() = %+v", challenge)
+	}
+}
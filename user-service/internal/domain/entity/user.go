@@ -1,11 +1,24 @@
 package entity
 
 import (
+	"errors"
+	"sync"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
+var (
+	ErrUserNotFound       = errors.New("user not found")
+	ErrEmailAlreadyExists = errors.New("user with this email already exists")
+)
+
+// User roles
+const (
+	RoleCustomer = "customer"
+	RoleAdmin    = "admin"
+)
+
 // User represents a user in the system
 type User struct {
 	ID        string    `json:"id"`
@@ -17,6 +30,8 @@ type User struct {
 	IsActive  bool      `json:"is_active"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// EmailVerifiedAt is nil until the user verified the email address
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty"`
 }
 
 // HashPassword hashes the user's password
@@ -35,7 +50,25 @@ func (u *User) CheckPassword(password string) bool {
 	return err == nil
 }
 
+// dummyPasswordHash is compared against when there is no user, created once
+// with the same cost as real password hashes
+var dummyPasswordHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+	return hash
+})
+
+// CompareDummyPassword takes as long as CheckPassword of a user, so logins
+// with unknown emails can not be told apart by their response time
+func CompareDummyPassword(password string) {
+	_ = bcrypt.CompareHashAndPassword(dummyPasswordHash(), []byte(password))
+}
+
+// IsEmailVerified checks if the user proved owning the email address
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
 // IsAdmin checks if user has admin role
 func (u *User) IsAdmin() bool {
-	return u.Role == "admin"
+	return u.Role == RoleAdmin
 }
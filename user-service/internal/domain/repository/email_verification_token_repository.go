@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+)
+
+// EmailVerificationTokenRepository defines the interface for email verification token storage
+type EmailVerificationTokenRepository interface {
+	// Create stores a new verification token and invalidates the unused
+	// tokens sent to the user before
+	Create(ctx context.Context, token *entity.EmailVerificationToken) error
+
+	// VerifyEmail uses the token with the given hash to mark the email of
+	// its user verified. A token that is no longer usable, or was sent to an
+	// address the user has changed since, returns
+	// entity.ErrInvalidEmailVerificationToken.
+	VerifyEmail(ctx context.Context, tokenHash string, now time.Time) error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+)
+
+// LoginChallengeRepository defines the interface for login challenge storage
+type LoginChallengeRepository interface {
+	// Create stores a new challenge
+	Create(ctx context.Context, challenge *entity.LoginChallenge) error
+
+	// GetByHash retrieves a challenge by the hash of the token
+	GetByHash(ctx context.Context, tokenHash string) (*entity.LoginChallenge, error)
+
+	// RecordFailure counts a wrong code entered for the challenge
+	RecordFailure(ctx context.Context, id string) error
+
+	// MarkUsed uses up the challenge. A challenge used before returns
+	// entity.ErrInvalidLoginChallenge.
+	MarkUsed(ctx context.Context, id string, now time.Time) error
+}
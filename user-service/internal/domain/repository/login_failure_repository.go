@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+)
+
+// LoginFailureRepository defines the interface for counting failed logins
+type LoginFailureRepository interface {
+	// Get retrieves the failures of an account or IP, without failures it
+	// returns a LoginFailure with no failed attempts
+	Get(ctx context.Context, scope, subject string) (*entity.LoginFailure, error)
+
+	// RecordFailure atomically counts a failed login and returns the new
+	// count. Counting starts over when the last failure is older than resetAfter.
+	RecordFailure(ctx context.Context, scope, subject string, now time.Time, resetAfter time.Duration) (*entity.LoginFailure, error)
+
+	// Lock refuses logins of an account or IP until the given time
+	Lock(ctx context.Context, scope, subject string, until time.Time) error
+
+	// Reset forgets the failures of an account or IP
+	Reset(ctx context.Context, scope, subject string) error
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+)
+
+// PasswordResetTokenRepository defines the interface for password reset token storage
+type PasswordResetTokenRepository interface {
+	// Create stores a new reset token and invalidates the unused tokens the
+	// user requested before
+	Create(ctx context.Context, token *entity.PasswordResetToken) error
+
+	// GetByHash retrieves a reset token by the hash of the token
+	GetByHash(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error)
+
+	// ResetPassword uses the token with the given hash to replace the user's
+	// password hash and revokes every refresh token of the user, all or
+	// nothing. A token that is no longer usable returns
+	// entity.ErrInvalidPasswordResetToken.
+	ResetPassword(ctx context.Context, tokenHash, passwordHash string, now time.Time) error
+}
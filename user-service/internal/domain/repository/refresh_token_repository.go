@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+)
+
+// RefreshTokenRepository defines the interface for refresh token storage
+type RefreshTokenRepository interface {
+	// Create stores a new refresh token
+	Create(ctx context.Context, token *entity.RefreshToken) error
+
+	// GetByHash retrieves a refresh token by the hash of the token
+	GetByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+
+	// Rotate replaces the usable token with the given hash by next, which
+	// joins the family and user of the replaced token. Presenting a token that
+	// was already replaced revokes its whole family and returns
+	// entity.ErrRefreshTokenReused.
+	Rotate(ctx context.Context, tokenHash string, next *entity.RefreshToken) error
+
+	// RevokeFamily revokes every token of a login
+	RevokeFamily(ctx context.Context, familyID string, now time.Time) error
+
+	// RevokeAllForUser revokes every token of the user, logging out all sessions
+	RevokeAllForUser(ctx context.Context, userID string, now time.Time) error
+}
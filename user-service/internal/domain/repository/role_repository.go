@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+)
+
+// RoleRepository defines the interface for roles and their permissions
+type RoleRepository interface {
+	// Create creates a role with its permissions
+	Create(ctx context.Context, role *entity.Role) error
+
+	// GetByName retrieves a role with its permissions
+	GetByName(ctx context.Context, name string) (*entity.Role, error)
+
+	// List retrieves all roles with their permissions
+	List(ctx context.Context) ([]*entity.Role, error)
+
+	// SetPermissions replaces the permissions of a role
+	SetPermissions(ctx context.Context, name string, permissions []string) error
+
+	// ListPermissions retrieves the permissions roles can grant
+	ListPermissions(ctx context.Context) ([]entity.Permission, error)
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+)
+
+// TwoFactorRepository defines the interface for two-factor authentication storage
+type TwoFactorRepository interface {
+	// GetByUserID retrieves the authenticator of a user, entity.ErrTwoFactorNotEnabled
+	// when the user never started an enrolment
+	GetByUserID(ctx context.Context, userID string) (*entity.TwoFactor, error)
+
+	// StartEnrolment stores a new secret for the user, replacing an enrolment
+	// that was not confirmed. It returns entity.ErrTwoFactorAlreadyEnabled
+	// when two-factor authentication is enabled.
+	StartEnrolment(ctx context.Context, twoFactor *entity.TwoFactor) error
+
+	// Enable confirms the enrolment with the time step of the entered code and
+	// replaces the user's recovery codes, all or nothing
+	Enable(ctx context.Context, userID string, step int64, recoveryCodes []*entity.RecoveryCode, now time.Time) error
+
+	// UseStep accepts a code of the given time step. A step not after the last
+	// accepted one returns entity.ErrInvalidTwoFactorCode.
+	UseStep(ctx context.Context, userID string, step int64) error
+
+	// UseRecoveryCode marks the unused recovery code with the given hash used,
+	// entity.ErrInvalidTwoFactorCode when there is none
+	UseRecoveryCode(ctx context.Context, userID, codeHash string, now time.Time) error
+
+	// Disable removes the authenticator and the recovery codes of the user
+	Disable(ctx context.Context, userID string) error
+}
@@ -21,9 +21,15 @@ type UserRepository interface {
 	// Update updates an existing user
 	Update(ctx context.Context, user *entity.User) error
 
+	// UpdateRole assigns an existing role to the user
+	UpdateRole(ctx context.Context, id, role string) error
+
 	// Delete soft deletes a user (sets IsActive to false)
 	Delete(ctx context.Context, id string) error
 
 	// List retrieves all users with pagination
 	List(ctx context.Context, limit, offset int) ([]*entity.User, error)
+
+	// Count counts the active users
+	Count(ctx context.Context) (int64, error)
 }
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"crypto"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+)
+
+// Token is a signed access token and the moment it stops being valid
+type Token struct {
+	Value     string
+	ExpiresAt time.Time
+}
+
+// PublicKey is a key other services verify tokens with, ID matches the kid
+// header of the tokens it verifies
+type PublicKey struct {
+	ID        string
+	Algorithm string
+	Key       crypto.PublicKey
+}
+
+// TokenService defines the interface for issuing access tokens
+// This is an INTERFACE - implementations will be in infrastructure layer
+type TokenService interface {
+	// Issue creates a signed token carrying the user's ID, email, whether the
+	// email is verified, role and the permissions of the role
+	Issue(user *entity.User, permissions []string) (*Token, error)
+
+	// PublicKeys returns the keys tokens can currently be verified with.
+	// It is empty when tokens are signed with a shared secret.
+	PublicKeys() []PublicKey
+}
@@ -0,0 +1,141 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+	sqlc "github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/infrastructure/persistence/sqlc"
+)
+
+// PostgresEmailVerificationTokenRepository implements repository.EmailVerificationTokenRepository using sqlc
+type PostgresEmailVerificationTokenRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+// NewPostgresEmailVerificationTokenRepository creates a new PostgreSQL email verification token repository
+func NewPostgresEmailVerificationTokenRepository(db *sql.DB) repository.EmailVerificationTokenRepository {
+	return &PostgresEmailVerificationTokenRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+// Create stores a new verification token, only the newest token of a user is usable
+func (r *PostgresEmailVerificationTokenRepository) Create(ctx context.Context, token *entity.EmailVerificationToken) error {
+	id, err := parseStringToUUID(token.ID)
+	if err != nil {
+		return errors.New("invalid email verification token ID format")
+	}
+	uid, err := parseStringToUUID(token.UserID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.InvalidateUserEmailVerificationTokens(ctx, sqlc.InvalidateUserEmailVerificationTokensParams{
+		UserID: uid,
+		UsedAt: sql.NullTime{Time: token.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to invalidate email verification tokens: %w", err)
+	}
+
+	err = qtx.CreateEmailVerificationToken(ctx, sqlc.CreateEmailVerificationTokenParams{
+		ID:        id,
+		UserID:    uid,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+		Email:     token.Email,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create email verification token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyEmail marks the email of the token's user verified if it is still the
+// address the token was sent to. The token is locked, so it is used only once.
+func (r *PostgresEmailVerificationTokenRepository) VerifyEmail(ctx context.Context, tokenHash string, now time.Time) error {
+	now = now.UTC()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+	row, err := qtx.GetEmailVerificationTokenByHashForUpdate(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.ErrInvalidEmailVerificationToken
+		}
+		return fmt.Errorf("failed to lock email verification token: %w", err)
+	}
+	if !toEmailVerificationTokenEntity(&row).IsUsable(now) {
+		return entity.ErrInvalidEmailVerificationToken
+	}
+
+	err = qtx.MarkEmailVerificationTokenUsed(ctx, sqlc.MarkEmailVerificationTokenUsedParams{
+		ID:     row.ID,
+		UsedAt: sql.NullTime{Time: now, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark email verification token used: %w", err)
+	}
+
+	verified, err := qtx.MarkUserEmailVerified(ctx, sqlc.MarkUserEmailVerifiedParams{
+		ID:              row.UserID,
+		EmailVerifiedAt: sql.NullTime{Time: now, Valid: true},
+		UpdatedAt:       now,
+		Email:           row.Email,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark email verified: %w", err)
+	}
+	if verified == 0 {
+		// The user changed the email since the token was sent or was
+		// disabled, the token stays unused in case the address comes back
+		return entity.ErrInvalidEmailVerificationToken
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// toEmailVerificationTokenEntity converts sqlc.EmailVerificationToken to domain entity
+func toEmailVerificationTokenEntity(token *sqlc.EmailVerificationToken) *entity.EmailVerificationToken {
+	result := &entity.EmailVerificationToken{
+		ID:        token.ID.String(),
+		UserID:    token.UserID.String(),
+		Email:     token.Email,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	}
+	if token.UsedAt.Valid {
+		result.UsedAt = &token.UsedAt.Time
+	}
+	return result
+}
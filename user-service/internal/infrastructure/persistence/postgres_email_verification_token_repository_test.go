@@ -0,0 +1,74 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+)
+
+func TestVerifyEmailOnlyVerifiesTheAddressTheTokenWasSentTo(t *testing.T) {
+	db := openTestDatabase(t)
+	tokens := NewPostgresEmailVerificationTokenRepository(db)
+	users := NewPostgresUserRepository(db)
+	ctx := context.Background()
+	user := createTestUser(t, db)
+	ownedEmail := user.Email
+
+	sendToken := func() string {
+		t.Helper()
+		token, raw, err := entity.NewEmailVerificationToken(user.ID, user.Email, time.Hour, time.Now())
+		if err != nil {
+			t.Fatalf("NewEmailVerificationToken() error = %v", err)
+		}
+		if err := tokens.Create(ctx, token); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		return raw
+	}
+	changeEmail := func(email string) {
+		t.Helper()
+		user.Email = email
+		if err := users.Update(ctx, user); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+	}
+	isVerified := func() bool {
+		t.Helper()
+		stored, err := users.GetByID(ctx, user.ID)
+		if err != nil {
+			t.Fatalf("GetByID() error = %v", err)
+		}
+		return stored.IsEmailVerified()
+	}
+
+	// Verify the owned address, then keep a second token for it
+	if err := tokens.VerifyEmail(ctx, entity.HashEmailVerificationToken(sendToken()), time.Now()); err != nil {
+		t.Fatalf("VerifyEmail() error = %v", err)
+	}
+	spare := sendToken()
+
+	// Switching to an address the user does not own drops the verification,
+	// and the spare token of the old address must not bring it back
+	changeEmail("not-owned-" + ownedEmail)
+	if isVerified() {
+		t.Fatal("changing the email kept it verified")
+	}
+	if err := tokens.VerifyEmail(ctx, entity.HashEmailVerificationToken(spare), time.Now()); !errors.Is(err, entity.ErrInvalidEmailVerificationToken) {
+		t.Fatalf("VerifyEmail() with a token of the old address error = %v, want ErrInvalidEmailVerificationToken", err)
+	}
+	if isVerified() {
+		t.Fatal("a token of the old address verified the new one")
+	}
+
+	// The token still proves the address it was sent to
+	changeEmail(ownedEmail)
+	if err := tokens.VerifyEmail(ctx, entity.HashEmailVerificationToken(spare), time.Now()); err != nil {
+		t.Fatalf("VerifyEmail() after changing the email back error = %v", err)
+	}
+	if !isVerified() {
+		t.Error("the address the token was sent to is not verified")
+	}
+}
@@ -0,0 +1,114 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+	sqlc "github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/infrastructure/persistence/sqlc"
+)
+
+// PostgresLoginChallengeRepository implements repository.LoginChallengeRepository using sqlc
+type PostgresLoginChallengeRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewPostgresLoginChallengeRepository creates a new PostgreSQL login challenge repository
+func NewPostgresLoginChallengeRepository(db *sql.DB) repository.LoginChallengeRepository {
+	return &PostgresLoginChallengeRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+// Create stores a new challenge
+func (r *PostgresLoginChallengeRepository) Create(ctx context.Context, challenge *entity.LoginChallenge) error {
+	id, err := parseStringToUUID(challenge.ID)
+	if err != nil {
+		return errors.New("invalid login challenge ID format")
+	}
+	uid, err := parseStringToUUID(challenge.UserID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	err = r.queries.CreateLoginChallenge(ctx, sqlc.CreateLoginChallengeParams{
+		ID:        id,
+		UserID:    uid,
+		TokenHash: challenge.TokenHash,
+		ExpiresAt: challenge.ExpiresAt,
+		CreatedAt: challenge.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create login challenge: %w", err)
+	}
+
+	return nil
+}
+
+// GetByHash retrieves a challenge by the hash of the token
+func (r *PostgresLoginChallengeRepository) GetByHash(ctx context.Context, tokenHash string) (*entity.LoginChallenge, error) {
+	challenge, err := r.queries.GetLoginChallengeByHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrInvalidLoginChallenge
+		}
+		return nil, fmt.Errorf("failed to get login challenge: %w", err)
+	}
+
+	return toLoginChallengeEntity(&challenge), nil
+}
+
+// RecordFailure counts a wrong code entered for the challenge
+func (r *PostgresLoginChallengeRepository) RecordFailure(ctx context.Context, id string) error {
+	cid, err := parseStringToUUID(id)
+	if err != nil {
+		return errors.New("invalid login challenge ID format")
+	}
+
+	if err := r.queries.RecordLoginChallengeFailure(ctx, cid); err != nil {
+		return fmt.Errorf("failed to record login challenge failure: %w", err)
+	}
+
+	return nil
+}
+
+// MarkUsed uses up the challenge, of two concurrent uses only one succeeds
+func (r *PostgresLoginChallengeRepository) MarkUsed(ctx context.Context, id string, now time.Time) error {
+	cid, err := parseStringToUUID(id)
+	if err != nil {
+		return errors.New("invalid login challenge ID format")
+	}
+
+	rows, err := r.queries.MarkLoginChallengeUsed(ctx, sqlc.MarkLoginChallengeUsedParams{
+		ID:     cid,
+		UsedAt: sql.NullTime{Time: now.UTC(), Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark login challenge used: %w", err)
+	}
+	if rows == 0 {
+		return entity.ErrInvalidLoginChallenge
+	}
+
+	return nil
+}
+
+// toLoginChallengeEntity converts sqlc.LoginChallenge to domain entity
+func toLoginChallengeEntity(challenge *sqlc.LoginChallenge) *entity.LoginChallenge {
+	result := &entity.LoginChallenge{
+		ID:             challenge.ID.String(),
+		UserID:         challenge.UserID.String(),
+		TokenHash:      challenge.TokenHash,
+		ExpiresAt:      challenge.ExpiresAt,
+		FailedAttempts: int(challenge.FailedAttempts),
+		CreatedAt:      challenge.CreatedAt,
+	}
+	if challenge.UsedAt.Valid {
+		result.UsedAt = &challenge.UsedAt.Time
+	}
+	return result
+}
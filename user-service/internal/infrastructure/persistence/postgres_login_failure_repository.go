@@ -0,0 +1,99 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+	sqlc "github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/infrastructure/persistence/sqlc"
+)
+
+// PostgresLoginFailureRepository implements repository.LoginFailureRepository using sqlc
+type PostgresLoginFailureRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewPostgresLoginFailureRepository creates a new PostgreSQL login failure repository
+func NewPostgresLoginFailureRepository(db *sql.DB) repository.LoginFailureRepository {
+	return &PostgresLoginFailureRepository{
+		queries: sqlc.New(db),
+	}
+}
+
+// Get retrieves the failures of an account or IP
+func (r *PostgresLoginFailureRepository) Get(ctx context.Context, scope, subject string) (*entity.LoginFailure, error) {
+	failure, err := r.queries.GetLoginFailure(ctx, sqlc.GetLoginFailureParams{
+		Scope:   scope,
+		Subject: subject,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &entity.LoginFailure{Scope: scope, Subject: subject}, nil
+		}
+		return nil, fmt.Errorf("failed to get login failures: %w", err)
+	}
+
+	return toLoginFailureEntity(&failure), nil
+}
+
+// RecordFailure counts a failed login
+func (r *PostgresLoginFailureRepository) RecordFailure(ctx context.Context, scope, subject string, now time.Time, resetAfter time.Duration) (*entity.LoginFailure, error) {
+	now = now.UTC()
+
+	failure, err := r.queries.RecordLoginFailure(ctx, sqlc.RecordLoginFailureParams{
+		Scope:        scope,
+		Subject:      subject,
+		LastFailedAt: now,
+		ResetBefore:  now.Add(-resetAfter),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	return toLoginFailureEntity(&failure), nil
+}
+
+// Lock refuses logins of an account or IP until the given time
+func (r *PostgresLoginFailureRepository) Lock(ctx context.Context, scope, subject string, until time.Time) error {
+	err := r.queries.LockLoginFailure(ctx, sqlc.LockLoginFailureParams{
+		Scope:       scope,
+		Subject:     subject,
+		LockedUntil: sql.NullTime{Time: until.UTC(), Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to lock logins: %w", err)
+	}
+
+	return nil
+}
+
+// Reset forgets the failures of an account or IP
+func (r *PostgresLoginFailureRepository) Reset(ctx context.Context, scope, subject string) error {
+	err := r.queries.DeleteLoginFailure(ctx, sqlc.DeleteLoginFailureParams{
+		Scope:   scope,
+		Subject: subject,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset login failures: %w", err)
+	}
+
+	return nil
+}
+
+// toLoginFailureEntity converts sqlc.LoginFailure to domain entity
+func toLoginFailureEntity(failure *sqlc.LoginFailure) *entity.LoginFailure {
+	result := &entity.LoginFailure{
+		Scope:          failure.Scope,
+		Subject:        failure.Subject,
+		FailedAttempts: int(failure.FailedAttempts),
+		LastFailedAt:   failure.LastFailedAt,
+	}
+	if failure.LockedUntil.Valid {
+		result.LockedUntil = &failure.LockedUntil.Time
+	}
+	return result
+}
@@ -0,0 +1,154 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+	sqlc "github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/infrastructure/persistence/sqlc"
+)
+
+// PostgresPasswordResetTokenRepository implements repository.PasswordResetTokenRepository using sqlc
+type PostgresPasswordResetTokenRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+// NewPostgresPasswordResetTokenRepository creates a new PostgreSQL password reset token repository
+func NewPostgresPasswordResetTokenRepository(db *sql.DB) repository.PasswordResetTokenRepository {
+	return &PostgresPasswordResetTokenRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+// Create stores a new reset token, only the newest token of a user is usable
+func (r *PostgresPasswordResetTokenRepository) Create(ctx context.Context, token *entity.PasswordResetToken) error {
+	id, err := parseStringToUUID(token.ID)
+	if err != nil {
+		return errors.New("invalid password reset token ID format")
+	}
+	uid, err := parseStringToUUID(token.UserID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.InvalidateUserPasswordResetTokens(ctx, sqlc.InvalidateUserPasswordResetTokensParams{
+		UserID: uid,
+		UsedAt: sql.NullTime{Time: token.CreatedAt, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to invalidate password reset tokens: %w", err)
+	}
+
+	err = qtx.CreatePasswordResetToken(ctx, sqlc.CreatePasswordResetTokenParams{
+		ID:        id,
+		UserID:    uid,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByHash retrieves a reset token by the hash of the token
+func (r *PostgresPasswordResetTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error) {
+	token, err := r.queries.GetPasswordResetTokenByHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrInvalidPasswordResetToken
+		}
+		return nil, fmt.Errorf("failed to get password reset token: %w", err)
+	}
+
+	return toPasswordResetTokenEntity(&token), nil
+}
+
+// ResetPassword replaces the password and logs out every session. The token
+// is locked, so of two resets with the same token only one succeeds.
+func (r *PostgresPasswordResetTokenRepository) ResetPassword(ctx context.Context, tokenHash, passwordHash string, now time.Time) error {
+	now = now.UTC()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+	row, err := qtx.GetPasswordResetTokenByHashForUpdate(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.ErrInvalidPasswordResetToken
+		}
+		return fmt.Errorf("failed to lock password reset token: %w", err)
+	}
+	if !toPasswordResetTokenEntity(&row).IsUsable(now) {
+		return entity.ErrInvalidPasswordResetToken
+	}
+
+	err = qtx.MarkPasswordResetTokenUsed(ctx, sqlc.MarkPasswordResetTokenUsedParams{
+		ID:     row.ID,
+		UsedAt: sql.NullTime{Time: now, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark password reset token used: %w", err)
+	}
+
+	err = qtx.UpdateUserPassword(ctx, sqlc.UpdateUserPasswordParams{
+		ID:        row.UserID,
+		Password:  passwordHash,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	err = qtx.RevokeUserRefreshTokens(ctx, sqlc.RevokeUserRefreshTokensParams{
+		UserID:    row.UserID,
+		RevokedAt: sql.NullTime{Time: now, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// toPasswordResetTokenEntity converts sqlc.PasswordResetToken to domain entity
+func toPasswordResetTokenEntity(token *sqlc.PasswordResetToken) *entity.PasswordResetToken {
+	result := &entity.PasswordResetToken{
+		ID:        token.ID.String(),
+		UserID:    token.UserID.String(),
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	}
+	if token.UsedAt.Valid {
+		result.UsedAt = &token.UsedAt.Time
+	}
+	return result
+}
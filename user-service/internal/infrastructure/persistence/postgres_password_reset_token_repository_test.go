@@ -0,0 +1,80 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+)
+
+func TestResetPasswordTokenIsSingleUse(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := NewPostgresPasswordResetTokenRepository(db)
+	ctx := context.Background()
+	user := createTestUser(t, db)
+
+	token, raw, err := entity.NewPasswordResetToken(user.ID, time.Hour, time.Now())
+	if err != nil {
+		t.Fatalf("NewPasswordResetToken() error = %v", err)
+	}
+	if err := repo.Create(ctx, token); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	const resets = 10
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+	)
+	for i := 0; i < resets; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := repo.ResetPassword(ctx, entity.HashPasswordResetToken(raw), "new hash", time.Now())
+			if err != nil && !errors.Is(err, entity.ErrInvalidPasswordResetToken) {
+				t.Errorf("ResetPassword() error = %v", err)
+				return
+			}
+			if err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != 1 {
+		t.Errorf("%d of %d resets with the same token succeeded, want 1", succeeded, resets)
+	}
+}
+
+func TestNewPasswordResetTokenInvalidatesOlderOnes(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := NewPostgresPasswordResetTokenRepository(db)
+	ctx := context.Background()
+	user := createTestUser(t, db)
+
+	older, olderRaw, err := entity.NewPasswordResetToken(user.ID, time.Hour, time.Now())
+	if err != nil {
+		t.Fatalf("NewPasswordResetToken() error = %v", err)
+	}
+	if err := repo.Create(ctx, older); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	newer, _, err := entity.NewPasswordResetToken(user.ID, time.Hour, time.Now())
+	if err != nil {
+		t.Fatalf("NewPasswordResetToken() error = %v", err)
+	}
+	if err := repo.Create(ctx, newer); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if err := repo.ResetPassword(ctx, entity.HashPasswordResetToken(olderRaw), "new hash", time.Now()); !errors.Is(err, entity.ErrInvalidPasswordResetToken) {
+		t.Errorf("ResetPassword() with the older token error = %v, want ErrInvalidPasswordResetToken", err)
+	}
+}
@@ -0,0 +1,199 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+	sqlc "github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/infrastructure/persistence/sqlc"
+)
+
+// PostgresRefreshTokenRepository implements repository.RefreshTokenRepository using sqlc
+type PostgresRefreshTokenRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+// NewPostgresRefreshTokenRepository creates a new PostgreSQL refresh token repository
+func NewPostgresRefreshTokenRepository(db *sql.DB) repository.RefreshTokenRepository {
+	return &PostgresRefreshTokenRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+// Create stores a new refresh token
+func (r *PostgresRefreshTokenRepository) Create(ctx context.Context, token *entity.RefreshToken) error {
+	return createRefreshToken(ctx, r.queries, token)
+}
+
+// GetByHash retrieves a refresh token by the hash of the token
+func (r *PostgresRefreshTokenRepository) GetByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	token, err := r.queries.GetRefreshTokenByHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+
+	return toRefreshTokenEntity(&token), nil
+}
+
+// Rotate replaces a refresh token by the next one of its family. The token
+// is locked, so of two refreshes with the same token only one succeeds and
+// the other is treated as reuse.
+func (r *PostgresRefreshTokenRepository) Rotate(ctx context.Context, tokenHash string, next *entity.RefreshToken) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+	row, err := qtx.GetRefreshTokenByHashForUpdate(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entity.ErrInvalidRefreshToken
+		}
+		return fmt.Errorf("failed to lock refresh token: %w", err)
+	}
+	current := toRefreshTokenEntity(&row)
+
+	if current.UsedAt != nil {
+		err = qtx.RevokeRefreshTokenFamily(ctx, sqlc.RevokeRefreshTokenFamilyParams{
+			FamilyID:  row.FamilyID,
+			RevokedAt: sql.NullTime{Time: next.CreatedAt, Valid: true},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to revoke refresh token family: %w", err)
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return entity.ErrRefreshTokenReused
+	}
+
+	if !current.IsUsable(next.CreatedAt) {
+		return entity.ErrInvalidRefreshToken
+	}
+
+	next.UserID = current.UserID
+	next.FamilyID = current.FamilyID
+	if err := createRefreshToken(ctx, qtx, next); err != nil {
+		return err
+	}
+
+	nextID, err := parseStringToUUID(next.ID)
+	if err != nil {
+		return errors.New("invalid refresh token ID format")
+	}
+	err = qtx.MarkRefreshTokenUsed(ctx, sqlc.MarkRefreshTokenUsedParams{
+		ID:         row.ID,
+		UsedAt:     sql.NullTime{Time: next.CreatedAt, Valid: true},
+		ReplacedBy: uuid.NullUUID{UUID: nextID, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark refresh token used: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeFamily revokes every token of a login
+func (r *PostgresRefreshTokenRepository) RevokeFamily(ctx context.Context, familyID string, now time.Time) error {
+	fid, err := parseStringToUUID(familyID)
+	if err != nil {
+		return errors.New("invalid refresh token family ID format")
+	}
+
+	err = r.queries.RevokeRefreshTokenFamily(ctx, sqlc.RevokeRefreshTokenFamilyParams{
+		FamilyID:  fid,
+		RevokedAt: sql.NullTime{Time: now.UTC(), Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh token family: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAllForUser revokes every token of the user
+func (r *PostgresRefreshTokenRepository) RevokeAllForUser(ctx context.Context, userID string, now time.Time) error {
+	uid, err := parseStringToUUID(userID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	err = r.queries.RevokeUserRefreshTokens(ctx, sqlc.RevokeUserRefreshTokensParams{
+		UserID:    uid,
+		RevokedAt: sql.NullTime{Time: now.UTC(), Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	return nil
+}
+
+// createRefreshToken stores a refresh token with the given queries, which
+// may be bound to a transaction
+func createRefreshToken(ctx context.Context, queries *sqlc.Queries, token *entity.RefreshToken) error {
+	id, err := parseStringToUUID(token.ID)
+	if err != nil {
+		return errors.New("invalid refresh token ID format")
+	}
+	uid, err := parseStringToUUID(token.UserID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+	fid, err := parseStringToUUID(token.FamilyID)
+	if err != nil {
+		return errors.New("invalid refresh token family ID format")
+	}
+
+	err = queries.CreateRefreshToken(ctx, sqlc.CreateRefreshTokenParams{
+		ID:        id,
+		UserID:    uid,
+		FamilyID:  fid,
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+
+	return nil
+}
+
+// toRefreshTokenEntity converts sqlc.RefreshToken to domain entity
+func toRefreshTokenEntity(token *sqlc.RefreshToken) *entity.RefreshToken {
+	result := &entity.RefreshToken{
+		ID:        token.ID.String(),
+		UserID:    token.UserID.String(),
+		FamilyID:  token.FamilyID.String(),
+		TokenHash: token.TokenHash,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	}
+	if token.UsedAt.Valid {
+		result.UsedAt = &token.UsedAt.Time
+	}
+	if token.ReplacedBy.Valid {
+		result.ReplacedBy = token.ReplacedBy.UUID.String()
+	}
+	if token.RevokedAt.Valid {
+		result.RevokedAt = &token.RevokedAt.Time
+	}
+	return result
+}
@@ -0,0 +1,128 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+)
+
+// openTestDatabase connects to a migrated user database, e.g. the one
+// `make test-integration` starts:
+// USER_TEST_DATABASE_URL="host=localhost port=55432 user=postgres password=postgres dbname=userdb sslmode=disable"
+func openTestDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+
+	dsn := os.Getenv("USER_TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("USER_TEST_DATABASE_URL is not set")
+	}
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	if err := db.Ping(); err != nil {
+		t.Fatalf("failed to ping database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func createTestUser(t *testing.T, db *sql.DB) *entity.User {
+	t.Helper()
+
+	now := time.Now().UTC()
+	user := &entity.User{
+		ID:        uuid.New().String(),
+		Email:     uuid.New().String() + "@example.com",
+		Password:  "not a real hash",
+		Role:      entity.RoleCustomer,
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := NewPostgresUserRepository(db).Create(context.Background(), user); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	// Tokens of the user are deleted with it
+	t.Cleanup(func() { db.Exec("DELETE FROM users WHERE id = $1", user.ID) })
+	return user
+}
+
+func TestRotateRefreshTokenDetectsReuse(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := NewPostgresRefreshTokenRepository(db)
+	ctx := context.Background()
+	user := createTestUser(t, db)
+
+	login, loginToken, err := entity.NewRefreshToken(user.ID, uuid.New().String(), time.Hour, time.Now())
+	if err != nil {
+		t.Fatalf("NewRefreshToken() error = %v", err)
+	}
+	if err := repo.Create(ctx, login); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// The first refresh replaces the login token with one of the same family
+	next, nextToken, err := entity.NewRefreshToken("", "", time.Hour, time.Now())
+	if err != nil {
+		t.Fatalf("NewRefreshToken() error = %v", err)
+	}
+	if err := repo.Rotate(ctx, entity.HashRefreshToken(loginToken), next); err != nil {
+		t.Fatalf("Rotate() error = %v", err)
+	}
+	if next.UserID != user.ID || next.FamilyID != login.FamilyID {
+		t.Errorf("rotated token = %+v, want user %s and family %s", next, user.ID, login.FamilyID)
+	}
+	replaced, err := repo.GetByHash(ctx, login.TokenHash)
+	if err != nil {
+		t.Fatalf("GetByHash() error = %v", err)
+	}
+	if replaced.UsedAt == nil || replaced.ReplacedBy != next.ID {
+		t.Errorf("replaced token = %+v, want it used and replaced by %s", replaced, next.ID)
+	}
+
+	// Presenting the replaced token again revokes the whole family
+	stolen, _, err := entity.NewRefreshToken("", "", time.Hour, time.Now())
+	if err != nil {
+		t.Fatalf("NewRefreshToken() error = %v", err)
+	}
+	if err := repo.Rotate(ctx, entity.HashRefreshToken(loginToken), stolen); !errors.Is(err, entity.ErrRefreshTokenReused) {
+		t.Fatalf("Rotate() with a replaced token error = %v, want ErrRefreshTokenReused", err)
+	}
+	current, err := repo.GetByHash(ctx, entity.HashRefreshToken(nextToken))
+	if err != nil {
+		t.Fatalf("GetByHash() error = %v", err)
+	}
+	if current.RevokedAt == nil {
+		t.Error("the family's current token was not revoked")
+	}
+
+	again, _, err := entity.NewRefreshToken("", "", time.Hour, time.Now())
+	if err != nil {
+		t.Fatalf("NewRefreshToken() error = %v", err)
+	}
+	if err := repo.Rotate(ctx, entity.HashRefreshToken(nextToken), again); !errors.Is(err, entity.ErrInvalidRefreshToken) {
+		t.Errorf("Rotate() with a revoked token error = %v, want ErrInvalidRefreshToken", err)
+	}
+}
+
+func TestRotateUnknownRefreshToken(t *testing.T) {
+	db := openTestDatabase(t)
+	repo := NewPostgresRefreshTokenRepository(db)
+
+	next, _, err := entity.NewRefreshToken("", "", time.Hour, time.Now())
+	if err != nil {
+		t.Fatalf("NewRefreshToken() error = %v", err)
+	}
+	if err := repo.Rotate(context.Background(), entity.HashRefreshToken("unknown"), next); !errors.Is(err, entity.ErrInvalidRefreshToken) {
+		t.Errorf("Rotate() error = %v, want ErrInvalidRefreshToken", err)
+	}
+}
@@ -0,0 +1,179 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+	sqlc "github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/infrastructure/persistence/sqlc"
+)
+
+// PostgresRoleRepository implements repository.RoleRepository using sqlc
+type PostgresRoleRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+// NewPostgresRoleRepository creates a new PostgreSQL role repository
+func NewPostgresRoleRepository(db *sql.DB) repository.RoleRepository {
+	return &PostgresRoleRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+// Create creates a role with its permissions
+func (r *PostgresRoleRepository) Create(ctx context.Context, role *entity.Role) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+	err = qtx.CreateRole(ctx, sqlc.CreateRoleParams{
+		Name:        role.Name,
+		Description: role.Description,
+		CreatedAt:   role.CreatedAt,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("%w: %s", entity.ErrRoleAlreadyExists, role.Name)
+		}
+		return fmt.Errorf("failed to create role: %w", err)
+	}
+
+	if err := addRolePermissions(ctx, qtx, role.Name, role.Permissions); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetByName retrieves a role with its permissions
+func (r *PostgresRoleRepository) GetByName(ctx context.Context, name string) (*entity.Role, error) {
+	row, err := r.queries.GetRole(ctx, name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", entity.ErrRoleNotFound, name)
+		}
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+
+	permissions, err := r.queries.ListRolePermissions(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role permissions: %w", err)
+	}
+
+	role := toRoleEntity(row)
+	role.Permissions = permissions
+	return role, nil
+}
+
+// List retrieves all roles with their permissions
+func (r *PostgresRoleRepository) List(ctx context.Context) ([]*entity.Role, error) {
+	rows, err := r.queries.ListRoles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+
+	rolePermissions, err := r.queries.ListAllRolePermissions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role permissions: %w", err)
+	}
+	permissionsByRole := make(map[string][]string)
+	for _, rp := range rolePermissions {
+		permissionsByRole[rp.RoleName] = append(permissionsByRole[rp.RoleName], rp.PermissionName)
+	}
+
+	roles := make([]*entity.Role, len(rows))
+	for i, row := range rows {
+		roles[i] = toRoleEntity(row)
+		roles[i].Permissions = permissionsByRole[row.Name]
+		if roles[i].Permissions == nil {
+			roles[i].Permissions = []string{}
+		}
+	}
+	return roles, nil
+}
+
+// SetPermissions replaces the permissions of a role. The role is locked, so
+// concurrent changes do not merge their permissions.
+func (r *PostgresRoleRepository) SetPermissions(ctx context.Context, name string, permissions []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+	if _, err := qtx.GetRoleForUpdate(ctx, name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: %s", entity.ErrRoleNotFound, name)
+		}
+		return fmt.Errorf("failed to lock role: %w", err)
+	}
+
+	if err := qtx.DeleteRolePermissions(ctx, name); err != nil {
+		return fmt.Errorf("failed to clear role permissions: %w", err)
+	}
+	if err := addRolePermissions(ctx, qtx, name, permissions); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListPermissions retrieves the permissions roles can grant
+func (r *PostgresRoleRepository) ListPermissions(ctx context.Context) ([]entity.Permission, error) {
+	rows, err := r.queries.ListPermissions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list permissions: %w", err)
+	}
+
+	permissions := make([]entity.Permission, len(rows))
+	for i, row := range rows {
+		permissions[i] = entity.Permission{
+			Name:        row.Name,
+			Description: row.Description,
+		}
+	}
+	return permissions, nil
+}
+
+// addRolePermissions grants the permissions within the caller's transaction
+func addRolePermissions(ctx context.Context, qtx *sqlc.Queries, roleName string, permissions []string) error {
+	for _, permission := range permissions {
+		err := qtx.AddRolePermission(ctx, sqlc.AddRolePermissionParams{
+			RoleName:       roleName,
+			PermissionName: permission,
+		})
+		if err != nil {
+			if isForeignKeyViolation(err) {
+				return fmt.Errorf("%w: %s", entity.ErrUnknownPermission, permission)
+			}
+			return fmt.Errorf("failed to add role permission: %w", err)
+		}
+	}
+	return nil
+}
+
+// toRoleEntity converts sqlc.Role to domain entity
+func toRoleEntity(row sqlc.Role) *entity.Role {
+	return &entity.Role{
+		Name:        row.Name,
+		Description: row.Description,
+		CreatedAt:   row.CreatedAt,
+	}
+}
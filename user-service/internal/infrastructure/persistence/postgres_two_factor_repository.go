@@ -0,0 +1,206 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
+	sqlc "github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/infrastructure/persistence/sqlc"
+)
+
+// PostgresTwoFactorRepository implements repository.TwoFactorRepository using sqlc
+type PostgresTwoFactorRepository struct {
+	db      *sql.DB
+	queries *sqlc.Queries
+}
+
+// NewPostgresTwoFactorRepository creates a new PostgreSQL two-factor repository
+func NewPostgresTwoFactorRepository(db *sql.DB) repository.TwoFactorRepository {
+	return &PostgresTwoFactorRepository{
+		db:      db,
+		queries: sqlc.New(db),
+	}
+}
+
+// GetByUserID retrieves the authenticator of a user
+func (r *PostgresTwoFactorRepository) GetByUserID(ctx context.Context, userID string) (*entity.TwoFactor, error) {
+	uid, err := parseStringToUUID(userID)
+	if err != nil {
+		return nil, errors.New("invalid user ID format")
+	}
+
+	twoFactor, err := r.queries.GetTwoFactor(ctx, uid)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, entity.ErrTwoFactorNotEnabled
+		}
+		return nil, fmt.Errorf("failed to get two-factor authentication: %w", err)
+	}
+
+	return toTwoFactorEntity(&twoFactor), nil
+}
+
+// StartEnrolment stores a new secret unless two-factor authentication is enabled
+func (r *PostgresTwoFactorRepository) StartEnrolment(ctx context.Context, twoFactor *entity.TwoFactor) error {
+	uid, err := parseStringToUUID(twoFactor.UserID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	rows, err := r.queries.StartTwoFactorEnrolment(ctx, sqlc.StartTwoFactorEnrolmentParams{
+		UserID:    uid,
+		Secret:    twoFactor.Secret,
+		CreatedAt: twoFactor.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start two-factor enrolment: %w", err)
+	}
+	if rows == 0 {
+		return entity.ErrTwoFactorAlreadyEnabled
+	}
+
+	return nil
+}
+
+// Enable confirms the enrolment and replaces the recovery codes in one transaction
+func (r *PostgresTwoFactorRepository) Enable(ctx context.Context, userID string, step int64, recoveryCodes []*entity.RecoveryCode, now time.Time) error {
+	uid, err := parseStringToUUID(userID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+	rows, err := qtx.EnableTwoFactor(ctx, sqlc.EnableTwoFactorParams{
+		UserID:       uid,
+		EnabledAt:    sql.NullTime{Time: now.UTC(), Valid: true},
+		LastUsedStep: step,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+	if rows == 0 {
+		// Enabled concurrently, or the code was already used
+		return entity.ErrInvalidTwoFactorCode
+	}
+
+	if err := qtx.DeleteRecoveryCodes(ctx, uid); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	for _, code := range recoveryCodes {
+		id, err := parseStringToUUID(code.ID)
+		if err != nil {
+			return errors.New("invalid recovery code ID format")
+		}
+
+		err = qtx.CreateRecoveryCode(ctx, sqlc.CreateRecoveryCodeParams{
+			ID:        id,
+			UserID:    uid,
+			CodeHash:  code.CodeHash,
+			CreatedAt: code.CreatedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create recovery code: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UseStep accepts a code of the given time step once
+func (r *PostgresTwoFactorRepository) UseStep(ctx context.Context, userID string, step int64) error {
+	uid, err := parseStringToUUID(userID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	rows, err := r.queries.UseTwoFactorStep(ctx, sqlc.UseTwoFactorStepParams{
+		UserID:       uid,
+		LastUsedStep: step,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to use two-factor code: %w", err)
+	}
+	if rows == 0 {
+		return entity.ErrInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+// UseRecoveryCode marks an unused recovery code used
+func (r *PostgresTwoFactorRepository) UseRecoveryCode(ctx context.Context, userID, codeHash string, now time.Time) error {
+	uid, err := parseStringToUUID(userID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	rows, err := r.queries.UseRecoveryCode(ctx, sqlc.UseRecoveryCodeParams{
+		UserID:   uid,
+		CodeHash: codeHash,
+		UsedAt:   sql.NullTime{Time: now.UTC(), Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	if rows == 0 {
+		return entity.ErrInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+// Disable removes the authenticator and the recovery codes in one transaction
+func (r *PostgresTwoFactorRepository) Disable(ctx context.Context, userID string) error {
+	uid, err := parseStringToUUID(userID)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	qtx := r.queries.WithTx(tx)
+	if err := qtx.DeleteRecoveryCodes(ctx, uid); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	if err := qtx.DeleteTwoFactor(ctx, uid); err != nil {
+		return fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// toTwoFactorEntity converts sqlc.UserTwoFactor to domain entity
+func toTwoFactorEntity(twoFactor *sqlc.UserTwoFactor) *entity.TwoFactor {
+	result := &entity.TwoFactor{
+		UserID:       twoFactor.UserID.String(),
+		Secret:       twoFactor.Secret,
+		LastUsedStep: twoFactor.LastUsedStep,
+		CreatedAt:    twoFactor.CreatedAt,
+	}
+	if twoFactor.EnabledAt.Valid {
+		result.EnabledAt = &twoFactor.EnabledAt.Time
+	}
+	return result
+}
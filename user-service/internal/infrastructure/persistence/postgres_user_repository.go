@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/repository"
 	sqlc "github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/infrastructure/persistence/sqlc"
@@ -49,6 +50,9 @@ func (r *PostgresUserRepository) Create(ctx context.Context, user *entity.User)
 	})
 
 	if err != nil {
+		if isUniqueViolation(err) {
+			return entity.ErrEmailAlreadyExists
+		}
 		return errors.New("failed to create user")
 	}
 
@@ -65,7 +69,7 @@ func (r *PostgresUserRepository) GetByID(ctx context.Context, id string) (*entit
 	user, err := r.queries.GetUserByID(ctx, uid)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("user not found")
+			return nil, entity.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -78,7 +82,7 @@ func (r *PostgresUserRepository) GetByEmail(ctx context.Context, email string) (
 	user, err := r.queries.GetUserByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, errors.New("user not found")
+			return nil, entity.ErrUserNotFound
 		}
 		return nil, err
 	}
@@ -102,12 +106,38 @@ func (r *PostgresUserRepository) Update(ctx context.Context, user *entity.User)
 	})
 
 	if err != nil {
+		if isUniqueViolation(err) {
+			return entity.ErrEmailAlreadyExists
+		}
 		return errors.New("failed to update user")
 	}
 
 	return nil
 }
 
+// UpdateRole assigns an existing role to the user
+func (r *PostgresUserRepository) UpdateRole(ctx context.Context, id, role string) error {
+	uid, err := parseStringToUUID(id)
+	if err != nil {
+		return errors.New("invalid user ID format")
+	}
+
+	err = r.queries.UpdateUserRole(ctx, sqlc.UpdateUserRoleParams{
+		ID:        uid,
+		Role:      role,
+		UpdatedAt: time.Now().UTC(),
+	})
+
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return entity.ErrRoleNotFound
+		}
+		return errors.New("failed to update user role")
+	}
+
+	return nil
+}
+
 // Delete soft deletes a user (sets IsActive to false)
 func (r *PostgresUserRepository) Delete(ctx context.Context, id string) error {
 	uid, err := parseStringToUUID(id)
@@ -146,13 +176,36 @@ func (r *PostgresUserRepository) List(ctx context.Context, limit, offset int) ([
 	return result, nil
 }
 
+// Count counts the active users
+func (r *PostgresUserRepository) Count(ctx context.Context) (int64, error) {
+	count, err := r.queries.CountActiveUsers(ctx)
+	if err != nil {
+		return 0, errors.New("failed to count users")
+	}
+
+	return count, nil
+}
+
+// isUniqueViolation checks if the error is a unique constraint violation,
+// the only unique column besides the ID is the email
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// isForeignKeyViolation checks if the error is a foreign key violation
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
+
 // toEntity converts sqlc.User to domain entity
 func toEntity(user *sqlc.User) *entity.User {
 	if user == nil {
 		return nil
 	}
 
-	return &entity.User{
+	result := &entity.User{
 		ID:        user.ID.String(),
 		Email:     user.Email,
 		Password:  user.Password,
@@ -163,4 +216,8 @@ func toEntity(user *sqlc.User) *entity.User {
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}
+	if user.EmailVerifiedAt.Valid {
+		result.EmailVerifiedAt = &user.EmailVerifiedAt.Time
+	}
+	return result
 }
@@ -0,0 +1,22 @@
+-- name: CreateEmailVerificationToken :exec
+INSERT INTO email_verification_tokens (
+    id, user_id, token_hash, expires_at, created_at, email
+) VALUES (
+             $1, $2, $3, $4, $5, $6
+         );
+
+-- name: GetEmailVerificationTokenByHashForUpdate :one
+SELECT id, user_id, token_hash, expires_at, used_at, created_at, email
+FROM email_verification_tokens
+WHERE token_hash = $1
+FOR UPDATE;
+
+-- name: InvalidateUserEmailVerificationTokens :exec
+UPDATE email_verification_tokens
+SET used_at = $2
+WHERE user_id = $1 AND used_at IS NULL;
+
+-- name: MarkEmailVerificationTokenUsed :exec
+UPDATE email_verification_tokens
+SET used_at = $2
+WHERE id = $1;
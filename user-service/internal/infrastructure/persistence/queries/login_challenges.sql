@@ -0,0 +1,21 @@
+-- name: CreateLoginChallenge :exec
+INSERT INTO login_challenges (
+    id, user_id, token_hash, expires_at, created_at
+) VALUES (
+             $1, $2, $3, $4, $5
+         );
+
+-- name: GetLoginChallengeByHash :one
+SELECT id, user_id, token_hash, expires_at, used_at, failed_attempts, created_at
+FROM login_challenges
+WHERE token_hash = $1;
+
+-- name: MarkLoginChallengeUsed :execrows
+UPDATE login_challenges
+SET used_at = $2
+WHERE id = $1 AND used_at IS NULL;
+
+-- name: RecordLoginChallengeFailure :exec
+UPDATE login_challenges
+SET failed_attempts = failed_attempts + 1
+WHERE id = $1;
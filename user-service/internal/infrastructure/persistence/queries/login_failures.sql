@@ -0,0 +1,28 @@
+-- name: DeleteLoginFailure :exec
+DELETE FROM login_failures
+WHERE scope = $1 AND subject = $2;
+
+-- name: GetLoginFailure :one
+SELECT scope, subject, failed_attempts, last_failed_at, locked_until
+FROM login_failures
+WHERE scope = $1 AND subject = $2;
+
+-- name: LockLoginFailure :exec
+UPDATE login_failures
+SET locked_until = $3
+WHERE scope = $1 AND subject = $2;
+
+-- name: RecordLoginFailure :one
+INSERT INTO login_failures (
+    scope, subject, failed_attempts, last_failed_at
+) VALUES (
+             $1, $2, 1, $3
+         )
+ON CONFLICT (scope, subject) DO UPDATE
+SET
+    failed_attempts = CASE
+        WHEN login_failures.last_failed_at < sqlc.arg(reset_before) THEN 1
+        ELSE login_failures.failed_attempts + 1
+    END,
+    last_failed_at = EXCLUDED.last_failed_at
+RETURNING scope, subject, failed_attempts, last_failed_at, locked_until;
@@ -0,0 +1,27 @@
+-- name: CreatePasswordResetToken :exec
+INSERT INTO password_reset_tokens (
+    id, user_id, token_hash, expires_at, created_at
+) VALUES (
+             $1, $2, $3, $4, $5
+         );
+
+-- name: GetPasswordResetTokenByHash :one
+SELECT id, user_id, token_hash, expires_at, used_at, created_at
+FROM password_reset_tokens
+WHERE token_hash = $1;
+
+-- name: GetPasswordResetTokenByHashForUpdate :one
+SELECT id, user_id, token_hash, expires_at, used_at, created_at
+FROM password_reset_tokens
+WHERE token_hash = $1
+FOR UPDATE;
+
+-- name: InvalidateUserPasswordResetTokens :exec
+UPDATE password_reset_tokens
+SET used_at = $2
+WHERE user_id = $1 AND used_at IS NULL;
+
+-- name: MarkPasswordResetTokenUsed :exec
+UPDATE password_reset_tokens
+SET used_at = $2
+WHERE id = $1;
@@ -0,0 +1,32 @@
+-- name: CreateRefreshToken :exec
+INSERT INTO refresh_tokens (
+    id, user_id, family_id, token_hash, expires_at, created_at
+) VALUES (
+             $1, $2, $3, $4, $5, $6
+         );
+
+-- name: GetRefreshTokenByHash :one
+SELECT id, user_id, family_id, token_hash, expires_at, used_at, replaced_by, revoked_at, created_at
+FROM refresh_tokens
+WHERE token_hash = $1;
+
+-- name: GetRefreshTokenByHashForUpdate :one
+SELECT id, user_id, family_id, token_hash, expires_at, used_at, replaced_by, revoked_at, created_at
+FROM refresh_tokens
+WHERE token_hash = $1
+FOR UPDATE;
+
+-- name: MarkRefreshTokenUsed :exec
+UPDATE refresh_tokens
+SET used_at = $2, replaced_by = $3
+WHERE id = $1;
+
+-- name: RevokeRefreshTokenFamily :exec
+UPDATE refresh_tokens
+SET revoked_at = $2
+WHERE family_id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeUserRefreshTokens :exec
+UPDATE refresh_tokens
+SET revoked_at = $2
+WHERE user_id = $1 AND revoked_at IS NULL;
@@ -0,0 +1,43 @@
+-- name: CreateRole :exec
+INSERT INTO roles (name, description, created_at)
+VALUES ($1, $2, $3);
+
+-- name: GetRole :one
+SELECT name, description, created_at
+FROM roles
+WHERE name = $1;
+
+-- name: ListRoles :many
+SELECT name, description, created_at
+FROM roles
+ORDER BY name;
+
+-- name: ListPermissions :many
+SELECT name, description
+FROM permissions
+ORDER BY name;
+
+-- name: ListRolePermissions :many
+SELECT permission_name
+FROM role_permissions
+WHERE role_name = $1
+ORDER BY permission_name;
+
+-- name: ListAllRolePermissions :many
+SELECT role_name, permission_name
+FROM role_permissions
+ORDER BY role_name, permission_name;
+
+-- name: AddRolePermission :exec
+INSERT INTO role_permissions (role_name, permission_name)
+VALUES ($1, $2);
+
+-- name: DeleteRolePermissions :exec
+DELETE FROM role_permissions
+WHERE role_name = $1;
+
+-- name: GetRoleForUpdate :one
+SELECT name, description, created_at
+FROM roles
+WHERE name = $1
+FOR UPDATE;
@@ -0,0 +1,44 @@
+-- name: CreateRecoveryCode :exec
+INSERT INTO two_factor_recovery_codes (
+    id, user_id, code_hash, created_at
+) VALUES (
+             $1, $2, $3, $4
+         );
+
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM two_factor_recovery_codes
+WHERE user_id = $1;
+
+-- name: DeleteTwoFactor :exec
+DELETE FROM user_two_factor
+WHERE user_id = $1;
+
+-- name: EnableTwoFactor :execrows
+UPDATE user_two_factor
+SET enabled_at = $2, last_used_step = $3
+WHERE user_id = $1 AND enabled_at IS NULL AND last_used_step < $3;
+
+-- name: GetTwoFactor :one
+SELECT user_id, secret, enabled_at, last_used_step, created_at
+FROM user_two_factor
+WHERE user_id = $1;
+
+-- name: StartTwoFactorEnrolment :execrows
+INSERT INTO user_two_factor (
+    user_id, secret, last_used_step, created_at
+) VALUES (
+             $1, $2, 0, $3
+         )
+ON CONFLICT (user_id) DO UPDATE
+SET secret = EXCLUDED.secret, last_used_step = 0, created_at = EXCLUDED.created_at
+WHERE user_two_factor.enabled_at IS NULL;
+
+-- name: UseRecoveryCode :execrows
+UPDATE two_factor_recovery_codes
+SET used_at = $3
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: UseTwoFactorStep :execrows
+UPDATE user_two_factor
+SET last_used_step = $2
+WHERE user_id = $1 AND enabled_at IS NOT NULL AND last_used_step < $2;
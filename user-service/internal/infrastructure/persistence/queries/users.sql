@@ -6,12 +6,12 @@ INSERT INTO users (
          );
 
 -- name: GetUserByID :one
-SELECT id, email, password, first_name, last_name, role, is_active, created_at, updated_at
+SELECT id, email, password, first_name, last_name, role, is_active, created_at, updated_at, email_verified_at
 FROM users
 WHERE id = $1 AND is_active = true;
 
 -- name: GetUserByEmail :one
-SELECT id, email, password, first_name, last_name, role, is_active, created_at, updated_at
+SELECT id, email, password, first_name, last_name, role, is_active, created_at, updated_at, email_verified_at
 FROM users
 WHERE email = $1;
 
@@ -21,6 +21,8 @@ SET
     first_name = COALESCE($2, first_name),
     last_name = COALESCE($3, last_name),
     email = COALESCE($4, email),
+    -- a changed email has to be verified again
+    email_verified_at = CASE WHEN email = COALESCE($4, email) THEN email_verified_at END,
     updated_at = $5
 WHERE id = $1;
 
@@ -30,7 +32,7 @@ SET is_active = false, updated_at = $2
 WHERE id = $1;
 
 -- name: ListUsers :many
-SELECT id, email, password, first_name, last_name, role, is_active, created_at, updated_at
+SELECT id, email, password, first_name, last_name, role, is_active, created_at, updated_at, email_verified_at
 FROM users
 WHERE is_active = true
 ORDER BY created_at DESC
@@ -38,3 +40,18 @@ LIMIT $1 OFFSET $2;
 
 -- name: CountActiveUsers :one
 SELECT COUNT(*) FROM users WHERE is_active = true;
+
+-- name: UpdateUserRole :exec
+UPDATE users
+SET role = $2, updated_at = $3
+WHERE id = $1;
+
+-- name: MarkUserEmailVerified :execrows
+UPDATE users
+SET email_verified_at = $2, updated_at = $3
+WHERE id = $1 AND email = $4 AND is_active = true;
+
+-- name: UpdateUserPassword :exec
+UPDATE users
+SET password = $2, updated_at = $3
+WHERE id = $1;
@@ -11,13 +11,56 @@ import (
 )
 
 type Querier interface {
+	AddRolePermission(ctx context.Context, arg AddRolePermissionParams) error
 	CountActiveUsers(ctx context.Context) (int64, error)
+	CreateEmailVerificationToken(ctx context.Context, arg CreateEmailVerificationTokenParams) error
+	CreateLoginChallenge(ctx context.Context, arg CreateLoginChallengeParams) error
+	CreatePasswordResetToken(ctx context.Context, arg CreatePasswordResetTokenParams) error
+	CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) error
+	CreateRefreshToken(ctx context.Context, arg CreateRefreshTokenParams) error
+	CreateRole(ctx context.Context, arg CreateRoleParams) error
 	CreateUser(ctx context.Context, arg CreateUserParams) error
+	DeleteLoginFailure(ctx context.Context, arg DeleteLoginFailureParams) error
+	DeleteRecoveryCodes(ctx context.Context, userID uuid.UUID) error
+	DeleteRolePermissions(ctx context.Context, roleName string) error
+	DeleteTwoFactor(ctx context.Context, userID uuid.UUID) error
 	DeleteUser(ctx context.Context, arg DeleteUserParams) error
+	EnableTwoFactor(ctx context.Context, arg EnableTwoFactorParams) (int64, error)
+	GetEmailVerificationTokenByHashForUpdate(ctx context.Context, tokenHash string) (EmailVerificationToken, error)
+	GetLoginChallengeByHash(ctx context.Context, tokenHash string) (LoginChallenge, error)
+	GetLoginFailure(ctx context.Context, arg GetLoginFailureParams) (LoginFailure, error)
+	GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (PasswordResetToken, error)
+	GetPasswordResetTokenByHashForUpdate(ctx context.Context, tokenHash string) (PasswordResetToken, error)
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (RefreshToken, error)
+	GetRefreshTokenByHashForUpdate(ctx context.Context, tokenHash string) (RefreshToken, error)
+	GetRole(ctx context.Context, name string) (Role, error)
+	GetRoleForUpdate(ctx context.Context, name string) (Role, error)
+	GetTwoFactor(ctx context.Context, userID uuid.UUID) (UserTwoFactor, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (User, error)
+	InvalidateUserEmailVerificationTokens(ctx context.Context, arg InvalidateUserEmailVerificationTokensParams) error
+	InvalidateUserPasswordResetTokens(ctx context.Context, arg InvalidateUserPasswordResetTokensParams) error
+	ListAllRolePermissions(ctx context.Context) ([]RolePermission, error)
+	ListPermissions(ctx context.Context) ([]Permission, error)
+	ListRolePermissions(ctx context.Context, roleName string) ([]string, error)
+	ListRoles(ctx context.Context) ([]Role, error)
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	LockLoginFailure(ctx context.Context, arg LockLoginFailureParams) error
+	MarkEmailVerificationTokenUsed(ctx context.Context, arg MarkEmailVerificationTokenUsedParams) error
+	MarkLoginChallengeUsed(ctx context.Context, arg MarkLoginChallengeUsedParams) (int64, error)
+	MarkPasswordResetTokenUsed(ctx context.Context, arg MarkPasswordResetTokenUsedParams) error
+	MarkRefreshTokenUsed(ctx context.Context, arg MarkRefreshTokenUsedParams) error
+	MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) (int64, error)
+	RecordLoginChallengeFailure(ctx context.Context, id uuid.UUID) error
+	RecordLoginFailure(ctx context.Context, arg RecordLoginFailureParams) (LoginFailure, error)
+	RevokeRefreshTokenFamily(ctx context.Context, arg RevokeRefreshTokenFamilyParams) error
+	RevokeUserRefreshTokens(ctx context.Context, arg RevokeUserRefreshTokensParams) error
+	StartTwoFactorEnrolment(ctx context.Context, arg StartTwoFactorEnrolmentParams) (int64, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) error
+	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) error
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error)
+	UseTwoFactorStep(ctx context.Context, arg UseTwoFactorStepParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -7,6 +7,7 @@ package persistence
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/google/uuid"
@@ -75,7 +76,7 @@ func (q *Queries) DeleteUser(ctx context.Context, arg DeleteUserParams) error {
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, password, first_name, last_name, role, is_active, created_at, updated_at
+SELECT id, email, password, first_name, last_name, role, is_active, created_at, updated_at, email_verified_at
 FROM users
 WHERE email = $1
 `
@@ -93,12 +94,13 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, password, first_name, last_name, role, is_active, created_at, updated_at
+SELECT id, email, password, first_name, last_name, role, is_active, created_at, updated_at, email_verified_at
 FROM users
 WHERE id = $1 AND is_active = true
 `
@@ -116,12 +118,13 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.EmailVerifiedAt,
 	)
 	return i, err
 }
 
 const listUsers = `-- name: ListUsers :many
-SELECT id, email, password, first_name, last_name, role, is_active, created_at, updated_at
+SELECT id, email, password, first_name, last_name, role, is_active, created_at, updated_at, email_verified_at
 FROM users
 WHERE is_active = true
 ORDER BY created_at DESC
@@ -152,6 +155,7 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.EmailVerifiedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -166,12 +170,40 @@ func (q *Queries) ListUsers(ctx context.Context, arg ListUsersParams) ([]User, e
 	return items, nil
 }
 
+const markUserEmailVerified = `-- name: MarkUserEmailVerified :execrows
+UPDATE users
+SET email_verified_at = $2, updated_at = $3
+WHERE id = $1 AND email = $4 AND is_active = true
+`
+
+type MarkUserEmailVerifiedParams struct {
+	ID              uuid.UUID    `json:"id"`
+	EmailVerifiedAt sql.NullTime `json:"email_verified_at"`
+	UpdatedAt       time.Time    `json:"updated_at"`
+	Email           string       `json:"email"`
+}
+
+func (q *Queries) MarkUserEmailVerified(ctx context.Context, arg MarkUserEmailVerifiedParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markUserEmailVerified,
+		arg.ID,
+		arg.EmailVerifiedAt,
+		arg.UpdatedAt,
+		arg.Email,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateUser = `-- name: UpdateUser :exec
 UPDATE users
 SET
     first_name = COALESCE($2, first_name),
     last_name = COALESCE($3, last_name),
     email = COALESCE($4, email),
+    -- a changed email has to be verified again
+    email_verified_at = CASE WHEN email = COALESCE($4, email) THEN email_verified_at END,
     updated_at = $5
 WHERE id = $1
 `
@@ -194,3 +226,37 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) error {
 	)
 	return err
 }
+
+const updateUserPassword = `-- name: UpdateUserPassword :exec
+UPDATE users
+SET password = $2, updated_at = $3
+WHERE id = $1
+`
+
+type UpdateUserPasswordParams struct {
+	ID        uuid.UUID `json:"id"`
+	Password  string    `json:"password"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserPassword, arg.ID, arg.Password, arg.UpdatedAt)
+	return err
+}
+
+const updateUserRole = `-- name: UpdateUserRole :exec
+UPDATE users
+SET role = $2, updated_at = $3
+WHERE id = $1
+`
+
+type UpdateUserRoleParams struct {
+	ID        uuid.UUID `json:"id"`
+	Role      string    `json:"role"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (q *Queries) UpdateUserRole(ctx context.Context, arg UpdateUserRoleParams) error {
+	_, err := q.db.ExecContext(ctx, updateUserRole, arg.ID, arg.Role, arg.UpdatedAt)
+	return err
+}
@@ -0,0 +1,172 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/service"
+)
+
+// Issuer is the iss claim of every token signed by the user service
+const Issuer = "user-service"
+
+// AlgorithmHS256 is the signing algorithm of tokens signed with a shared secret
+const AlgorithmHS256 = "HS256"
+
+// Claims are the JWT claims of an access token
+type Claims struct {
+	Subject       string   `json:"sub"`
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+	Role          string   `json:"role"`
+	Permissions   []string `json:"permissions"`
+	Issuer        string   `json:"iss"`
+	IssuedAt      int64    `json:"iat"`
+	ExpiresAt     int64    `json:"exp"`
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyID     string `json:"kid,omitempty"`
+}
+
+// JWTTokenService implements service.TokenService with JWTs signed either
+// with a shared secret (HS256) or with asymmetric keys (RS256 or EdDSA)
+type JWTTokenService struct {
+	secret []byte
+	// signingKey signs new tokens, keys are all keys published for verifying
+	signingKey *SigningKey
+	keys       []*SigningKey
+	expiry     time.Duration
+	now        func() time.Time
+}
+
+// NewJWTTokenService creates a token service signing with the given secret
+func NewJWTTokenService(secret string, expiry time.Duration) (service.TokenService, error) {
+	if secret == "" {
+		return nil, errors.New("JWT secret must not be empty")
+	}
+	if expiry <= 0 {
+		return nil, errors.New("JWT expiry must be positive")
+	}
+
+	return &JWTTokenService{
+		secret: []byte(secret),
+		expiry: expiry,
+		now:    time.Now,
+	}, nil
+}
+
+// NewAsymmetricJWTTokenService creates a token service signing with the key
+// activeKeyID. The other keys are kept published, so tokens signed before a
+// key rotation can be verified until they expire.
+func NewAsymmetricJWTTokenService(keys []*SigningKey, activeKeyID string, expiry time.Duration) (service.TokenService, error) {
+	if expiry <= 0 {
+		return nil, errors.New("JWT expiry must be positive")
+	}
+
+	seen := make(map[string]bool, len(keys))
+	var signingKey *SigningKey
+	for _, key := range keys {
+		if seen[key.ID] {
+			return nil, fmt.Errorf("duplicate signing key ID %s", key.ID)
+		}
+		seen[key.ID] = true
+		if key.ID == activeKeyID {
+			signingKey = key
+		}
+	}
+	if signingKey == nil {
+		return nil, fmt.Errorf("active signing key %q not found", activeKeyID)
+	}
+
+	return &JWTTokenService{
+		signingKey: signingKey,
+		keys:       keys,
+		expiry:     expiry,
+		now:        time.Now,
+	}, nil
+}
+
+// Issue creates a signed token for the user
+func (s *JWTTokenService) Issue(user *entity.User, permissions []string) (*service.Token, error) {
+	issuedAt := s.now().UTC().Truncate(time.Second)
+	expiresAt := issuedAt.Add(s.expiry)
+	if permissions == nil {
+		permissions = []string{}
+	}
+
+	header := jwtHeader{Algorithm: AlgorithmHS256, Type: "JWT"}
+	if s.signingKey != nil {
+		header.Algorithm = s.signingKey.Algorithm
+		header.KeyID = s.signingKey.ID
+	}
+
+	encodedHeader, err := encodeSegment(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode token header: %w", err)
+	}
+	payload, err := encodeSegment(Claims{
+		Subject:       user.ID,
+		Email:         user.Email,
+		EmailVerified: user.IsEmailVerified(),
+		Role:          user.Role,
+		Permissions:   permissions,
+		Issuer:        Issuer,
+		IssuedAt:      issuedAt.Unix(),
+		ExpiresAt:     expiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode token claims: %w", err)
+	}
+
+	signingInput := encodedHeader + "." + payload
+	signature, err := s.sign([]byte(signingInput))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign token: %w", err)
+	}
+
+	return &service.Token{
+		Value:     signingInput + "." + base64.RawURLEncoding.EncodeToString(signature),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// PublicKeys returns the public part of every published signing key
+func (s *JWTTokenService) PublicKeys() []service.PublicKey {
+	publicKeys := make([]service.PublicKey, len(s.keys))
+	for i, key := range s.keys {
+		publicKeys[i] = service.PublicKey{
+			ID:        key.ID,
+			Algorithm: key.Algorithm,
+			Key:       key.Public(),
+		}
+	}
+	return publicKeys
+}
+
+func (s *JWTTokenService) sign(signingInput []byte) ([]byte, error) {
+	if s.signingKey != nil {
+		return s.signingKey.Sign(signingInput)
+	}
+
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write(signingInput)
+	return mac.Sum(nil), nil
+}
+
+// encodeSegment encodes a JWT header or payload as unpadded base64url JSON
+func encodeSegment(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
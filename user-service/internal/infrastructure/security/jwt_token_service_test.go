@@ -0,0 +1,102 @@
+package security
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/auth"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+)
+
+func newTestUser() *entity.User {
+	verifiedAt := time.Now().UTC()
+	return &entity.User{
+		ID:              "u-1",
+		Email:           "ada@example.com",
+		Role:            entity.RoleCustomer,
+		EmailVerifiedAt: &verifiedAt,
+	}
+}
+
+func TestNewJWTTokenService(t *testing.T) {
+	if _, err := NewJWTTokenService("", time.Hour); err == nil {
+		t.Error("expected an empty secret to be rejected")
+	}
+	if _, err := NewJWTTokenService("secret", 0); err == nil {
+		t.Error("expected a zero expiry to be rejected")
+	}
+}
+
+func TestHS256TokenRoundTrip(t *testing.T) {
+	tokens, err := NewJWTTokenService("secret", 15*time.Minute)
+	if err != nil {
+		t.Fatalf("NewJWTTokenService() error = %v", err)
+	}
+
+	token, err := tokens.Issue(newTestUser(), []string{auth.PermissionOrdersReadOwn})
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	if until := time.Until(token.ExpiresAt); until <= 14*time.Minute || until > 15*time.Minute {
+		t.Errorf("ExpiresAt is %v from now, want 15m", until)
+	}
+
+	principal, err := auth.NewHMACVerifier("secret", Issuer).Verify(token.Value)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if principal.UserID != "u-1" || principal.Email != "ada@example.com" || !principal.EmailVerified ||
+		principal.Role != entity.RoleCustomer || !principal.HasPermission(auth.PermissionOrdersReadOwn) {
+		t.Errorf("principal = %+v", principal)
+	}
+	if !principal.ExpiresAt.Equal(token.ExpiresAt) {
+		t.Errorf("principal expires at %v, want %v", principal.ExpiresAt, token.ExpiresAt)
+	}
+}
+
+func TestHS256TokenRejected(t *testing.T) {
+	tokens, err := NewJWTTokenService("secret", 15*time.Minute)
+	if err != nil {
+		t.Fatalf("NewJWTTokenService() error = %v", err)
+	}
+	token, err := tokens.Issue(newTestUser(), nil)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	// Issued an hour ago, well past the verifier's clock skew
+	past := &JWTTokenService{
+		secret: []byte("secret"),
+		expiry: 15 * time.Minute,
+		now:    func() time.Time { return time.Now().Add(-time.Hour) },
+	}
+	expiredToken, err := past.Issue(newTestUser(), nil)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	parts := strings.Split(token.Value, ".")
+	tampered := parts[0] + "." + parts[1] + "x." + parts[2]
+
+	tests := []struct {
+		name     string
+		verifier *auth.HMACVerifier
+		token    string
+		wantErr  error
+	}{
+		{name: "other secret", verifier: auth.NewHMACVerifier("other", Issuer), token: token.Value, wantErr: auth.ErrInvalidToken},
+		{name: "other issuer", verifier: auth.NewHMACVerifier("secret", "someone-else"), token: token.Value, wantErr: auth.ErrInvalidToken},
+		{name: "tampered claims", verifier: auth.NewHMACVerifier("secret", Issuer), token: tampered, wantErr: auth.ErrInvalidToken},
+		{name: "not a JWT", verifier: auth.NewHMACVerifier("secret", Issuer), token: "abc", wantErr: auth.ErrInvalidToken},
+		{name: "expired", verifier: auth.NewHMACVerifier("secret", Issuer), token: expiredToken.Value, wantErr: auth.ErrExpiredToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.verifier.Verify(tt.token); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Signing algorithms of the asymmetric keys
+const (
+	AlgorithmRS256 = "RS256"
+	AlgorithmEdDSA = "EdDSA"
+)
+
+// minRSAKeyBits is the smallest RSA key accepted for signing tokens
+const minRSAKeyBits = 2048
+
+// SigningKey is a private key tokens are signed with. Its ID is sent as the
+// kid header, so verifiers can pick the matching public key.
+type SigningKey struct {
+	ID        string
+	Algorithm string
+	key       crypto.Signer
+}
+
+// NewSigningKey wraps an RSA or Ed25519 private key
+func NewSigningKey(id string, key crypto.Signer) (*SigningKey, error) {
+	if id == "" {
+		return nil, errors.New("signing key ID must not be empty")
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA signing key %s must have at least %d bits", id, minRSAKeyBits)
+		}
+		return &SigningKey{ID: id, Algorithm: AlgorithmRS256, key: k}, nil
+	case ed25519.PrivateKey:
+		return &SigningKey{ID: id, Algorithm: AlgorithmEdDSA, key: k}, nil
+	default:
+		return nil, fmt.Errorf("signing key %s must be an RSA or Ed25519 key", id)
+	}
+}
+
+// ParseSigningKey reads a PEM encoded PKCS#8 or PKCS#1 private key
+func ParseSigningKey(id string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("signing key %s is not PEM encoded", id)
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("signing key %s has unsupported PEM type %q", id, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key %s: %w", id, err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("signing key %s must be an RSA or Ed25519 key", id)
+	}
+	return NewSigningKey(id, signer)
+}
+
+// LoadSigningKeys reads every <kid>.pem file of the directory, sorted by kid
+func LoadSigningKeys(dir string) ([]*SigningKey, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no signing keys found in %s", dir)
+	}
+	sort.Strings(paths)
+
+	keys := make([]*SigningKey, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read signing key: %w", err)
+		}
+
+		key, err := ParseSigningKey(strings.TrimSuffix(filepath.Base(path), ".pem"), data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// Sign signs the JWT signing input with the key's algorithm
+func (k *SigningKey) Sign(signingInput []byte) ([]byte, error) {
+	switch k.Algorithm {
+	case AlgorithmRS256:
+		digest := sha256.Sum256(signingInput)
+		return k.key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	default:
+		// Ed25519 signs the message itself
+		return k.key.Sign(rand.Reader, signingInput, crypto.Hash(0))
+	}
+}
+
+// Public returns the public key tokens signed with this key are verified with
+func (k *SigningKey) Public() crypto.PublicKey {
+	return k.key.Public()
+}
@@ -0,0 +1,144 @@
+package security
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/auth"
+)
+
+func TestNewSigningKey(t *testing.T) {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() error = %v", err)
+	}
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+
+	if key, err := NewSigningKey("ed", edKey); err != nil || key.Algorithm != AlgorithmEdDSA {
+		t.Errorf("NewSigningKey(ed25519) = %+v, %v, want EdDSA", key, err)
+	}
+	if _, err := NewSigningKey("", edKey); err == nil {
+		t.Error("expected an empty key ID to be rejected")
+	}
+	if _, err := NewSigningKey("weak", weakKey); err == nil {
+		t.Error("expected an RSA key below 2048 bits to be rejected")
+	}
+}
+
+func TestLoadSigningKeys(t *testing.T) {
+	dir := t.TempDir()
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() error = %v", err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(edKey)
+	if err != nil {
+		t.Fatalf("x509.MarshalPKCS8PrivateKey() error = %v", err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+
+	files := map[string]*pem.Block{
+		"2026-02.pem": {Type: "PRIVATE KEY", Bytes: pkcs8},
+		"2026-01.pem": {Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)},
+	}
+	for name, block := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), pem.EncodeToMemory(block), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	// Only .pem files are keys
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("keys"), 0o600); err != nil {
+		t.Fatalf("failed to write README: %v", err)
+	}
+
+	keys, err := LoadSigningKeys(dir)
+	if err != nil {
+		t.Fatalf("LoadSigningKeys() error = %v", err)
+	}
+	if len(keys) != 2 ||
+		keys[0].ID != "2026-01" || keys[0].Algorithm != AlgorithmRS256 ||
+		keys[1].ID != "2026-02" || keys[1].Algorithm != AlgorithmEdDSA {
+		t.Errorf("LoadSigningKeys() = %+v, %+v, want the RSA key 2026-01 and the Ed25519 key 2026-02", keys[0], keys[1])
+	}
+
+	if _, err := LoadSigningKeys(t.TempDir()); err == nil {
+		t.Error("expected an empty directory to be rejected")
+	}
+	if _, err := ParseSigningKey("broken", []byte("not pem")); err == nil {
+		t.Error("expected a key that is not PEM encoded to be rejected")
+	}
+	if _, err := ParseSigningKey("cert", pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: pkcs8})); err == nil {
+		t.Error("expected an unsupported PEM type to be rejected")
+	}
+}
+
+func TestAsymmetricTokensSurviveKeyRotation(t *testing.T) {
+	_, oldKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() error = %v", err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+	old, _ := NewSigningKey("old", oldKey)
+	current, _ := NewSigningKey("current", rsaKey)
+
+	if _, err := NewAsymmetricJWTTokenService([]*SigningKey{old, current}, "missing", time.Hour); err == nil {
+		t.Error("expected an unknown active key to be rejected")
+	}
+	if _, err := NewAsymmetricJWTTokenService([]*SigningKey{old, old}, "old", time.Hour); err == nil {
+		t.Error("expected duplicate key IDs to be rejected")
+	}
+
+	before, err := NewAsymmetricJWTTokenService([]*SigningKey{old}, "old", time.Hour)
+	if err != nil {
+		t.Fatalf("NewAsymmetricJWTTokenService() error = %v", err)
+	}
+	after, err := NewAsymmetricJWTTokenService([]*SigningKey{old, current}, "current", time.Hour)
+	if err != nil {
+		t.Fatalf("NewAsymmetricJWTTokenService() error = %v", err)
+	}
+	oldToken, err := before.Issue(newTestUser(), nil)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	newToken, err := after.Issue(newTestUser(), nil)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+
+	publicKeys := make([]auth.PublicKey, 0, 2)
+	for _, key := range after.PublicKeys() {
+		publicKeys = append(publicKeys, auth.PublicKey{ID: key.ID, Algorithm: key.Algorithm, Key: key.Key})
+	}
+	verifier := auth.NewPublicKeyVerifier(publicKeys, Issuer)
+
+	if _, err := verifier.Verify(oldToken.Value); err != nil {
+		t.Errorf("Verify() of a token signed before the rotation error = %v", err)
+	}
+	if _, err := verifier.Verify(newToken.Value); err != nil {
+		t.Errorf("Verify() of a token signed after the rotation error = %v", err)
+	}
+
+	// Once the old key is retired its tokens are rejected
+	retired := auth.NewPublicKeyVerifier(publicKeys[1:], Issuer)
+	if _, err := retired.Verify(oldToken.Value); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Errorf("Verify() with the old key retired error = %v, want ErrInvalidToken", err)
+	}
+}
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/usecase"
+)
+
+// AdminUserHandler handles HTTP requests of admins managing users
+type AdminUserHandler struct {
+	listUsersUseCase  *usecase.ListUsersUseCase
+	getUserUseCase    *usecase.GetUserUseCase
+	updateUserUseCase *usecase.UpdateUserUseCase
+	deleteUserUseCase *usecase.DeleteUserUseCase
+}
+
+// NewAdminUserHandler creates a new admin user handler
+func NewAdminUserHandler(
+	listUsersUseCase *usecase.ListUsersUseCase,
+	getUserUseCase *usecase.GetUserUseCase,
+	updateUserUseCase *usecase.UpdateUserUseCase,
+	deleteUserUseCase *usecase.DeleteUserUseCase,
+) *AdminUserHandler {
+	return &AdminUserHandler{
+		listUsersUseCase:  listUsersUseCase,
+		getUserUseCase:    getUserUseCase,
+		updateUserUseCase: updateUserUseCase,
+		deleteUserUseCase: deleteUserUseCase,
+	}
+}
+
+// ListUsers handles listing users
+// @Summary List users
+// @Description Return active users, newest first, with the total number of active users. Requires users:read:any.
+// @Tags admin
+// @Produce json
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param offset query int false "Number of users to skip" default(0)
+// @Success 200 {object} dto.ListUsersResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/users [get]
+func (h *AdminUserHandler) ListUsers(c *gin.Context) {
+	var query dto.ListUsersQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	users, err := h.listUsersUseCase.Execute(c.Request.Context(), query)
+	if err != nil {
+		respondWithUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+// GetUser handles fetching a user
+// @Summary Get a user
+// @Description Return an active user. Requires users:read:any.
+// @Tags admin
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/users/{id} [get]
+func (h *AdminUserHandler) GetUser(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.getUserUseCase.Execute(c.Request.Context(), userID)
+	if err != nil {
+		respondWithUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// UpdateUser handles changes to a user
+// @Summary Update a user
+// @Description Change the name or email of a user, only the given fields change. Requires users:write:any.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body dto.UpdateUserRequest true "User changes"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string "Email is used by another user"
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/users/{id} [patch]
+func (h *AdminUserHandler) UpdateUser(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req dto.UpdateUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.updateUserUseCase.Execute(c.Request.Context(), userID, req)
+	if err != nil {
+		respondWithUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// DeleteUser handles deactivating a user
+// @Summary Delete a user
+// @Description Deactivate a user and revoke its refresh tokens. Requires users:write:any.
+// @Tags admin
+// @Param id path string true "User ID"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/users/{id} [delete]
+func (h *AdminUserHandler) DeleteUser(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.deleteUserUseCase.Execute(c.Request.Context(), userID); err != nil {
+		respondWithUserError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
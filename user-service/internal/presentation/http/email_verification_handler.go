@@ -0,0 +1,89 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/usecase"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+)
+
+// EmailVerificationHandler handles HTTP requests for verifying email addresses
+type EmailVerificationHandler struct {
+	verifyEmailUseCase             *usecase.VerifyEmailUseCase
+	resendEmailVerificationUseCase *usecase.ResendEmailVerificationUseCase
+}
+
+// NewEmailVerificationHandler creates a new email verification handler
+func NewEmailVerificationHandler(
+	verifyEmailUseCase *usecase.VerifyEmailUseCase,
+	resendEmailVerificationUseCase *usecase.ResendEmailVerificationUseCase,
+) *EmailVerificationHandler {
+	return &EmailVerificationHandler{
+		verifyEmailUseCase:             verifyEmailUseCase,
+		resendEmailVerificationUseCase: resendEmailVerificationUseCase,
+	}
+}
+
+// VerifyEmail handles verifying an email with the emailed token
+// @Summary Verify email
+// @Description Mark the user's email verified. Access tokens issued from the next login or refresh on carry the verification.
+// @Tags users
+// @Accept json
+// @Param request body dto.VerifyEmailRequest true "Verification token"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/users/verify-email [post]
+func (h *EmailVerificationHandler) VerifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Execute use case
+	if err := h.verifyEmailUseCase.Execute(c.Request.Context(), req); err != nil {
+		if errors.Is(err, entity.ErrInvalidEmailVerificationToken) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ResendVerification handles requests for a new verification email
+// @Summary Resend verification email
+// @Description Email a new verification token, the previous ones stop working. The response is the same whether the email is registered or not.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.ResendVerificationRequest true "Account email"
+// @Success 202 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/users/verify-email/resend [post]
+func (h *EmailVerificationHandler) ResendVerification(c *gin.Context) {
+	var req dto.ResendVerificationRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Execute use case
+	if err := h.resendEmailVerificationUseCase.Execute(c.Request.Context(), req); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "if the email is registered and not verified yet, a verification link has been sent"})
+}
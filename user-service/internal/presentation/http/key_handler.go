@@ -0,0 +1,33 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/usecase"
+)
+
+// KeyHandler publishes the keys other services verify access tokens with
+type KeyHandler struct {
+	getJWKSUseCase *usecase.GetJWKSUseCase
+}
+
+// NewKeyHandler creates a new key handler
+func NewKeyHandler(getJWKSUseCase *usecase.GetJWKSUseCase) *KeyHandler {
+	return &KeyHandler{
+		getJWKSUseCase: getJWKSUseCase,
+	}
+}
+
+// JWKS handles the JSON Web Key Set endpoint
+// @Summary JSON Web Key Set
+// @Description Public keys access tokens are verified with, matched by the kid header of the token. Empty when tokens are signed with a shared secret.
+// @Tags keys
+// @Produce json
+// @Success 200 {object} dto.JWKSResponse
+// @Router /.well-known/jwks.json [get]
+func (h *KeyHandler) JWKS(c *gin.Context) {
+	// Verifiers may cache the keys, a rotated in key is published before it signs
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.getJWKSUseCase.Execute())
+}
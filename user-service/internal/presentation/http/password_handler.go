@@ -0,0 +1,89 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/usecase"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+)
+
+// PasswordHandler handles HTTP requests for recovering an account
+type PasswordHandler struct {
+	requestPasswordResetUseCase *usecase.RequestPasswordResetUseCase
+	resetPasswordUseCase        *usecase.ResetPasswordUseCase
+}
+
+// NewPasswordHandler creates a new password handler
+func NewPasswordHandler(
+	requestPasswordResetUseCase *usecase.RequestPasswordResetUseCase,
+	resetPasswordUseCase *usecase.ResetPasswordUseCase,
+) *PasswordHandler {
+	return &PasswordHandler{
+		requestPasswordResetUseCase: requestPasswordResetUseCase,
+		resetPasswordUseCase:        resetPasswordUseCase,
+	}
+}
+
+// ForgotPassword handles requests for a password reset email
+// @Summary Request a password reset
+// @Description Email a single-use password reset token to the user. The response is the same whether the email is registered or not.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.ForgotPasswordRequest true "Account email"
+// @Success 202 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/users/password/forgot [post]
+func (h *PasswordHandler) ForgotPassword(c *gin.Context) {
+	var req dto.ForgotPasswordRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Execute use case
+	if err := h.requestPasswordResetUseCase.Execute(c.Request.Context(), req); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "if the email is registered, a password reset link has been sent"})
+}
+
+// ResetPassword handles setting a new password with a reset token
+// @Summary Reset the password
+// @Description Set a new password with a password reset token and revoke every refresh token of the user
+// @Tags users
+// @Accept json
+// @Param request body dto.ResetPasswordRequest true "Reset token and new password"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/users/password/reset [post]
+func (h *PasswordHandler) ResetPassword(c *gin.Context) {
+	var req dto.ResetPasswordRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Execute use case
+	if err := h.resetPasswordUseCase.Execute(c.Request.Context(), req); err != nil {
+		if errors.Is(err, entity.ErrInvalidPasswordResetToken) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,116 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/auth"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/usecase"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+)
+
+// ProfileHandler handles HTTP requests of users managing their own profile
+type ProfileHandler struct {
+	getUserUseCase    *usecase.GetUserUseCase
+	updateUserUseCase *usecase.UpdateUserUseCase
+}
+
+// NewProfileHandler creates a new profile handler
+func NewProfileHandler(
+	getUserUseCase *usecase.GetUserUseCase,
+	updateUserUseCase *usecase.UpdateUserUseCase,
+) *ProfileHandler {
+	return &ProfileHandler{
+		getUserUseCase:    getUserUseCase,
+		updateUserUseCase: updateUserUseCase,
+	}
+}
+
+// GetProfile handles fetching the authenticated user
+// @Summary Get own profile
+// @Description Return the profile of the authenticated user
+// @Tags users
+// @Produce json
+// @Success 200 {object} dto.UserResponse
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/users/me [get]
+func (h *ProfileHandler) GetProfile(c *gin.Context) {
+	principal, ok := auth.PrincipalFromGin(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": auth.ErrMissingToken.Error()})
+		return
+	}
+
+	user, err := h.getUserUseCase.Execute(c.Request.Context(), principal.UserID)
+	if err != nil {
+		respondWithUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// UpdateProfile handles changes to the authenticated user
+// @Summary Update own profile
+// @Description Change the name or email of the authenticated user, only the given fields change
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.UpdateUserRequest true "Profile changes"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string "Email is used by another user"
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/users/me [patch]
+func (h *ProfileHandler) UpdateProfile(c *gin.Context) {
+	principal, ok := auth.PrincipalFromGin(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": auth.ErrMissingToken.Error()})
+		return
+	}
+
+	var req dto.UpdateUserRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.updateUserUseCase.Execute(c.Request.Context(), principal.UserID, req)
+	if err != nil {
+		respondWithUserError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// userIDParam reads and validates the user ID path parameter
+func userIDParam(c *gin.Context) (string, bool) {
+	userID := c.Param("id")
+	if _, err := uuid.Parse(userID); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user ID format"})
+		return "", false
+	}
+	return userID, true
+}
+
+// respondWithUserError maps domain errors to HTTP status codes
+func respondWithUserError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrEmailAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
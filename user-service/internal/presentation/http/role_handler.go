@@ -0,0 +1,189 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/usecase"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+)
+
+// RoleHandler handles HTTP requests for managing roles and permissions
+type RoleHandler struct {
+	listRolesUseCase          *usecase.ListRolesUseCase
+	createRoleUseCase         *usecase.CreateRoleUseCase
+	setRolePermissionsUseCase *usecase.SetRolePermissionsUseCase
+	listPermissionsUseCase    *usecase.ListPermissionsUseCase
+	assignUserRoleUseCase     *usecase.AssignUserRoleUseCase
+}
+
+// NewRoleHandler creates a new role handler
+func NewRoleHandler(
+	listRolesUseCase *usecase.ListRolesUseCase,
+	createRoleUseCase *usecase.CreateRoleUseCase,
+	setRolePermissionsUseCase *usecase.SetRolePermissionsUseCase,
+	listPermissionsUseCase *usecase.ListPermissionsUseCase,
+	assignUserRoleUseCase *usecase.AssignUserRoleUseCase,
+) *RoleHandler {
+	return &RoleHandler{
+		listRolesUseCase:          listRolesUseCase,
+		createRoleUseCase:         createRoleUseCase,
+		setRolePermissionsUseCase: setRolePermissionsUseCase,
+		listPermissionsUseCase:    listPermissionsUseCase,
+		assignUserRoleUseCase:     assignUserRoleUseCase,
+	}
+}
+
+// ListRoles handles listing roles
+// @Summary List roles
+// @Description Return all roles with the permissions they grant. Requires roles:manage.
+// @Tags roles
+// @Produce json
+// @Success 200 {object} dto.ListRolesResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/roles [get]
+func (h *RoleHandler) ListRoles(c *gin.Context) {
+	roles, err := h.listRolesUseCase.Execute(c.Request.Context())
+	if err != nil {
+		respondWithRoleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, roles)
+}
+
+// CreateRole handles creating a role
+// @Summary Create a role
+// @Description Create a role granting the given permissions. Requires roles:manage.
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param request body dto.CreateRoleRequest true "Role"
+// @Success 201 {object} dto.RoleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 409 {object} map[string]string "Role already exists"
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/roles [post]
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	var req dto.CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, err := h.createRoleUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		respondWithRoleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, role)
+}
+
+// SetRolePermissions handles replacing the permissions of a role
+// @Summary Set role permissions
+// @Description Replace the permissions a role grants. Users get them with their next access token. Requires roles:manage.
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param name path string true "Role name"
+// @Param request body dto.SetRolePermissionsRequest true "Permissions"
+// @Success 200 {object} dto.RoleResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string "Role can not be changed"
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/roles/{name}/permissions [put]
+func (h *RoleHandler) SetRolePermissions(c *gin.Context) {
+	var req dto.SetRolePermissionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, err := h.setRolePermissionsUseCase.Execute(c.Request.Context(), c.Param("name"), req)
+	if err != nil {
+		respondWithRoleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, role)
+}
+
+// ListPermissions handles listing permissions
+// @Summary List permissions
+// @Description Return all permissions roles can grant. Requires roles:manage.
+// @Tags roles
+// @Produce json
+// @Success 200 {object} dto.ListPermissionsResponse
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/permissions [get]
+func (h *RoleHandler) ListPermissions(c *gin.Context) {
+	permissions, err := h.listPermissionsUseCase.Execute(c.Request.Context())
+	if err != nil {
+		respondWithRoleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, permissions)
+}
+
+// AssignUserRole handles changing the role of a user
+// @Summary Assign a role
+// @Description Change the role of a user. The user gets its permissions with the next access token. Requires roles:manage.
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body dto.AssignRoleRequest true "Role"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/users/{id}/role [put]
+func (h *RoleHandler) AssignUserRole(c *gin.Context) {
+	userID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req dto.AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.assignUserRoleUseCase.Execute(c.Request.Context(), userID, req)
+	if err != nil {
+		respondWithRoleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// respondWithRoleError maps domain errors to HTTP status codes
+func respondWithRoleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrRoleNotFound), errors.Is(err, entity.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrRoleAlreadyExists), errors.Is(err, entity.ErrProtectedRole):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrUnknownPermission), errors.Is(err, entity.ErrInvalidRoleName):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
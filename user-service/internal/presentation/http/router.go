@@ -2,15 +2,30 @@ package http
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/auth"
 )
 
 // SetupRouter configures all routes for the user service
-func SetupRouter(userHandler *UserHandler) *gin.Engine {
+func SetupRouter(
+	userHandler *UserHandler,
+	sessionHandler *SessionHandler,
+	passwordHandler *PasswordHandler,
+	emailVerificationHandler *EmailVerificationHandler,
+	keyHandler *KeyHandler,
+	profileHandler *ProfileHandler,
+	adminUserHandler *AdminUserHandler,
+	roleHandler *RoleHandler,
+	twoFactorHandler *TwoFactorHandler,
+	authMiddleware gin.HandlerFunc,
+) *gin.Engine {
 	router := gin.Default()
 
 	// Health check
 	router.GET("/health", userHandler.Health)
 
+	// Public keys for verifying access tokens
+	router.GET("/.well-known/jwks.json", keyHandler.JWKS)
+
 	// API v1 routes
 	v1 := router.Group("/api/v1")
 	{
@@ -18,7 +33,44 @@ func SetupRouter(userHandler *UserHandler) *gin.Engine {
 		{
 			users.POST("/register", userHandler.Register)
 			users.POST("/login", userHandler.Login)
+			users.POST("/login/2fa", userHandler.LoginTwoFactor)
+			users.POST("/refresh", sessionHandler.Refresh)
+			users.POST("/logout", sessionHandler.Logout)
+			users.POST("/logout-all", sessionHandler.LogoutAll)
+			users.POST("/password/forgot", passwordHandler.ForgotPassword)
+			users.POST("/password/reset", passwordHandler.ResetPassword)
+			users.POST("/verify-email", emailVerificationHandler.VerifyEmail)
+			users.POST("/verify-email/resend", emailVerificationHandler.ResendVerification)
+		}
+
+		// Own profile of the authenticated user
+		me := v1.Group("/users/me", authMiddleware)
+		{
+			me.GET("", profileHandler.GetProfile)
+			me.PATCH("", profileHandler.UpdateProfile)
+			me.POST("/2fa/enroll", twoFactorHandler.Enroll)
+			me.POST("/2fa/verify", twoFactorHandler.Confirm)
+			me.POST("/2fa/disable", twoFactorHandler.Disable)
+		}
+
+		// User management
+		admin := v1.Group("/users", authMiddleware)
+		{
+			admin.GET("", auth.RequirePermission(auth.PermissionUsersReadAny), adminUserHandler.ListUsers)
+			admin.GET("/:id", auth.RequirePermission(auth.PermissionUsersReadAny), adminUserHandler.GetUser)
+			admin.PATCH("/:id", auth.RequirePermission(auth.PermissionUsersWriteAny), adminUserHandler.UpdateUser)
+			admin.DELETE("/:id", auth.RequirePermission(auth.PermissionUsersWriteAny), adminUserHandler.DeleteUser)
+			admin.PUT("/:id/role", auth.RequirePermission(auth.PermissionRolesManage), roleHandler.AssignUserRole)
+		}
+
+		// Roles and the permissions they grant
+		roles := v1.Group("/roles", authMiddleware, auth.RequirePermission(auth.PermissionRolesManage))
+		{
+			roles.GET("", roleHandler.ListRoles)
+			roles.POST("", roleHandler.CreateRole)
+			roles.PUT("/:name/permissions", roleHandler.SetRolePermissions)
 		}
+		v1.GET("/permissions", authMiddleware, auth.RequirePermission(auth.PermissionRolesManage), roleHandler.ListPermissions)
 	}
 
 	return router
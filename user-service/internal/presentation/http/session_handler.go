@@ -0,0 +1,131 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/usecase"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+)
+
+// SessionHandler handles HTTP requests for refreshing tokens and logging out
+type SessionHandler struct {
+	refreshTokenUseCase      *usecase.RefreshTokenUseCase
+	logoutUseCase            *usecase.LogoutUserUseCase
+	logoutAllSessionsUseCase *usecase.LogoutAllSessionsUseCase
+}
+
+// NewSessionHandler creates a new session handler
+func NewSessionHandler(
+	refreshTokenUseCase *usecase.RefreshTokenUseCase,
+	logoutUseCase *usecase.LogoutUserUseCase,
+	logoutAllSessionsUseCase *usecase.LogoutAllSessionsUseCase,
+) *SessionHandler {
+	return &SessionHandler{
+		refreshTokenUseCase:      refreshTokenUseCase,
+		logoutUseCase:            logoutUseCase,
+		logoutAllSessionsUseCase: logoutAllSessionsUseCase,
+	}
+}
+
+// Refresh handles exchanging a refresh token for new tokens
+// @Summary Refresh access token
+// @Description Exchange a refresh token for a new access token and a new refresh token. The presented refresh token can not be used again; presenting it again revokes the whole session.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} dto.LoginResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/users/refresh [post]
+func (h *SessionHandler) Refresh(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Execute use case
+	login, err := h.refreshTokenUseCase.Execute(c.Request.Context(), req)
+	if err != nil {
+		respondWithSessionError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, login)
+}
+
+// Logout handles ending the session of a refresh token
+// @Summary Log out
+// @Description Revoke the refresh token and every token rotated from the same login. Access tokens stay valid until they expire.
+// @Tags users
+// @Accept json
+// @Param request body dto.RefreshTokenRequest true "Refresh token"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/users/logout [post]
+func (h *SessionHandler) Logout(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Execute use case
+	if err := h.logoutUseCase.Execute(c.Request.Context(), req); err != nil {
+		respondWithSessionError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// LogoutAll handles ending every session of a user
+// @Summary Log out of all sessions
+// @Description Revoke every refresh token of the user owning the given refresh token. Access tokens stay valid until they expire.
+// @Tags users
+// @Accept json
+// @Param request body dto.RefreshTokenRequest true "Refresh token"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/users/logout-all [post]
+func (h *SessionHandler) LogoutAll(c *gin.Context) {
+	var req dto.RefreshTokenRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Execute use case
+	if err := h.logoutAllSessionsUseCase.Execute(c.Request.Context(), req); err != nil {
+		respondWithSessionError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondWithSessionError maps domain errors to HTTP status codes
+func respondWithSessionError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrInvalidRefreshToken),
+		errors.Is(err, entity.ErrRefreshTokenReused):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,149 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/shared/auth"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/usecase"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
+)
+
+// TwoFactorHandler handles HTTP requests of users managing their two-factor authentication
+type TwoFactorHandler struct {
+	startEnrolmentUseCase *usecase.StartTwoFactorEnrolmentUseCase
+	confirmUseCase        *usecase.ConfirmTwoFactorUseCase
+	disableUseCase        *usecase.DisableTwoFactorUseCase
+}
+
+// NewTwoFactorHandler creates a new two-factor handler
+func NewTwoFactorHandler(
+	startEnrolmentUseCase *usecase.StartTwoFactorEnrolmentUseCase,
+	confirmUseCase *usecase.ConfirmTwoFactorUseCase,
+	disableUseCase *usecase.DisableTwoFactorUseCase,
+) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		startEnrolmentUseCase: startEnrolmentUseCase,
+		confirmUseCase:        confirmUseCase,
+		disableUseCase:        disableUseCase,
+	}
+}
+
+// Enroll handles starting the two-factor enrolment of the authenticated user
+// @Summary Start two-factor enrolment
+// @Description Generate a TOTP secret and its otpauth URI for an authenticator app. Two-factor authentication is enabled once a code is confirmed.
+// @Tags users
+// @Produce json
+// @Success 200 {object} dto.TwoFactorEnrolmentResponse
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string "Two-factor authentication is already enabled"
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/users/me/2fa/enroll [post]
+func (h *TwoFactorHandler) Enroll(c *gin.Context) {
+	principal, ok := auth.PrincipalFromGin(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": auth.ErrMissingToken.Error()})
+		return
+	}
+
+	enrolment, err := h.startEnrolmentUseCase.Execute(c.Request.Context(), principal.UserID)
+	if err != nil {
+		respondWithTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, enrolment)
+}
+
+// Confirm handles enabling two-factor authentication
+// @Summary Confirm two-factor enrolment
+// @Description Enable two-factor authentication with a code from the authenticator app. The returned recovery codes are only shown once.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.TwoFactorCodeRequest true "Code from the authenticator app"
+// @Success 200 {object} dto.RecoveryCodesResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string "Enrolment not started or already enabled"
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/users/me/2fa/verify [post]
+func (h *TwoFactorHandler) Confirm(c *gin.Context) {
+	principal, ok := auth.PrincipalFromGin(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": auth.ErrMissingToken.Error()})
+		return
+	}
+
+	var req dto.TwoFactorCodeRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Execute use case
+	recoveryCodes, err := h.confirmUseCase.Execute(c.Request.Context(), principal.UserID, req)
+	if err != nil {
+		respondWithTwoFactorError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recoveryCodes)
+}
+
+// Disable handles turning two-factor authentication off
+// @Summary Disable two-factor authentication
+// @Description Disable two-factor authentication with a code from the authenticator app or a recovery code
+// @Tags users
+// @Accept json
+// @Param request body dto.TwoFactorCodeRequest true "Code or recovery code"
+// @Success 204
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 409 {object} map[string]string "Two-factor authentication is not enabled"
+// @Failure 500 {object} map[string]string
+// @Router /api/v1/users/me/2fa/disable [post]
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	principal, ok := auth.PrincipalFromGin(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": auth.ErrMissingToken.Error()})
+		return
+	}
+
+	var req dto.TwoFactorCodeRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Execute use case
+	if err := h.disableUseCase.Execute(c.Request.Context(), principal.UserID, req); err != nil {
+		respondWithTwoFactorError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// respondWithTwoFactorError maps two-factor domain errors to HTTP status codes
+func respondWithTwoFactorError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, entity.ErrInvalidTwoFactorCode):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, entity.ErrTwoFactorAlreadyEnabled),
+		errors.Is(err, entity.ErrTwoFactorNotEnrolled),
+		errors.Is(err, entity.ErrTwoFactorNotEnabled):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
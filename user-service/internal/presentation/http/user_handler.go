@@ -1,11 +1,15 @@
 package http
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/dto"
 	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/application/usecase"
+	"github.com/sandroapkhaidze/Golang-Microservices-Ecommerce/user-service/internal/domain/entity"
 )
 
 // UserHandler handles HTTP requests for user operations
@@ -57,14 +61,16 @@ func (h *UserHandler) Register(c *gin.Context) {
 
 // Login handles user authentication
 // @Summary User login
-// @Description Authenticate user and return user data
+// @Description Authenticate user and return a signed JWT access token with the user data. Users with two-factor authentication get a challenge instead, exchanged at /api/v1/users/login/2fa. Repeated failures delay and then temporarily lock further logins of the account and the client.
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param request body dto.LoginRequest true "Login credentials"
-// @Success 200 {object} dto.UserResponse
+// @Success 200 {object} dto.LoginResponse
+// @Success 202 {object} dto.TwoFactorChallengeResponse "Two-factor code required"
 // @Failure 400 {object} map[string]string
 // @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string "Too many failed logins, see the Retry-After header"
 // @Router /api/v1/users/login [post]
 func (h *UserHandler) Login(c *gin.Context) {
 	var req dto.LoginRequest
@@ -75,14 +81,65 @@ func (h *UserHandler) Login(c *gin.Context) {
 		return
 	}
 
+	req.IPAddress = c.ClientIP()
+
 	// Execute use case
-	user, err := h.loginUseCase.Execute(c.Request.Context(), req)
+	login, challenge, err := h.loginUseCase.Execute(c.Request.Context(), req)
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		respondWithLoginError(c, err)
+		return
+	}
+	if challenge != nil {
+		c.JSON(http.StatusAccepted, challenge)
 		return
 	}
 
-	c.JSON(http.StatusOK, user)
+	c.JSON(http.StatusOK, login)
+}
+
+// LoginTwoFactor handles the second step of a login with two-factor authentication
+// @Summary Complete login with a two-factor code
+// @Description Exchange the challenge of a login and a code from the authenticator app or a recovery code for a signed JWT access token. Wrong codes count as failed logins.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.TwoFactorLoginRequest true "Challenge and code"
+// @Success 200 {object} dto.LoginResponse
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 429 {object} map[string]string "Too many failed logins, see the Retry-After header"
+// @Router /api/v1/users/login/2fa [post]
+func (h *UserHandler) LoginTwoFactor(c *gin.Context) {
+	var req dto.TwoFactorLoginRequest
+
+	// Bind and validate request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	req.IPAddress = c.ClientIP()
+
+	// Execute use case
+	login, err := h.loginUseCase.CompleteTwoFactor(c.Request.Context(), req)
+	if err != nil {
+		respondWithLoginError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, login)
+}
+
+// respondWithLoginError answers throttled logins with 429 and Retry-After,
+// every other failed login with 401
+func respondWithLoginError(c *gin.Context, err error) {
+	var throttled *entity.LoginThrottledError
+	if errors.As(err, &throttled) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 }
 
 // Health check endpoint
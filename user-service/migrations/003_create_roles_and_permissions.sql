@@ -0,0 +1,63 @@
+-- Create roles and permissions tables
+-- Permissions are a fixed catalogue checked by the services, roles and the
+-- permissions they grant can be changed by admins.
+CREATE TABLE IF NOT EXISTS roles (
+    name VARCHAR(50) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+    );
+
+CREATE TABLE IF NOT EXISTS permissions (
+    name VARCHAR(100) PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT ''
+    );
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_name VARCHAR(50) NOT NULL REFERENCES roles(name) ON DELETE CASCADE,
+    permission_name VARCHAR(100) NOT NULL REFERENCES permissions(name) ON DELETE CASCADE,
+    PRIMARY KEY (role_name, permission_name)
+    );
+
+INSERT INTO permissions (name, description) VALUES
+    ('users:read:any', 'View any user'),
+    ('users:write:any', 'Change and deactivate any user'),
+    ('roles:manage', 'Manage roles, their permissions and role assignments'),
+    ('orders:create', 'Place orders'),
+    ('orders:read:own', 'View own orders'),
+    ('orders:read:any', 'View any order'),
+    ('orders:cancel:own', 'Cancel own orders'),
+    ('orders:cancel:any', 'Cancel any order'),
+    ('products:write', 'Create, change, import and deactivate products'),
+    ('prices:write', 'Schedule and cancel price changes'),
+    ('catalog:write', 'Manage categories and attributes'),
+    ('stock:read', 'View stock levels, movements, warehouses and reports'),
+    ('stock:write', 'Adjust and reconcile stock'),
+    ('warehouses:write', 'Manage warehouses')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO roles (name, description) VALUES
+    ('customer', 'Shops and manages own orders'),
+    ('inventory_manager', 'Manages products, prices and stock'),
+    ('admin', 'Full access')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_name, permission_name) VALUES
+    ('customer', 'orders:create'),
+    ('customer', 'orders:read:own'),
+    ('customer', 'orders:cancel:own'),
+    ('inventory_manager', 'orders:read:any'),
+    ('inventory_manager', 'products:write'),
+    ('inventory_manager', 'prices:write'),
+    ('inventory_manager', 'catalog:write'),
+    ('inventory_manager', 'stock:read'),
+    ('inventory_manager', 'stock:write'),
+    ('inventory_manager', 'warehouses:write')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO role_permissions (role_name, permission_name)
+SELECT 'admin', name FROM permissions
+ON CONFLICT DO NOTHING;
+
+-- Users can only have an existing role
+UPDATE users SET role = 'customer' WHERE role NOT IN (SELECT name FROM roles);
+ALTER TABLE users ADD CONSTRAINT fk_users_role FOREIGN KEY (role) REFERENCES roles(name);
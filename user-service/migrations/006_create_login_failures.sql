@@ -0,0 +1,12 @@
+-- Create login_failures table
+-- Counts recent failed logins per account and per client IP. Accounts are
+-- keyed by the normalized email, so unknown emails are throttled the same
+-- way as registered ones.
+CREATE TABLE IF NOT EXISTS login_failures (
+    scope VARCHAR(20) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    failed_attempts INTEGER NOT NULL DEFAULT 0,
+    last_failed_at TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP,
+    PRIMARY KEY (scope, subject)
+    );
@@ -0,0 +1,10 @@
+-- Completing an order sells its reserved stock, granted to the roles
+-- fulfilling orders
+INSERT INTO permissions (name, description) VALUES
+    ('orders:complete', 'Mark orders as fulfilled')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_name, permission_name) VALUES
+    ('inventory_manager', 'orders:complete'),
+    ('admin', 'orders:complete')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,16 @@
+-- A verification token only verifies the address it was sent to, so a token
+-- sent before the user changed the email can't verify the new address
+ALTER TABLE email_verification_tokens ADD COLUMN IF NOT EXISTS email VARCHAR(255);
+
+-- Which address the unused tokens were sent to is unknown, users request a
+-- new token instead
+UPDATE email_verification_tokens
+SET used_at = NOW()
+WHERE email IS NULL AND used_at IS NULL;
+
+UPDATE email_verification_tokens t
+SET email = u.email
+FROM users u
+WHERE t.user_id = u.id AND t.email IS NULL;
+
+ALTER TABLE email_verification_tokens ALTER COLUMN email SET NOT NULL;